@@ -0,0 +1,182 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=gateway-api
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Backend",type=string,JSONPath=`.spec.backendRef.name`
+// +kubebuilder:printcolumn:name="Fail Open",type=boolean,JSONPath=`.spec.failOpen`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GCPAuthzExtension is the CRD for an authorization extension.
+// It describes a service that implements the Envoy ext_authz gRPC protocol
+// and makes the authorization decisions of the CUSTOM GCPAuthzPolicies that
+// reference it in their customProviders.
+type GCPAuthzExtension struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of GCPAuthzExtension.
+	// +required
+	Spec GCPAuthzExtensionSpec `json:"spec,omitempty"`
+
+	// Status defines the current state of GCPAuthzExtension.
+	// +optional
+	Status GCPAuthzExtensionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCPAuthzExtensionList contains a list of GCPAuthzExtensions.
+type GCPAuthzExtensionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPAuthzExtension `json:"items"`
+}
+
+// GCPAuthzExtensionSpec defines the desired state of GCPAuthzExtension.
+// +kubebuilder:validation:XValidation:message="Only backendRefs of kind Service or ServiceImport are supported",rule="self.backendRef.kind == 'Service' || self.backendRef.kind == 'ServiceImport'"
+// +kubebuilder:validation:XValidation:message="timeout must be between 10-10000 milliseconds",rule="has(self.timeout) ? duration(self.timeout) >= duration('10ms') && duration(self.timeout) <= duration('10000ms') : true"
+// +kubebuilder:validation:XValidation:message="statusOnError cannot be set when failOpen is true",rule="!(has(self.failOpen) && self.failOpen && has(self.statusOnError))"
+type GCPAuthzExtensionSpec struct {
+	// BackendRef identifies the Service that implements the ext_authz gRPC
+	// protocol, in the namespace of the GCPAuthzExtension.
+	// Valid Kinds are:
+	// - "Service"
+	// - "ServiceImport"
+	//
+	// +required
+	BackendRef ExtensionServiceReference `json:"backendRef"`
+
+	// Authority is the `:authority` header in the gRPC requests sent from
+	// the load balancer to the extension.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1000
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9-_:%\.\[\]]*$`
+	// +required
+	Authority string `json:"authority"`
+
+	// Timeout specifies the timeout of each authorization check.
+	// The timeout must be between 10-10000 milliseconds.
+	// If omitted, the default timeout is 1000 milliseconds.
+	//
+	// +optional
+	Timeout *v1.Duration `json:"timeout,omitempty"`
+
+	// FailOpen determines how the load balancer behaves if the check fails
+	// or times out. When set to `TRUE`, the request is allowed. When set to
+	// `FALSE` or the default setting of `FALSE` is used, the request is
+	// rejected with StatusOnError.
+	//
+	// +optional
+	FailOpen bool `json:"failOpen,omitempty"`
+
+	// StatusOnError is the HTTP status code returned to the client when the
+	// check fails or times out and FailOpen is false.
+	// If omitted, the default status code is 403.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=599
+	StatusOnError *int32 `json:"statusOnError,omitempty"`
+
+	// ForwardHeaders is a list of the request headers sent to the extension
+	// in the check request. If omitted, all headers are sent.
+	// Limited to 50 headers.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	ForwardHeaders []HTTPHeaderName `json:"forwardHeaders,omitempty"`
+
+	// HeadersToUpstreamOnAllow is a list of the headers of an OK check
+	// response that are added to the request sent to the backend. If
+	// omitted, no headers are added.
+	// Limited to 50 headers.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	HeadersToUpstreamOnAllow []HTTPHeaderName `json:"headersToUpstreamOnAllow,omitempty"`
+
+	// HeadersToDownstreamOnDeny is a list of the headers of a denied check
+	// response that are returned to the client. If omitted, all headers of
+	// the denied response are returned.
+	// Limited to 50 headers.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	HeadersToDownstreamOnDeny []HTTPHeaderName `json:"headersToDownstreamOnDeny,omitempty"`
+
+	// IncludeRequestBody configures whether and how much of the request
+	// body is sent to the extension. If omitted, the body is not sent.
+	//
+	// +optional
+	IncludeRequestBody *GCPAuthzExtensionRequestBody `json:"includeRequestBody,omitempty"`
+}
+
+// GCPAuthzExtensionRequestBody configures how the request body is sent to
+// an authorization extension. The load balancer buffers the body before the
+// check, which delays the request.
+type GCPAuthzExtensionRequestBody struct {
+	// MaxRequestBytes is the maximum number of bytes of the body that are
+	// buffered and sent to the extension.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65536
+	// +required
+	MaxRequestBytes int32 `json:"maxRequestBytes"`
+
+	// AllowPartialMessage determines what happens to requests whose body is
+	// larger than MaxRequestBytes. When set to `TRUE`, the first
+	// MaxRequestBytes bytes are sent to the extension. When set to `FALSE`
+	// or the default setting of `FALSE` is used, the request is rejected
+	// with status code 413.
+	//
+	// +optional
+	AllowPartialMessage bool `json:"allowPartialMessage,omitempty"`
+
+	// PackAsBytes sends the body as raw bytes instead of a UTF-8 string,
+	// which is required for binary bodies.
+	//
+	// +optional
+	PackAsBytes bool `json:"packAsBytes,omitempty"`
+}
+
+// GCPAuthzExtensionStatus defines the observed state of GCPAuthzExtension.
+type GCPAuthzExtensionStatus struct {
+	// Conditions describe the current conditions of the GCPAuthzExtension.
+	//
+	// Known condition types are:
+	//
+	// * "Accepted"
+	// * "ResolvedRefs"
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
@@ -48,8 +48,84 @@ const (
 	L4 EnforcementLevel = "L4"
 )
 
+// GCPAuthzPolicyEnforcementMode specifies whether the decisions of an
+// authorization policy are enforced.
+// +kubebuilder:validation:Enum=Enforce;DryRun
+type GCPAuthzPolicyEnforcementMode string
+
+const (
+	// Enforce enforces the decisions of the policy. This is the default mode.
+	Enforce GCPAuthzPolicyEnforcementMode = "Enforce"
+	// DryRun evaluates the policy and logs the decisions it would make,
+	// without enforcing them. Requests are authorized as if the policy did
+	// not exist.
+	DryRun GCPAuthzPolicyEnforcementMode = "DryRun"
+)
+
+const (
+	// PolicyConditionEnforced indicates whether the decisions of a
+	// GCPAuthzPolicy are enforced.
+	//
+	// Possible reasons for this condition to be true are:
+	//
+	// * "Enforced"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "DryRun"
+	//
+	PolicyConditionEnforced PolicyConditionType = "Enforced"
+
+	// PolicyReasonEnforced is used with the "Enforced" condition when the
+	// decisions of the policy are enforced.
+	PolicyReasonEnforced PolicyConditionReason = "Enforced"
+
+	// PolicyReasonDryRun is used with the "Enforced" condition when the
+	// policy is in DryRun mode and its decisions are only logged.
+	PolicyReasonDryRun PolicyConditionReason = "DryRun"
+
+	// PolicyConditionResolvedRefs indicates whether the extensions referenced
+	// by the CustomProviders of a CUSTOM GCPAuthzPolicy were resolved.
+	//
+	// Possible reasons for this condition to be true are:
+	//
+	// * "ResolvedRefs"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "InvalidKind"
+	// * "ExtensionNotFound"
+	// * "UnsupportedEvent"
+	// * "UnsupportedWireFormat"
+	//
+	PolicyConditionResolvedRefs PolicyConditionType = "ResolvedRefs"
+
+	// PolicyReasonResolvedRefs is used with the "ResolvedRefs" condition when
+	// all the extensions referenced by the policy were resolved.
+	PolicyReasonResolvedRefs PolicyConditionReason = "ResolvedRefs"
+
+	// PolicyReasonInvalidKind is used with the "ResolvedRefs" condition when
+	// an extension reference has a group or kind that cannot be used as an
+	// authorization extension.
+	PolicyReasonInvalidKind PolicyConditionReason = "InvalidKind"
+
+	// PolicyReasonExtensionNotFound is used with the "ResolvedRefs" condition
+	// when a referenced extension does not exist.
+	PolicyReasonExtensionNotFound PolicyConditionReason = "ExtensionNotFound"
+
+	// PolicyReasonUnsupportedEvent is used with the "ResolvedRefs" condition
+	// when a referenced extension is not called for the RequestHeaders event,
+	// which is when authorization decisions are made.
+	PolicyReasonUnsupportedEvent PolicyConditionReason = "UnsupportedEvent"
+
+	// PolicyReasonUnsupportedWireFormat is used with the "ResolvedRefs"
+	// condition when a referenced extension is not reachable over gRPC, for
+	// example a Service port without an HTTP/2 appProtocol.
+	PolicyReasonUnsupportedWireFormat PolicyConditionReason = "UnsupportedWireFormat"
+)
+
 // StringMatchCriteriaType specifies the type of the string match criteria.
-// +kubebuilder:validation:Enum=Exact;Prefix;Suffix;Contains
+// +kubebuilder:validation:Enum=Exact;Prefix;Suffix;Contains;SafeRegex;PathTemplate
 type StringMatchCriteriaType string
 
 const (
@@ -61,6 +137,16 @@ const (
 	StringSuffix StringMatchCriteriaType = "Suffix"
 	// StringContains matches the string that contains the value.
 	StringContains StringMatchCriteriaType = "Contains"
+	// StringSafeRegex matches the string against an RE2 regular expression,
+	// which must match the whole string. It is limited to 1024 characters.
+	StringSafeRegex StringMatchCriteriaType = "SafeRegex"
+	// StringPathTemplate matches a URL path against a path template, such as
+	// `/v1/users/*/orders` or `/static/**`. `*` matches a single path segment
+	// and `**` matches zero or more trailing path segments. Segments can be
+	// captured as variables, for example `/v1/users/{id}/orders/{rest=**}`.
+	// It is only supported for paths and limited to 255 characters and 5
+	// operators.
+	StringPathTemplate StringMatchCriteriaType = "PathTemplate"
 )
 
 // HTTPMethod describes how to select a HTTP route by matching the HTTP
@@ -105,6 +191,8 @@ const (
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:categories=gateway-api
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Action",type=string,JSONPath=`.spec.action`
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.enforcementMode`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // GCPAuthzPolicy is the CRD for Authorization Policy.
@@ -137,7 +225,8 @@ type GCPAuthzPolicyList struct {
 // +kubebuilder:validation:XValidation:message="CustomProviders are required when the action is CUSTOM",rule="!(self.action == 'CUSTOM' && !has(self.customProviders)) && !(self.action != 'CUSTOM' && has(self.customProviders))"
 // +kubebuilder:validation:XValidation:message="When Action is DENY_BY_DEFAULT, Rules and CustomProviders must be empty",rule="self.action != 'DENY_BY_DEFAULT' || (!has(self.rules) && !has(self.customProviders))"
 // +kubebuilder:validation:XValidation:message="When Action is CUSTOM, EnforcementLevel must be L7",rule="self.action != 'CUSTOM' || self.enforcementLevel == 'L7'"
-// +kubebuilder:validation:XValidation:message="When EnforcementLevel is L4, only principals are allowed in sources and notSources, and no operations are allowed",rule="self.enforcementLevel != 'L4' || self.rules.all(r, (!has(r.from) || ((!has(r.from.sources) || r.from.sources.all(s, has(s.principals) && s.principals.size() > 0 && !has(s.resources))) && (!has(r.from.notSources) || r.from.notSources.all(s, has(s.principals) && s.principals.size() > 0 && !has(s.resources))))) && !has(r.to))"
+// +kubebuilder:validation:XValidation:message="EnforcementMode DryRun is not supported when Action is CUSTOM",rule="!has(self.enforcementMode) || self.enforcementMode != 'DryRun' || !has(self.action) || self.action != 'CUSTOM'"
+// +kubebuilder:validation:XValidation:message="When EnforcementLevel is L4, only principals and IP blocks are allowed in sources and notSources, and no operations are allowed",rule="self.enforcementLevel != 'L4' || self.rules.all(r, (!has(r.from) || ((!has(r.from.sources) || r.from.sources.all(s, (has(s.principals) || has(s.ipBlocks) || has(s.notIpBlocks)) && !has(s.resources) && !has(s.requestAuth))) && (!has(r.from.notSources) || r.from.notSources.all(s, (has(s.principals) || has(s.ipBlocks) || has(s.notIpBlocks)) && !has(s.resources) && !has(s.requestAuth))))) && !has(r.to))"
 // +kubebuilder:validation:XValidation:message="When Resources is set in GCPAuthzPolicySource, at least one TargetRef must have Kind=Gateway",rule="(!has(self.rules) || !self.rules.exists(r, has(r.from) && ((has(r.from.sources) && r.from.sources.exists(s, has(s.resources))) || (has(r.from.notSources) && r.from.notSources.exists(s, has(s.resources)))))) || self.targetRefs.exists(t, t.kind == 'Gateway')"
 // +kubebuilder:validation:XValidation:message="Only one TargetRef of kind=Pod is allowed",rule="self.targetRefs.filter(t, t.kind == 'Pod').size() <= 1"
 // +kubebuilder:validation:XValidation:message="principalSelector must be CLIENT_CERT_URI_SAN when TargetRef kind is Pod.",rule="!self.targetRefs.exists(t, t.kind == 'Pod') || !has(self.rules) || self.rules.all(r, !has(r.from) || ((!has(r.from.sources) || r.from.sources.all(s, !has(s.principals) || s.principals.all(p, !has(p.principalSelector) || p.principalSelector == 'CLIENT_CERT_URI_SAN'))) && (!has(r.from.notSources) || r.from.notSources.all(s, !has(s.principals) || s.principals.all(p, !has(p.principalSelector) || p.principalSelector == 'CLIENT_CERT_URI_SAN')))))"
@@ -157,6 +246,16 @@ type GCPAuthzPolicySpec struct {
 	// CustomProviders defines the extension providers for authorization policy.
 	// +optional
 	CustomProviders *GCPAuthzPolicyCustomProviders `json:"customProviders,omitempty"`
+	// EnforcementMode specifies whether the decisions of the policy are
+	// enforced. In DryRun mode the policy is evaluated and the decisions it
+	// would make are logged, but requests are authorized as if the policy
+	// did not exist. DryRun is not supported for CUSTOM policies, as their
+	// extensions may have side effects.
+	// Default is Enforce if not specified.
+	// +kubebuilder:validation:Enum=Enforce;DryRun
+	// +kubebuilder:default=Enforce
+	// +optional
+	EnforcementMode *GCPAuthzPolicyEnforcementMode `json:"enforcementMode,omitempty"`
 	// TargetRefs identifies a list of API objects to apply policy to.
 	// Limited to 10 TargetRef, can not be empty.
 	// +kubebuilder:validation:MinItems=1
@@ -313,6 +412,85 @@ type GCPAuthzPolicySource struct {
 	// +kubebuilder:validation:MaxItems=10
 	// +optional
 	Resources []GCPAuthzPolicyResource `json:"resources,omitempty"`
+	// IPBlocks is a list of IP ranges in CIDR notation, for example
+	// `203.0.113.0/24` or `2001:db8::/32`, that the client IP address must
+	// be in. The client IP address is the address of the peer connection;
+	// for external load balancers it is the address of the client connecting
+	// to the load balancer.
+	// Supported for L4 and L7 policies.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	IPBlocks []CIDR `json:"ipBlocks,omitempty"`
+	// NotIPBlocks is a list of IP ranges in CIDR notation that the client IP
+	// address must not be in.
+	// Supported for L4 and L7 policies.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	NotIPBlocks []CIDR `json:"notIpBlocks,omitempty"`
+	// RequestAuth matches the claims of the request credential, a JWT that
+	// was validated by a GCPRequestAuthenticationPolicy or the load balancer.
+	// Requests without a validated credential do not match.
+	// Only supported for L7 policies.
+	// +optional
+	RequestAuth *GCPAuthzPolicyRequestAuth `json:"requestAuth,omitempty"`
+}
+
+// CIDR is an IPv4 or IPv6 address range in CIDR notation, for example
+// `10.0.0.0/8`. A single address can be written without a prefix length.
+// +kubebuilder:validation:MinLength=1
+// +kubebuilder:validation:MaxLength=43
+// +kubebuilder:validation:Pattern=`^[0-9a-fA-F:.]+(/[0-9]{1,3})?$`
+type CIDR string
+
+// GCPAuthzPolicyRequestAuth matches the claims of a validated JWT.
+// Fields are ANDed together, and a field matches if any of its entries
+// matches.
+// +kubebuilder:validation:XValidation:message="At least one of issuers, audiences, requestPrincipals and claims must be specified",rule="has(self.issuers) || has(self.audiences) || has(self.requestPrincipals) || has(self.claims)"
+type GCPAuthzPolicyRequestAuth struct {
+	// Issuers is a list of matches for the `iss` claim.
+	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:XValidation:message="PathTemplate is only allowed for Paths",rule="self.all(m, m.type != 'PathTemplate')"
+	// +optional
+	Issuers []StringMatchCriteria `json:"issuers,omitempty"`
+	// Audiences is a list of matches for the `aud` claim. A credential with
+	// multiple audiences matches if any of them matches.
+	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:XValidation:message="PathTemplate is only allowed for Paths",rule="self.all(m, m.type != 'PathTemplate')"
+	// +optional
+	Audiences []StringMatchCriteria `json:"audiences,omitempty"`
+	// RequestPrincipals is a list of matches for the request principal, which
+	// is the `iss` and `sub` claims joined by a slash, for example
+	// `https://accounts.google.com/1234567890`.
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:XValidation:message="PathTemplate is only allowed for Paths",rule="self.all(m, m.type != 'PathTemplate')"
+	// +optional
+	RequestPrincipals []StringMatchCriteria `json:"requestPrincipals,omitempty"`
+	// Claims is a list of claims that must all match.
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Claims []JWTClaimMatch `json:"claims,omitempty"`
+}
+
+// JWTClaimMatch matches a claim of a validated JWT.
+type JWTClaimMatch struct {
+	// Name is the name of the claim. Nested claims are separated by dots, for
+	// example `realm_access.roles`.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_:\-]+(\.[A-Za-z0-9_:\-]+)*$`
+	// +required
+	Name string `json:"name"`
+	// Values is a list of matches for the claim. String claims match if any
+	// of the values matches. List claims match if any of their elements
+	// matches any of the values. Number and boolean claims are matched in
+	// their JSON form, for example `true`.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:XValidation:message="PathTemplate is only allowed for Paths",rule="self.all(m, m.type != 'PathTemplate')"
+	// +required
+	Values []StringMatchCriteria `json:"values"`
 }
 
 // GCPAuthzPolicyOperation is the spec for the operation of the rule.
@@ -326,6 +504,7 @@ type GCPAuthzPolicyOperation struct {
 	// Hosts is a list of HTTP Hosts to match against.
 	// Limited to 10 matches.
 	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:XValidation:message="PathTemplate is only allowed for Paths",rule="self.all(h, h.type != 'PathTemplate')"
 	// +optional
 	Hosts []StringMatchCriteria `json:"hosts,omitempty"`
 	// Methods is a list of HTTP methods to match against.
@@ -342,6 +521,8 @@ type GCPAuthzPolicyOperation struct {
 }
 
 // HTTPHeaderMatch builds the header match criteria.
+// +kubebuilder:validation:XValidation:message="PathTemplate is only allowed for Paths",rule="self.type != 'PathTemplate'"
+// +kubebuilder:validation:XValidation:message="IgnoreCase cannot be set for SafeRegex, use the (?i) flag instead",rule="!(self.type == 'SafeRegex' && has(self.ignoreCase) && self.ignoreCase)"
 type HTTPHeaderMatch struct {
 	// Type specifies how to match against the value of the header.
 	// +required
@@ -362,6 +543,9 @@ type HTTPHeaderMatch struct {
 }
 
 // StringMatchCriteria defines the match criteria for a string.
+// +kubebuilder:validation:XValidation:message="SafeRegex cannot exceed 1024 characters",rule="self.type != 'SafeRegex' || size(self.value) <= 1024"
+// +kubebuilder:validation:XValidation:message="PathTemplate must start with / and cannot exceed 255 characters",rule="self.type != 'PathTemplate' || (self.value.startsWith('/') && size(self.value) <= 255)"
+// +kubebuilder:validation:XValidation:message="IgnoreCase cannot be set for SafeRegex or PathTemplate, use the (?i) flag in SafeRegex instead",rule="!((self.type == 'SafeRegex' || self.type == 'PathTemplate') && has(self.ignoreCase) && self.ignoreCase)"
 type StringMatchCriteria struct {
 	// Type is the type of the string match criteria.
 	// +required
@@ -376,10 +560,23 @@ type StringMatchCriteria struct {
 
 // GCPAuthzPolicyCustomProviders defines the custom providers for authorization policy.
 type GCPAuthzPolicyCustomProviders struct {
-	// ExtensionRefs identifies a list of Authz Extensions.
+	// ExtensionRefs identifies a list of Authz Extensions, in the namespace
+	// of the policy.
 	// Limited to 2 ExtensionRefs.
+	//
+	// Valid references are:
+	// - group "networking.gke.io", kind "GCPAuthzExtension": an extension
+	//   that implements the Envoy ext_authz gRPC protocol.
+	// - group "networking.gke.io", kind "GCPTrafficExtension": the first
+	//   extension called for the RequestHeaders event makes the decision,
+	//   over the ext_proc protocol. It must not be a GCPWasmPlugin.
+	// - group "", kind "Service": a Service that implements the Envoy
+	//   ext_authz gRPC protocol. It must have a port with the appProtocol
+	//   HTTP2, kubernetes.io/h2c or grpc.
+	//
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:XValidation:message="ExtensionRefs must be of kind GCPAuthzExtension or GCPTrafficExtension in group networking.gke.io, or kind Service in the core group",rule="self.all(r, (r.group == 'networking.gke.io' && (r.kind == 'GCPAuthzExtension' || r.kind == 'GCPTrafficExtension')) || (r.group == '' && r.kind == 'Service'))"
 	// +required
 	ExtensionRefs []v1.LocalObjectReference `json:"extensionRefs,omitempty"`
 }
@@ -397,6 +594,7 @@ type GCPAuthzPolicyResource struct {
 
 	// IAMServiceAccount to match against the GCP IAM service account
 	// associated with the source VM of a request.
+	// +kubebuilder:validation:XValidation:message="PathTemplate is only allowed for Paths",rule="self.type != 'PathTemplate'"
 	// +optional
 	IAMServiceAccount *StringMatchCriteria `json:"iamServiceAccount,omitempty"`
 }
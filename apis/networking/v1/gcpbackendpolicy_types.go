@@ -18,6 +18,7 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
 	v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
@@ -59,6 +60,11 @@ type GCPBackendPolicySpec struct {
 }
 
 // GCPBackendPolicyConfig contains LoadBalancer policy configuration.
+// +kubebuilder:validation:XValidation:rule="!has(self.tls) || (has(self.protocol) && self.protocol in ['HTTPS', 'HTTP2'])",message="tls can only be specified if protocol is HTTPS or HTTP2"
+// +kubebuilder:validation:XValidation:rule="!has(self.sessionAffinity) || !has(self.sessionAffinity.type) || self.sessionAffinity.type != 'HEADER_FIELD' || (has(self.consistentHash) && has(self.consistentHash.httpHeaderName))",message="consistentHash.httpHeaderName must be specified if sessionAffinity.type is HEADER_FIELD"
+// +kubebuilder:validation:XValidation:rule="!has(self.sessionAffinity) || !has(self.sessionAffinity.type) || self.sessionAffinity.type != 'HTTP_COOKIE' || (has(self.consistentHash) && has(self.consistentHash.httpCookie))",message="consistentHash.httpCookie must be specified if sessionAffinity.type is HTTP_COOKIE"
+// +kubebuilder:validation:XValidation:rule="!has(self.consistentHash) || !has(self.consistentHash.httpHeaderName) || (has(self.sessionAffinity) && has(self.sessionAffinity.type) && self.sessionAffinity.type == 'HEADER_FIELD')",message="consistentHash.httpHeaderName can only be specified if sessionAffinity.type is HEADER_FIELD"
+// +kubebuilder:validation:XValidation:rule="!has(self.consistentHash) || !has(self.consistentHash.httpCookie) || (has(self.sessionAffinity) && has(self.sessionAffinity.type) && self.sessionAffinity.type == 'HTTP_COOKIE')",message="consistentHash.httpCookie can only be specified if sessionAffinity.type is HTTP_COOKIE"
 type GCPBackendPolicyConfig struct {
 	Logging            *LoggingConfig         `json:"logging,omitempty"`
 	SessionAffinity    *SessionAffinityConfig `json:"sessionAffinity,omitempty"`
@@ -69,9 +75,27 @@ type GCPBackendPolicyConfig struct {
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=2147483647
 	TimeoutSec *int64 `json:"timeoutSec,omitempty"`
-	// SecurityPolicy is a reference to a GCP Cloud Armor SecurityPolicy resource.
+	// SecurityPolicy is a reference to a GCP Cloud Armor backend SecurityPolicy resource.
+	// It can be a raw name or a path:
+	// "[name-of-security-policy]"
+	// "projects/[projectID]/global/securityPolicies/[name-of-security-policy]"
+	// "projects/[projectID]/regions/[region]/securityPolicies/[name-of-security-policy]"
+	// If it is set to a raw name, the project and region of the SecurityPolicy
+	// derive from the attached load balancer.
+	// If it is set to an empty string, the SecurityPolicy is detached from the
+	// BackendService.
 	// +optional
 	SecurityPolicy *string `json:"securityPolicy,omitempty"`
+	// EdgeSecurityPolicy is a reference to a GCP Cloud Armor edge SecurityPolicy
+	// resource. Edge security policies filter requests before they are served
+	// from cache and are only supported by global external load balancers.
+	// It can be a raw name or a path:
+	// "[name-of-security-policy]"
+	// "projects/[projectID]/global/securityPolicies/[name-of-security-policy]"
+	// If it is set to an empty string, the edge SecurityPolicy is detached from
+	// the BackendService.
+	// +optional
+	EdgeSecurityPolicy *string `json:"edgeSecurityPolicy,omitempty"`
 	// IAP contains the configurations for Identity-Aware Proxy.
 	// See https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
 	// Identity-Aware Proxy manages access control policies for backend services associated with a HTTPRoute,
@@ -93,6 +117,375 @@ type GCPBackendPolicyConfig struct {
 	// +kubebuilder:validation:Enum=DEFAULT;PREFERRED
 	// +optional
 	BackendPreference *string `json:"backendPreference,omitempty"`
+	// CircuitBreakers limits the volume of traffic sent to the backends.
+	// See circuitBreakers in https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
+	// Not supported by the classic Application Load Balancer.
+	// +optional
+	CircuitBreakers *CircuitBreakers `json:"circuitBreakers,omitempty"`
+	// OutlierDetection controls the eviction of unhealthy endpoints from the
+	// load balancing pool.
+	// See outlierDetection in https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
+	// Not supported by the classic Application Load Balancer.
+	// +optional
+	OutlierDetection *OutlierDetection `json:"outlierDetection,omitempty"`
+	// ConsistentHash configures what is hashed to select a backend endpoint.
+	// HTTPHeaderName is required with HEADER_FIELD session affinity and
+	// HTTPCookie with HTTP_COOKIE session affinity.
+	// Consistent hashing requires a GCPTrafficDistributionPolicy with the
+	// RING_HASH or MAGLEV locality load balancing algorithm on the same Service.
+	// +optional
+	ConsistentHash *ConsistentHashConfig `json:"consistentHash,omitempty"`
+	// CustomRequestHeaders are headers that the load balancer adds to the
+	// requests it sends to the backends.
+	// Values can contain variables such as {client_region} or
+	// {tls_sni_hostname}, which are replaced by the load balancer.
+	// See https://cloud.google.com/load-balancing/docs/https/custom-headers
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	CustomRequestHeaders []CustomHeader `json:"customRequestHeaders,omitempty"`
+	// CustomResponseHeaders are headers that the load balancer adds to the
+	// responses it sends to the clients.
+	// Values can contain the same variables as CustomRequestHeaders, as well
+	// as the response-only variables {cdn_cache_id}, {cdn_cache_status} and
+	// {origin_request_header}.
+	// See https://cloud.google.com/load-balancing/docs/https/custom-headers
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	CustomResponseHeaders []CustomHeader `json:"customResponseHeaders,omitempty"`
+	// CDN contains the configuration for Cloud CDN.
+	// Cloud CDN is only supported by global external load balancers.
+	// See https://cloud.google.com/cdn/docs/overview
+	// +optional
+	CDN *CDNConfig `json:"cdn,omitempty"`
+	// Protocol is the protocol that the load balancer uses to communicate
+	// with the backends, one of HTTP, HTTPS, HTTP2, H2C or GRPC.
+	// If not specified, the protocol is derived from the appProtocol of the
+	// Service port, and defaults to HTTP.
+	// See protocol in https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
+	// +kubebuilder:validation:Enum=HTTP;HTTPS;HTTP2;H2C;GRPC
+	// +optional
+	Protocol *BackendProtocol `json:"protocol,omitempty"`
+	// TLS configures the TLS connection from the load balancer to the
+	// backends. It can only be specified if Protocol is HTTPS or HTTP2.
+	// Its semantics follow the validation of the Gateway API BackendTLSPolicy.
+	// +optional
+	TLS *BackendTLSConfig `json:"tls,omitempty"`
+}
+
+// BackendProtocol is the protocol used to communicate with the backends.
+type BackendProtocol string
+
+const (
+	// BackendProtocolHTTP is HTTP/1.1 without TLS.
+	BackendProtocolHTTP BackendProtocol = "HTTP"
+	// BackendProtocolHTTPS is HTTP/1.1 over TLS.
+	BackendProtocolHTTPS BackendProtocol = "HTTPS"
+	// BackendProtocolHTTP2 is HTTP/2 over TLS.
+	BackendProtocolHTTP2 BackendProtocol = "HTTP2"
+	// BackendProtocolH2C is HTTP/2 without TLS.
+	BackendProtocolH2C BackendProtocol = "H2C"
+	// BackendProtocolGRPC is gRPC.
+	BackendProtocolGRPC BackendProtocol = "GRPC"
+)
+
+// BackendTLSConfig configures the TLS connection from the load balancer to
+// the backends. Unlike BackendTLSPolicy, the certificate of the backends is
+// only validated if CACertificateRefs or WellKnownCACertificates is
+// specified.
+// See tlsSettings in https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
+// +kubebuilder:validation:XValidation:rule="!(has(self.caCertificateRefs) && size(self.caCertificateRefs) > 0 && has(self.wellKnownCACertificates))",message="only one of caCertificateRefs and wellKnownCACertificates can be specified"
+// +kubebuilder:validation:XValidation:rule="!has(self.subjectAltNames) || size(self.subjectAltNames) == 0 || (has(self.caCertificateRefs) && size(self.caCertificateRefs) > 0) || has(self.wellKnownCACertificates)",message="subjectAltNames can only be specified if caCertificateRefs or wellKnownCACertificates is specified"
+type BackendTLSConfig struct {
+	// Hostname is the server name indication (SNI) sent to the backends.
+	// If SubjectAltNames is empty and the certificate of the backends is
+	// validated, the certificate must match Hostname.
+	// +optional
+	Hostname *v1.PreciseHostname `json:"hostname,omitempty"`
+	// SubjectAltNames are the Subject Alternative Names that the certificate
+	// of the backends must match, one of which is required.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	SubjectAltNames []v1.SubjectAltName `json:"subjectAltNames,omitempty"`
+	// CACertificateRefs are references to ConfigMaps in the namespace of the
+	// policy that contain a PEM-encoded CA certificate bundle under the
+	// "ca.crt" key. The bundles are used to validate the certificate of the
+	// backends.
+	// +kubebuilder:validation:XValidation:rule="self.all(r, r.group == '' && r.kind == 'ConfigMap')",message="caCertificateRefs must reference ConfigMaps"
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	CACertificateRefs []v1.LocalObjectReference `json:"caCertificateRefs,omitempty"`
+	// WellKnownCACertificates specifies that the certificate of the backends
+	// is validated against the public root CAs trusted by Google.
+	// +optional
+	WellKnownCACertificates *v1.WellKnownCACertificatesType `json:"wellKnownCACertificates,omitempty"`
+}
+
+// CDNCacheMode specifies how Cloud CDN caches responses.
+type CDNCacheMode string
+
+const (
+	// CDNCacheModeUseOriginHeaders caches responses with valid caching
+	// directives from the origin. Responses without such directives are not
+	// cached.
+	CDNCacheModeUseOriginHeaders CDNCacheMode = "USE_ORIGIN_HEADERS"
+	// CDNCacheModeForceCacheAll caches all successful responses, ignoring any
+	// private or no-store directives from the origin.
+	CDNCacheModeForceCacheAll CDNCacheMode = "FORCE_CACHE_ALL"
+	// CDNCacheModeCacheAllStatic caches static content and responses with
+	// valid caching directives from the origin. This is the default.
+	CDNCacheModeCacheAllStatic CDNCacheMode = "CACHE_ALL_STATIC"
+)
+
+// CDNConfig contains the configuration for Cloud CDN.
+// See cdnPolicy in https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
+// +kubebuilder:validation:XValidation:rule="(has(self.enabled) && self.enabled) || !(has(self.cacheMode) || has(self.defaultTTL) || has(self.maxTTL) || has(self.clientTTL) || has(self.negativeCaching) || has(self.negativeCachingPolicy) || has(self.cacheKeyPolicy) || has(self.signedURLKeys) || has(self.signedURLCacheMaxAge) || has(self.serveWhileStale) || has(self.requestCoalescing))",message="CDN settings can only be specified if enabled is true"
+// +kubebuilder:validation:XValidation:rule="!has(self.cacheMode) || self.cacheMode != 'USE_ORIGIN_HEADERS' || !(has(self.defaultTTL) || has(self.maxTTL) || has(self.clientTTL))",message="defaultTTL, maxTTL and clientTTL cannot be specified if cacheMode is USE_ORIGIN_HEADERS"
+// +kubebuilder:validation:XValidation:rule="!has(self.maxTTL) || !has(self.cacheMode) || self.cacheMode == 'CACHE_ALL_STATIC'",message="maxTTL can only be specified if cacheMode is CACHE_ALL_STATIC"
+// +kubebuilder:validation:XValidation:rule="!has(self.defaultTTL) || !has(self.maxTTL) || duration(self.defaultTTL) <= duration(self.maxTTL)",message="defaultTTL must not be greater than maxTTL"
+// +kubebuilder:validation:XValidation:rule="!has(self.clientTTL) || !has(self.maxTTL) || duration(self.clientTTL) <= duration(self.maxTTL)",message="clientTTL must not be greater than maxTTL"
+// +kubebuilder:validation:XValidation:rule="!has(self.negativeCachingPolicy) || (has(self.negativeCaching) && self.negativeCaching)",message="negativeCachingPolicy can only be specified if negativeCaching is true"
+type CDNConfig struct {
+	// Enabled denotes whether Cloud CDN is enabled for the backend.
+	// If not specified, this defaults to false.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// CacheMode specifies how responses are cached, one of USE_ORIGIN_HEADERS,
+	// FORCE_CACHE_ALL or CACHE_ALL_STATIC.
+	// If not specified, this defaults to CACHE_ALL_STATIC.
+	// +kubebuilder:validation:Enum=USE_ORIGIN_HEADERS;FORCE_CACHE_ALL;CACHE_ALL_STATIC
+	// +optional
+	CacheMode *CDNCacheMode `json:"cacheMode,omitempty"`
+	// DefaultTTL is the time to live of responses that do not have a
+	// max-age or s-maxage directive. Must be in whole seconds.
+	// If not specified, a default value of 1h will be used.
+	// +kubebuilder:validation:XValidation:rule="duration(self) <= duration('8760h')",message="defaultTTL must not be greater than 1 year"
+	// +optional
+	DefaultTTL *v1.Duration `json:"defaultTTL,omitempty"`
+	// MaxTTL is the maximum time to live of cached responses. Must be in whole
+	// seconds. It can only be specified with the CACHE_ALL_STATIC cache mode.
+	// If not specified, a default value of 24h will be used.
+	// +kubebuilder:validation:XValidation:rule="duration(self) <= duration('8760h')",message="maxTTL must not be greater than 1 year"
+	// +optional
+	MaxTTL *v1.Duration `json:"maxTTL,omitempty"`
+	// ClientTTL is the maximum time to live that is sent to clients in the
+	// Cache-Control header. Must be in whole seconds.
+	// If not specified, DefaultTTL is used.
+	// +kubebuilder:validation:XValidation:rule="duration(self) <= duration('8760h')",message="clientTTL must not be greater than 1 year"
+	// +optional
+	ClientTTL *v1.Duration `json:"clientTTL,omitempty"`
+	// NegativeCaching denotes whether error responses, such as 404, are cached.
+	// If not specified, this defaults to false.
+	// +optional
+	NegativeCaching *bool `json:"negativeCaching,omitempty"`
+	// NegativeCachingPolicy overrides the time to live of error responses per
+	// status code. It can only be specified if NegativeCaching is true.
+	// +listType=map
+	// +listMapKey=code
+	// +kubebuilder:validation:MaxItems=11
+	// +optional
+	NegativeCachingPolicy []CDNNegativeCachingPolicy `json:"negativeCachingPolicy,omitempty"`
+	// CacheKeyPolicy specifies the parts of the request that are used to build
+	// the cache key.
+	// +optional
+	CacheKeyPolicy *CDNCacheKeyPolicy `json:"cacheKeyPolicy,omitempty"`
+	// SignedURLKeys are the keys used to sign URLs and cookies for this
+	// backend.
+	// +listType=map
+	// +listMapKey=keyName
+	// +kubebuilder:validation:MaxItems=3
+	// +optional
+	SignedURLKeys []CDNSignedURLKey `json:"signedURLKeys,omitempty"`
+	// SignedURLCacheMaxAge is the maximum time that responses to signed URL
+	// requests are considered fresh. Must be in whole seconds.
+	// If not specified, a default value of 1h will be used.
+	// +kubebuilder:validation:XValidation:rule="duration(self) <= duration('8760h')",message="signedURLCacheMaxAge must not be greater than 1 year"
+	// +optional
+	SignedURLCacheMaxAge *v1.Duration `json:"signedURLCacheMaxAge,omitempty"`
+	// ServeWhileStale is the time after a response has expired during which
+	// the stale response is served while it is revalidated. Must be in whole
+	// seconds. Set it to 0s to disable serving stale content.
+	// If not specified, a default value of 24h will be used.
+	// +kubebuilder:validation:XValidation:rule="duration(self) <= duration('24h')",message="serveWhileStale must not be greater than 1 day"
+	// +optional
+	ServeWhileStale *v1.Duration `json:"serveWhileStale,omitempty"`
+	// RequestCoalescing denotes whether concurrent cache fill requests for the
+	// same cache key are collapsed into a single request to the backend.
+	// If not specified, this defaults to true.
+	// +optional
+	RequestCoalescing *bool `json:"requestCoalescing,omitempty"`
+}
+
+// CDNNegativeCachingPolicy is the time to live of error responses with a
+// given status code.
+type CDNNegativeCachingPolicy struct {
+	// Code is the HTTP status code.
+	// +kubebuilder:validation:Enum=300;301;302;307;308;404;405;410;421;451;501
+	Code int32 `json:"code"`
+	// TTL is the time to live of responses with the status code. Must be in
+	// whole seconds.
+	// +kubebuilder:validation:XValidation:rule="duration(self) <= duration('30m')",message="ttl must not be greater than 30m"
+	TTL v1.Duration `json:"ttl"`
+}
+
+// CDNCacheKeyPolicy specifies the parts of the request that are used to build
+// the cache key.
+// +kubebuilder:validation:XValidation:rule="!(has(self.queryStringIncludeList) && has(self.queryStringExcludeList))",message="only one of queryStringIncludeList and queryStringExcludeList can be specified"
+// +kubebuilder:validation:XValidation:rule="!(has(self.queryStringIncludeList) || has(self.queryStringExcludeList)) || !has(self.includeQueryString) || self.includeQueryString",message="query string lists can only be specified if includeQueryString is true"
+type CDNCacheKeyPolicy struct {
+	// IncludeProtocol denotes whether http and https requests are cached
+	// separately. If not specified, this defaults to true.
+	// +optional
+	IncludeProtocol *bool `json:"includeProtocol,omitempty"`
+	// IncludeHost denotes whether requests to different hosts are cached
+	// separately. If not specified, this defaults to true.
+	// +optional
+	IncludeHost *bool `json:"includeHost,omitempty"`
+	// IncludeQueryString denotes whether the query string is part of the cache
+	// key. If not specified, this defaults to true.
+	// +optional
+	IncludeQueryString *bool `json:"includeQueryString,omitempty"`
+	// QueryStringIncludeList is the list of query string parameters that are
+	// part of the cache key. Other parameters are ignored.
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=128
+	// +optional
+	QueryStringIncludeList []string `json:"queryStringIncludeList,omitempty"`
+	// QueryStringExcludeList is the list of query string parameters that are
+	// not part of the cache key.
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=128
+	// +optional
+	QueryStringExcludeList []string `json:"queryStringExcludeList,omitempty"`
+	// IncludeHTTPHeaders is the list of request headers whose values are part
+	// of the cache key.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	IncludeHTTPHeaders []HTTPHeaderName `json:"includeHTTPHeaders,omitempty"`
+	// IncludeNamedCookies is the list of cookies whose values are part of the
+	// cache key.
+	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	// +optional
+	IncludeNamedCookies []string `json:"includeNamedCookies,omitempty"`
+}
+
+// CDNSignedURLKey is a key used to sign URLs and cookies.
+type CDNSignedURLKey struct {
+	// KeyName is the name of the key. It is the KeyName parameter of signed
+	// URLs and cookies.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	KeyName string `json:"keyName"`
+	// SecretRef is a reference to the Secret that contains the key.
+	// The Secret must contain the 128-bit key, encoded in base64url, under
+	// the "key" key.
+	SecretRef SecretObjectReference `json:"secretRef"`
+}
+
+// SecretObjectReference is a reference to a Secret.
+type SecretObjectReference struct {
+	// Name is the name of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+	// Namespace is the namespace of the Secret.
+	// If not specified, the namespace of the referencing policy is used.
+	// A Secret in another namespace can only be referenced if a
+	// ReferenceGrant in the namespace of the Secret allows it.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// CustomHeader is a header added by the load balancer.
+type CustomHeader struct {
+	// Name is the name of the header. Header names are case-insensitive.
+	// Headers that are reserved by the load balancer, such as Host or the
+	// X-Google and X-GFE prefixed headers, cannot be used.
+	Name HTTPHeaderName `json:"name"`
+	// Value is the value of the header. It can contain variables in curly
+	// braces, for example "{client_region},{client_city}".
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Pattern=`^[^\r\n]*$`
+	Value string `json:"value"`
+}
+
+// CircuitBreakers contains the circuit breaking thresholds of the backends.
+// When a threshold is reached, additional requests fail fast instead of
+// queueing on overloaded backends.
+type CircuitBreakers struct {
+	// MaxConnections is the maximum number of connections to the backend service.
+	// If not specified, a default value of 1024 will be used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+	// MaxPendingRequests is the maximum number of pending requests allowed to
+	// the backend service.
+	// If not specified, a default value of 1024 will be used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPendingRequests *int32 `json:"maxPendingRequests,omitempty"`
+	// MaxRequests is the maximum number of parallel requests allowed to the
+	// backend service.
+	// If not specified, a default value of 1024 will be used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRequests *int32 `json:"maxRequests,omitempty"`
+	// MaxRetries is the maximum number of parallel retries allowed to the
+	// backend service.
+	// If not specified, a default value of 3 will be used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+}
+
+// OutlierDetection contains the configuration to eject endpoints that
+// consistently return errors from the load balancing pool.
+// +kubebuilder:validation:XValidation:rule="!has(self.interval) || duration(self.interval) > duration('0s')",message="interval must be greater than 0s"
+// +kubebuilder:validation:XValidation:rule="!has(self.baseEjectionTime) || duration(self.baseEjectionTime) > duration('0s')",message="baseEjectionTime must be greater than 0s"
+type OutlierDetection struct {
+	// ConsecutiveErrors is the number of consecutive errors (HTTP 5xx or
+	// connection failures) after which an endpoint is ejected.
+	// If not specified, a default value of 5 will be used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ConsecutiveErrors *int32 `json:"consecutiveErrors,omitempty"`
+	// EnforcingConsecutiveErrors is the percentage chance that an endpoint is
+	// actually ejected when it reaches ConsecutiveErrors.
+	// If not specified, a default value of 0 will be used, which means that
+	// endpoints are not ejected for consecutive errors. Set it to 100 to
+	// always eject them.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	EnforcingConsecutiveErrors *int32 `json:"enforcingConsecutiveErrors,omitempty"`
+	// Interval is the time between ejection analysis sweeps.
+	// If not specified, a default value of 1s will be used.
+	// +optional
+	Interval *v1.Duration `json:"interval,omitempty"`
+	// BaseEjectionTime is the base time that an endpoint is ejected for. The
+	// actual ejection time is BaseEjectionTime multiplied by the number of
+	// times the endpoint has been ejected.
+	// If not specified, a default value of 30s will be used.
+	// +optional
+	BaseEjectionTime *v1.Duration `json:"baseEjectionTime,omitempty"`
+	// MaxEjectionPercent is the maximum percentage of endpoints that can be
+	// ejected at the same time.
+	// If not specified, a default value of 50 will be used.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxEjectionPercent *int32 `json:"maxEjectionPercent,omitempty"`
 }
 
 // ConnectionDraining contains configuration for connection draining
@@ -109,22 +502,6 @@ type ConnectionDraining struct {
 	DrainingTimeoutSec *int64 `json:"drainingTimeoutSec,omitempty"`
 }
 
-// LoggingConfig contains configuration for logging.
-type LoggingConfig struct {
-	// Enabled denotes whether to enable logging for the load balancer traffic
-	// served by this backend service. If not specified, this defaults to false,
-	// which means logging is disabled by default.
-	Enabled *bool `json:"enabled,omitempty"`
-	// This field can only be specified if logging is enabled for this backend
-	// service. The value of the field must be in range [0, 1e6]. This is
-	// converted to a floating point value in the range [0, 1] by dividing by 1e6
-	// for use with the GCE api and interpreted as the proportion of requests that
-	// will be logged. By default all requests will be logged.
-	// +kubebuilder:validation:Minimum=0
-	// +kubebuilder:validation:Maximum=1000000
-	SampleRate *int32 `json:"sampleRate,omitempty"`
-}
-
 // SessionAffinityConfig contains configuration for stickiness parameters.
 type SessionAffinityConfig struct {
 	// Type specifies the type of session affinity to use. If not specified, this
@@ -165,9 +542,17 @@ type IdentityAwareProxyConfig struct {
 // See https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
 type Oauth2ClientSecret struct {
 	// Name is the reference to the secret resource.
+	// The secret must contain the OAuth2 client secret under the "key" key.
 	Name *string `json:"name,omitempty"`
-	// Namespace will be supported in the future if people ask for cross namespace IAP reference grant support.
-	// Namespace *string `json:"namespace,omitempty"`
+	// Namespace is the namespace of the secret resource.
+	// If not specified, the namespace of the GCPBackendPolicy is used.
+	// A secret in another namespace can only be referenced if a ReferenceGrant
+	// in the namespace of the secret allows it.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
 }
 
 // GCPBackendPolicyStatus defines the observed state of GCPBackendPolicy.
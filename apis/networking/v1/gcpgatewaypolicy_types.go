@@ -65,8 +65,10 @@ type GCPGatewayPolicyConfig struct {
 
 	// Examples:
 	// "[name-of-ssl-policy]"
-	// "projects/[projectID]/global/targetHttpsProxies/[name-of-ssl-policy]"
-	// "projects/[projectID]/regions/[region]/targetHttpsProxies/[name-of-ssl-policy]"
+	// "projects/[projectID]/global/sslPolicies/[name-of-ssl-policy]"
+	// "projects/[projectID]/regions/[region]/sslPolicies/[name-of-ssl-policy]"
+	// A global SslPolicy must be used with global GatewayClasses and a regional
+	// SslPolicy with regional GatewayClasses.
 
 	// +optional
 	SslPolicy string `json:"sslPolicy,omitempty"`
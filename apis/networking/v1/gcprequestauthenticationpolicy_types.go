@@ -0,0 +1,185 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=gateway-api
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GCPRequestAuthenticationPolicy is the CRD for Request Authentication Policy.
+// This policy validates JSON Web Tokens (JWTs) presented by end users, so
+// that GCPAuthzPolicies can match the claims of the validated tokens.
+// Requests without a token are not rejected by this policy; use a
+// GCPAuthzPolicy with requestAuth to require one.
+type GCPRequestAuthenticationPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the implementation of this definition.
+	// +required
+	Spec GCPRequestAuthenticationPolicySpec `json:"spec,omitempty"`
+
+	// Status defines the current state of GCPRequestAuthenticationPolicy.
+	// +optional
+	Status v1.PolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCPRequestAuthenticationPolicyList contains a list of GCPRequestAuthenticationPolicy.
+type GCPRequestAuthenticationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPRequestAuthenticationPolicy `json:"items"`
+}
+
+// GCPRequestAuthenticationPolicySpec is the spec for Request Authentication Policy.
+// +kubebuilder:validation:XValidation:message="Only one TargetRef of kind=Pod is allowed",rule="self.targetRefs.filter(t, t.kind == 'Pod').size() <= 1"
+type GCPRequestAuthenticationPolicySpec struct {
+	// TargetRefs identifies a list of API objects to apply policy to.
+	// Limited to 10 TargetRef, can not be empty.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	// +required
+	TargetRefs []LocalObjectReference `json:"targetRefs,omitempty"`
+	// JWTRules is a list of token issuers and how their tokens are validated.
+	// A token is validated by the rule of its `iss` claim. Tokens of other
+	// issuers are rejected.
+	// Limited to 5 rules.
+	// +listType=map
+	// +listMapKey=issuer
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=5
+	// +required
+	JWTRules []JWTRule `json:"jwtRules,omitempty"`
+}
+
+// JWTRule describes the tokens of an issuer and how they are validated.
+// +kubebuilder:validation:XValidation:message="Exactly one of jwksUri and jwks must be specified",rule="has(self.jwksUri) != has(self.jwks)"
+type JWTRule struct {
+	// Issuer is the value of the `iss` claim of the tokens, for example
+	// `https://accounts.google.com`.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	// +required
+	Issuer string `json:"issuer"`
+	// Audiences is a list of accepted values of the `aud` claim. A token is
+	// accepted if any of its audiences is listed. If not specified, the
+	// audience is not validated.
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=512
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+	// JWKSURI is the HTTPS URL of the JSON Web Key Set of the issuer.
+	// Only one of JWKSURI and JWKS can be specified.
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https://`
+	// +optional
+	JWKSURI *string `json:"jwksUri,omitempty"`
+	// JWKS references a ConfigMap in the namespace of the policy that
+	// contains the JSON Web Key Set of the issuer.
+	// Only one of JWKSURI and JWKS can be specified.
+	// +optional
+	JWKS *JWKSConfigMapReference `json:"jwks,omitempty"`
+	// TokenLocations is a list of locations the token is read from, in
+	// order. If not specified, the token is read from the Authorization
+	// header with the `Bearer ` prefix.
+	// Limited to 5 locations.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	TokenLocations []JWTTokenLocation `json:"tokenLocations,omitempty"`
+	// ForwardOriginalToken forwards the token to the backend. If not
+	// specified, this defaults to false and the token is removed from the
+	// request.
+	// +optional
+	ForwardOriginalToken *bool `json:"forwardOriginalToken,omitempty"`
+	// ClaimToHeaders copies claims of the validated token to request headers
+	// that are sent to the backend.
+	// Limited to 10 headers.
+	// +listType=map
+	// +listMapKey=header
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	ClaimToHeaders []JWTClaimToHeader `json:"claimToHeaders,omitempty"`
+}
+
+// JWKSConfigMapReference references a key of a ConfigMap that contains a
+// JSON Web Key Set.
+type JWKSConfigMapReference struct {
+	// Name is the name of the ConfigMap.
+	// +required
+	Name v1.ObjectName `json:"name"`
+	// Key is the key of the ConfigMap that contains the JSON Web Key Set.
+	// If not specified, this defaults to `jwks.json`.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +optional
+	Key *string `json:"key,omitempty"`
+}
+
+// JWTTokenLocation is a location of the token in a request.
+// +kubebuilder:validation:XValidation:message="Exactly one of header and cookie must be specified",rule="has(self.header) != has(self.cookie)"
+type JWTTokenLocation struct {
+	// Header reads the token from a request header.
+	// +optional
+	Header *JWTHeaderLocation `json:"header,omitempty"`
+	// Cookie reads the token from the cookie with the given name.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[!#$%&'*+\-.^_\x60|~0-9A-Za-z]+$`
+	// +optional
+	Cookie *string `json:"cookie,omitempty"`
+}
+
+// JWTHeaderLocation is a request header that contains a token.
+type JWTHeaderLocation struct {
+	// Name is the name of the header.
+	// +required
+	Name HTTPHeaderName `json:"name"`
+	// Prefix is removed from the header value to obtain the token, for
+	// example `Bearer `. Headers that do not start with the prefix are
+	// ignored.
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+}
+
+// JWTClaimToHeader copies a claim of the validated token to a request header.
+type JWTClaimToHeader struct {
+	// Header is the name of the request header. An existing header with
+	// the same name is replaced.
+	// +required
+	Header HTTPHeaderName `json:"header"`
+	// Claim is the name of the claim. Nested claims are separated by dots,
+	// for example `realm_access.roles`. List claims are joined by commas.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_:\-]+(\.[A-Za-z0-9_:\-]+)*$`
+	// +required
+	Claim string `json:"claim"`
+}
@@ -52,17 +52,17 @@ type PortOverride struct {
 // objects by Group and Kind, with an optional label selector to narrow down the matching
 // objects.
 //
-// Currently, we only support label selectors when targeting Pods.
-// This restriction is intentional to limit the complexity and potential
-// ambiguity of supporting label selectors for arbitrary Kubernetes kinds.
-// Unless there is a very strong justification in the future, we plan to keep this
-// functionality limited to selecting Pods only.
+// Label selectors can target Pods, or the Pods of Deployments, StatefulSets and
+// DaemonSets. For workload kinds, the selector is matched against the labels of
+// the Pods, which inherit the labels of the Pod template, and only Pods whose
+// controller is of the given kind are selected. Selectors for other kinds are
+// intentionally not supported, to limit the complexity and potential
+// ambiguity of selecting arbitrary Kubernetes kinds.
 //
 // This is currently experimental in the Gateway API and should only be used
 // for policies implemented within Gateway API. It is currently not intended for general-purpose
 // use outside of Gateway API resources.
-// +kubebuilder:validation:XValidation:rule="!has(self.selector.matchLabels) || self.kind == 'Pod'",message="selector.matchLabels can only be used when targeting pods."
-// +kubebuilder:validation:XValidation:rule="!has(self.selector.matchExpressions) || size(self.selector.matchExpressions) == 0",message="selector.matchExpressions are not supported."
+// +kubebuilder:validation:XValidation:rule="(!has(self.selector.matchLabels) || size(self.selector.matchLabels) == 0) && (!has(self.selector.matchExpressions) || size(self.selector.matchExpressions) == 0) || (self.kind == 'Pod' && (!has(self.group) || size(self.group) == 0)) || (self.kind in ['Deployment', 'StatefulSet', 'DaemonSet'] && has(self.group) && self.group == 'apps')",message="selector can only be used when targeting Pods, or apps Deployments, StatefulSets and DaemonSets."
 type PolicyTargetReferenceWithLabelSelectors struct {
 	// Group is the group of the target object.
 	// +optional
@@ -72,13 +72,25 @@ type PolicyTargetReferenceWithLabelSelectors struct {
 	// +required
 	Kind v1.Kind `json:"kind,omitempty"`
 
+	// The rules of Selector iterate over lists of indices rather than over
+	// matchExpressions and values, which metav1.LabelSelector does not bound:
+	// the estimated cost of iterating them would exceed the CEL budget.
+
 	// Selector is the label selector of target objects of the specified kind.
+	// An empty selector targets the whole namespace.
+	// It can have at most 32 matchLabels and 16 matchExpressions. The
+	// matchExpressions use the In, NotIn, Exists and DoesNotExist operators,
+	// and In and NotIn take at most 64 values of at most 63 characters.
+	// +kubebuilder:validation:XValidation:rule="(!has(self.matchLabels) || size(self.matchLabels) <= 32) && (!has(self.matchExpressions) || size(self.matchExpressions) <= 16)",message="selector can have at most 32 matchLabels and 16 matchExpressions."
+	// +kubebuilder:validation:XValidation:rule="!has(self.matchExpressions) || [0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15].all(i, i >= size(self.matchExpressions) || self.matchExpressions[i].operator in ['In', 'NotIn', 'Exists', 'DoesNotExist'])",message="selector.matchExpressions operator must be In, NotIn, Exists or DoesNotExist."
+	// +kubebuilder:validation:XValidation:rule="!has(self.matchExpressions) || [0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15].all(i, i >= size(self.matchExpressions) || (self.matchExpressions[i].operator in ['In', 'NotIn']) == (has(self.matchExpressions[i].values) && size(self.matchExpressions[i].values) > 0))",message="selector.matchExpressions values must be set for the In and NotIn operators, and only for them."
+	// +kubebuilder:validation:XValidation:rule="!has(self.matchExpressions) || [0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15].all(i, i >= size(self.matchExpressions) || (size(self.matchExpressions[i].key) <= 317 && (!has(self.matchExpressions[i].values) || (size(self.matchExpressions[i].values) <= 64 && [0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63].all(j, j >= size(self.matchExpressions[i].values) || size(self.matchExpressions[i].values[j]) <= 63)))))",message="selector.matchExpressions keys can have at most 317 characters, and values at most 64 items of at most 63 characters."
 	// +required
 	Selector metav1.LabelSelector `json:"selector,omitempty"`
 }
 
 // GCPServerTLSPolicySpec defines the desired state of GCPServerTLSPolicy.
-// +kubebuilder:validation:XValidation:rule="!(self.targetRefs.exists(ref, (!has(ref.selector.matchLabels) || size(ref.selector.matchLabels) == 0)) && has(self.portOverrides))",message="portOverrides cannot be set when targeting a whole namespace (i.e., when the TargetRefs selector.matchLabels is absent or empty)."
+// +kubebuilder:validation:XValidation:rule="!(self.targetRefs.exists(ref, (!has(ref.selector.matchLabels) || size(ref.selector.matchLabels) == 0) && (!has(ref.selector.matchExpressions) || size(ref.selector.matchExpressions) == 0)) && has(self.portOverrides))",message="portOverrides cannot be set when targeting a whole namespace (i.e., when the selector of a TargetRef is absent or empty)."
 type GCPServerTLSPolicySpec struct {
 	// MTLSMode defines the default mutual TLS settings for the inbound connections on the targeted workload(s).
 	// Can be one of Disabled | Permissive | Strict.
@@ -91,7 +103,7 @@ type GCPServerTLSPolicySpec struct {
 	// PortOverrides allows specifying different mTLS settings for individual ports
 	// on the targeted workload(s). If a port is not listed, it inherits the
 	// default MTLSMode.
-	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:MaxItems=64
 	// +listType=map
 	// +listMapKey=port
 	// +optional
@@ -103,9 +115,10 @@ type GCPServerTLSPolicySpec struct {
 	// 3. Namespace policy (label selector is absent or empty)
 
 	// At most one policy can be picked for a given workload port. If there's no workload port-specific policy, the system checks for a workload policy. If that doesn't exist, the system checks for a namespace policy. If no namespace policy exists, then MTLS is disabled and workload will accept clear text traffic across all of its ports.
-	// If multiple policies are defined at the same level of precedence for the same target, the one with the oldest `creationTimestamp` is chosen. It is highly recommended to avoid configuring multiple policies for the same target.
+	// A workload is matched by a policy if any of its TargetRefs matches it.
+	// If multiple policies are defined at the same level of precedence for the same target, the one with the oldest `creationTimestamp` is chosen, and then the one whose name sorts first. It is highly recommended to avoid configuring multiple policies for the same target.
 	// +kubebuilder:validation:MinItems=1
-	// +kubebuilder:validation:MaxItems=1
+	// +kubebuilder:validation:MaxItems=16
 	// +required
 	TargetRefs []PolicyTargetReferenceWithLabelSelectors `json:"targetRefs,omitempty"`
 }
@@ -62,6 +62,8 @@ type GCPTrafficDistributionPolicySpec struct {
 }
 
 // GCPTrafficDistributionPolicyConfig defines the settings of GCPTrafficDistributionPolicy.
+// +kubebuilder:validation:XValidation:rule="!has(self.consistentHash) || (has(self.localityLbAlgorithm) && self.localityLbAlgorithm in ['RING_HASH', 'MAGLEV'])",message="consistentHash can only be specified if localityLbAlgorithm is RING_HASH or MAGLEV"
+// +kubebuilder:validation:XValidation:rule="!has(self.consistentHash) || !has(self.consistentHash.minimumRingSize) || (has(self.localityLbAlgorithm) && self.localityLbAlgorithm == 'RING_HASH')",message="consistentHash.minimumRingSize can only be specified if localityLbAlgorithm is RING_HASH"
 type GCPTrafficDistributionPolicyConfig struct {
 	// The load balancing algorithm used to determine traffic distribution weighting at
 	// cluster/zone level.
@@ -84,6 +86,12 @@ type GCPTrafficDistributionPolicyConfig struct {
 	// +kubebuilder:validation:Enum=ROUND_ROBIN;LEAST_REQUEST;RING_HASH;RANDOM;ORIGINAL_DESTINATION;MAGLEV;WEIGHTED_ROUND_ROBIN
 	LocalityLbAlgorithm *string `json:"localityLbAlgorithm,omitempty"`
 
+	// ConsistentHash configures what is hashed to select an endpoint.
+	// It can only be specified if LocalityLbAlgorithm is RING_HASH or MAGLEV.
+	//
+	// +optional
+	ConsistentHash *ConsistentHashConfig `json:"consistentHash,omitempty"`
+
 	// AutoCapacityDrain contains configurations for auto draining.
 	//
 	// +optional
@@ -93,14 +101,75 @@ type GCPTrafficDistributionPolicyConfig struct {
 	//
 	// +optional
 	FailoverConfig *FailoverConfig `json:"failoverConfig,omitempty"`
+
+	// LocalityWeights overrides the weight and capacity of the backends in
+	// specific zones or clusters. When several entries match a backend, the
+	// entry that specifies both zone and cluster takes precedence over the
+	// entries that only specify one of them.
+	//
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, (has(x.zone) ? x.zone : '') == (has(y.zone) ? y.zone : '') && (has(x.cluster) ? x.cluster : '') == (has(y.cluster) ? y.cluster : '')))",message="each zone and cluster combination can only be specified once"
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	LocalityWeights []LocalityWeight `json:"localityWeights,omitempty"`
+}
+
+// LocalityWeight overrides the weight and capacity of the backends in a zone,
+// a cluster, or a zone of a cluster.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.zone) || has(self.cluster)",message="at least one of zone and cluster must be specified"
+// +kubebuilder:validation:XValidation:rule="has(self.weight) || has(self.capacityScalerPercent)",message="at least one of weight and capacityScalerPercent must be specified"
+type LocalityWeight struct {
+	// Zone is the zone of the backends, for example "us-central1-a".
+	//
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z]+-[a-z]+[0-9]+-[a-z]$`
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// Cluster is the name of the cluster of the backends, as used by the
+	// fleet membership of the cluster.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Cluster *string `json:"cluster,omitempty"`
+
+	// Weight is the static weight of the backends. Backends without a static
+	// weight are weighted by their number of healthy endpoints.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	// CapacityScalerPercent scales the capacity of the backends. 0 drains the
+	// backends, otherwise it must be between 10 and 100.
+	// Default to 100.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:XValidation:rule="self == 0 || self >= 10",message="capacityScalerPercent must be 0 or between 10 and 100"
+	// +optional
+	CapacityScalerPercent *int32 `json:"capacityScalerPercent,omitempty"`
 }
 
 // AutoCapacityDrain contains configurations for auto draining.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.drainThresholdPercent) || (has(self.enableAutoCapacityDrain) && self.enableAutoCapacityDrain)",message="drainThresholdPercent can only be specified if enableAutoCapacityDrain is true"
 type AutoCapacityDrain struct {
 	// If set to 'True', backends in a certain (cluster, zone) will be
-	// drained(considered to have 0 capacity) when less than 25% of the endpoints
-	// there are healthy. Default to false.
+	// drained(considered to have 0 capacity) when less than
+	// DrainThresholdPercent of the endpoints there are healthy. Default to false.
 	EnableAutoCapacityDrain *bool `json:"enableAutoCapacityDrain,omitempty"`
+
+	// DrainThresholdPercent is the percentage of healthy endpoints below which
+	// the backends in a (cluster, zone) are drained.
+	// Default to 25.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	DrainThresholdPercent *int32 `json:"drainThresholdPercent,omitempty"`
 }
 
 // FailoverConfig contains configurations for failover behaviors.
@@ -113,4 +182,16 @@ type FailoverConfig struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=100
 	FailoverHealthThreshold *int32 `json:"failoverHealthThreshold,omitempty"`
+
+	// FailoverRegions is the ordered list of regions that receive traffic
+	// when the backends in the region of the client fall below
+	// FailoverHealthThreshold. When not specified, the load balancer chooses
+	// the failover regions.
+	//
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:MaxLength=63
+	// +kubebuilder:validation:items:Pattern=`^[a-z]+-[a-z]+[0-9]+$`
+	// +optional
+	FailoverRegions []string `json:"failoverRegions,omitempty"`
 }
@@ -100,6 +100,9 @@ type HealthCheckPolicySpec struct {
 // +kubebuilder:validation:XValidation:rule="has(self.checkIntervalSec) && has(self.timeoutSec) ? self.checkIntervalSec >= self.timeoutSec : true",message="timeOutSec cannot exceed checkIntervalSec"
 // +kubebuilder:validation:XValidation:rule="!has(self.checkIntervalSec) && has(self.timeoutSec) ? 5 >= self.timeoutSec : true",message="when checkIntervalSec is unspecified, timeOutSec cannot exceed 5, which is the default value of checkIntervalSec"
 // +kubebuilder:validation:XValidation:rule="has(self.checkIntervalSec) && !has(self.timeoutSec) ? self.checkIntervalSec >= 5 : true",message="when timeoutSec is unspecified, checkIntervalSec must be at least 5, which is the default value of timeoutSec"
+// +kubebuilder:validation:XValidation:rule="!(has(self.config) && has(self.checks))",message="only one of config and checks can be specified"
+// +kubebuilder:validation:XValidation:rule="!has(self.combination) || has(self.checks)",message="combination can only be specified with checks"
+// +kubebuilder:validation:XValidation:rule="!has(self.fromReadinessProbe) || !self.fromReadinessProbe || !(has(self.config) || has(self.checks))",message="fromReadinessProbe can only be enabled if neither config nor checks is specified"
 type HealthCheckPolicyConfig struct {
 	// How often (in seconds) to send a health check.
 	// If not specified, a default value of 5 seconds will be used.
@@ -128,7 +131,33 @@ type HealthCheckPolicyConfig struct {
 	// Config contains per protocol (i.e. HTTP, HTTPS, HTTP2, TCP, GRPC) configuration.
 	// If not specified, health check type defaults to HTTP.
 	Config *HealthCheck `json:"config,omitempty"`
+	// Checks defines multiple named health checks, for example a gRPC health
+	// check on the serving port and an HTTP readiness check on an admin port.
+	// Combination determines how their results are combined.
+	// Only one of Config and Checks can be specified.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=4
+	// +optional
+	Checks []NamedHealthCheck `json:"checks,omitempty"`
+	// Combination specifies how the results of Checks are combined, either
+	// All, where an endpoint is healthy only if all checks pass, or Any,
+	// where an endpoint is healthy if at least one check passes.
+	// If not specified, this defaults to All.
+	// +kubebuilder:validation:Enum=All;Any
+	// +optional
+	Combination *HealthCheckCombination `json:"combination,omitempty"`
+	// FromReadinessProbe derives the health check from the readinessProbe of
+	// the Pods that back the targeted Service when neither Config nor Checks
+	// is specified. The probe period, timeout and thresholds are used for the
+	// fields of this configuration that are not specified.
+	// If not specified, this defaults to false.
+	// +optional
+	FromReadinessProbe *bool `json:"fromReadinessProbe,omitempty"`
 	// LogConfig configures logging on this health check.
+	// Only Enabled is supported for health check logging.
+	// +kubebuilder:validation:XValidation:rule="!has(self.sampleRate) && !has(self.optionalMode) && !has(self.optionalFields)",message="only enabled is supported for health check logging"
 	LogConfig *LogConfig `json:"logConfig,omitempty"`
 }
 
@@ -171,6 +200,29 @@ type HealthCheck struct {
 	GRPC *GRPCHealthCheck `json:"grpcHealthCheck,omitempty"`
 }
 
+// HealthCheckCombination specifies how the results of multiple health checks
+// are combined.
+type HealthCheckCombination string
+
+const (
+	// HealthCheckCombinationAll requires all health checks to pass.
+	HealthCheckCombinationAll HealthCheckCombination = "All"
+	// HealthCheckCombinationAny requires at least one health check to pass.
+	HealthCheckCombinationAny HealthCheckCombination = "Any"
+)
+
+// NamedHealthCheck is a health check with a name.
+type NamedHealthCheck struct {
+	// Name is the name of the health check. It must be unique within the
+	// policy.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Config contains the protocol configuration of the health check.
+	Config HealthCheck `json:"config"`
+}
+
 // CommonHealthCheck holds all the fields that are common across all protocol health checks.
 // +union
 // +kubebuilder:validation:XValidation:rule="self.portSpecification == 'USE_FIXED_PORT' ? has(self.port) && !has(self.portName) : true",message="for portSpecification being USE_FIXED_PORT, port must be set and portName must be unset"
@@ -286,12 +338,9 @@ type GRPCHealthCheck struct {
 }
 
 // LogConfig configures logging on this health check.
-type LogConfig struct {
-	// Enabled indicates whether or not to export health check logs. If not
-	// specified, this defaults to false, which means health check logging will be
-	// disabled.
-	Enabled *bool `json:"enabled,omitempty"`
-}
+// It shares LoggingConfig with GCPBackendPolicy. Health check logs are neither
+// sampled nor have optional fields, so only Enabled may be set.
+type LogConfig = LoggingConfig
 
 // HealthCheckPolicyStatus defines the observed state of HealthCheckPolicy.
 type HealthCheckPolicyStatus struct {
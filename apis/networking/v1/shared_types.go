@@ -38,6 +38,10 @@ const (
 	// Possible reasons for this condition to be False are:
 	//
 	// * "Conflicted"
+	// * "Invalid"
+	// * "TargetNotFound"
+	// * "RefNotPermitted"
+	// * "InvalidRef"
 	//
 	PolicyConditionAttached PolicyConditionType = "Attached"
 
@@ -57,6 +61,14 @@ const (
 	// PolicyReasonTargetNotFound is used with the "Attached" condition when the policy is attached to
 	// an invalid target resource
 	PolicyReasonTargetNotFound PolicyConditionReason = "TargetNotFound"
+
+	// PolicyReasonRefNotPermitted is used with the "Attached" condition when the policy
+	// references an object in another namespace and no ReferenceGrant allows it.
+	PolicyReasonRefNotPermitted PolicyConditionReason = "RefNotPermitted"
+
+	// PolicyReasonInvalidRef is used with the "Attached" condition when an object
+	// referenced by the policy does not exist or is invalid.
+	PolicyReasonInvalidRef PolicyConditionReason = "InvalidRef"
 )
 
 // ExtensionConditionType is a type of condition for a extension.
@@ -479,9 +491,106 @@ const (
 	BodySendModeFullDuplexStreamed BodySendMode = "FullDuplexStreamed"
 )
 
+// LoggingOptionalMode specifies which optional fields are included in the logs.
+// +kubebuilder:validation:Enum=INCLUDE_ALL_OPTIONAL;EXCLUDE_ALL_OPTIONAL;CUSTOM
+type LoggingOptionalMode string
+
+const (
+	// LoggingIncludeAllOptional includes all optional fields in the logs.
+	LoggingIncludeAllOptional LoggingOptionalMode = "INCLUDE_ALL_OPTIONAL"
+	// LoggingExcludeAllOptional excludes all optional fields from the logs.
+	// This is the default.
+	LoggingExcludeAllOptional LoggingOptionalMode = "EXCLUDE_ALL_OPTIONAL"
+	// LoggingCustom includes the optional fields listed in OptionalFields.
+	LoggingCustom LoggingOptionalMode = "CUSTOM"
+)
+
+// LoggingConfig contains configuration for logging.
+// It is shared by GCPBackendPolicy and HealthCheckPolicy.
+// See logConfig in https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
+// +kubebuilder:validation:XValidation:rule="!has(self.sampleRate) || (has(self.enabled) && self.enabled)",message="sampleRate can only be specified if logging is enabled"
+// +kubebuilder:validation:XValidation:rule="!has(self.optionalMode) || (has(self.enabled) && self.enabled)",message="optionalMode can only be specified if logging is enabled"
+// +kubebuilder:validation:XValidation:rule="!has(self.optionalFields) || (has(self.optionalMode) && self.optionalMode == 'CUSTOM')",message="optionalFields can only be specified if optionalMode is CUSTOM"
+type LoggingConfig struct {
+	// Enabled denotes whether to enable logging for the load balancer traffic
+	// served by this backend service, or for the results of this health check.
+	// If not specified, this defaults to false, which means logging is disabled
+	// by default.
+	Enabled *bool `json:"enabled,omitempty"`
+	// This field can only be specified if logging is enabled for this backend
+	// service. The value of the field must be in range [0, 1e6]. This is
+	// converted to a floating point value in the range [0, 1] by dividing by 1e6
+	// for use with the GCE api and interpreted as the proportion of requests that
+	// will be logged. By default all requests will be logged.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000000
+	SampleRate *int32 `json:"sampleRate,omitempty"`
+	// OptionalMode specifies which optional fields are logged, one of
+	// INCLUDE_ALL_OPTIONAL, EXCLUDE_ALL_OPTIONAL or CUSTOM.
+	// This field can only be specified if logging is enabled.
+	// If not specified, this defaults to EXCLUDE_ALL_OPTIONAL.
+	// +optional
+	OptionalMode *LoggingOptionalMode `json:"optionalMode,omitempty"`
+	// OptionalFields is a list of optional fields to include in the logs,
+	// for example "tls.protocol" or "orca_load_report".
+	// This field can only be specified if OptionalMode is CUSTOM.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	OptionalFields []LoggingOptionalField `json:"optionalFields,omitempty"`
+}
+
+// LoggingOptionalField is the name of an optional log field.
+// +kubebuilder:validation:MinLength=1
+// +kubebuilder:validation:MaxLength=256
+// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.]+$`
+type LoggingOptionalField string
+
 // HTTPHeaderName is the name of the HTTP header.
 type HTTPHeaderName v1.HTTPHeaderName
 
+// ConsistentHashConfig contains the configuration of consistent hash based
+// load balancing. It is shared by GCPBackendPolicy and
+// GCPTrafficDistributionPolicy and only applies when the locality load
+// balancing algorithm is RING_HASH or MAGLEV.
+// See consistentHash in https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
+// +kubebuilder:validation:XValidation:rule="!(has(self.httpHeaderName) && has(self.httpCookie))",message="only one of httpHeaderName and httpCookie can be specified"
+type ConsistentHashConfig struct {
+	// HTTPHeaderName is the name of the request header whose value is hashed.
+	// It is used with HEADER_FIELD session affinity.
+	// +optional
+	HTTPHeaderName *HTTPHeaderName `json:"httpHeaderName,omitempty"`
+	// HTTPCookie identifies the cookie whose value is hashed. If the cookie is
+	// not present in the request, it is generated by the load balancer.
+	// It is used with HTTP_COOKIE session affinity.
+	// +optional
+	HTTPCookie *ConsistentHashHTTPCookie `json:"httpCookie,omitempty"`
+	// MinimumRingSize is the minimum number of virtual nodes to use for the
+	// hash ring. Larger rings reduce the variance of the traffic distribution.
+	// It only applies to the RING_HASH algorithm.
+	// If not specified, a default value of 1024 will be used.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8388608
+	// +optional
+	MinimumRingSize *int64 `json:"minimumRingSize,omitempty"`
+}
+
+// ConsistentHashHTTPCookie identifies the cookie used for consistent hashing.
+type ConsistentHashHTTPCookie struct {
+	// Name is the name of the cookie.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[!#$%&'*+\-.^_|~0-9A-Za-z]+$`
+	Name string `json:"name"`
+	// Path is the path of the cookie.
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Path *string `json:"path,omitempty"`
+	// TTL is the lifetime of the cookie. If not specified, the cookie is
+	// non-persistent and lasts only until the end of the browser session.
+	// +optional
+	TTL *v1.Duration `json:"ttl,omitempty"`
+}
+
 // MetadataKey is the key of an metadata in GCP Extensions.
 // The CEL validation may be removed from this field in the future because
 // in conjunction with the Metadata map object it is ignored and performed at the map level.
@@ -34,6 +34,11 @@ func (in *AutoCapacityDrain) DeepCopyInto(out *AutoCapacityDrain) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DrainThresholdPercent != nil {
+		in, out := &in.DrainThresholdPercent, &out.DrainThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoCapacityDrain.
@@ -46,6 +51,199 @@ func (in *AutoCapacityDrain) DeepCopy() *AutoCapacityDrain {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendTLSConfig) DeepCopyInto(out *BackendTLSConfig) {
+	*out = *in
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(apisv1.PreciseHostname)
+		**out = **in
+	}
+	if in.SubjectAltNames != nil {
+		in, out := &in.SubjectAltNames, &out.SubjectAltNames
+		*out = make([]apisv1.SubjectAltName, len(*in))
+		copy(*out, *in)
+	}
+	if in.CACertificateRefs != nil {
+		in, out := &in.CACertificateRefs, &out.CACertificateRefs
+		*out = make([]apisv1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.WellKnownCACertificates != nil {
+		in, out := &in.WellKnownCACertificates, &out.WellKnownCACertificates
+		*out = new(apisv1.WellKnownCACertificatesType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendTLSConfig.
+func (in *BackendTLSConfig) DeepCopy() *BackendTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDNCacheKeyPolicy) DeepCopyInto(out *CDNCacheKeyPolicy) {
+	*out = *in
+	if in.IncludeProtocol != nil {
+		in, out := &in.IncludeProtocol, &out.IncludeProtocol
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IncludeHost != nil {
+		in, out := &in.IncludeHost, &out.IncludeHost
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IncludeQueryString != nil {
+		in, out := &in.IncludeQueryString, &out.IncludeQueryString
+		*out = new(bool)
+		**out = **in
+	}
+	if in.QueryStringIncludeList != nil {
+		in, out := &in.QueryStringIncludeList, &out.QueryStringIncludeList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueryStringExcludeList != nil {
+		in, out := &in.QueryStringExcludeList, &out.QueryStringExcludeList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeHTTPHeaders != nil {
+		in, out := &in.IncludeHTTPHeaders, &out.IncludeHTTPHeaders
+		*out = make([]HTTPHeaderName, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeNamedCookies != nil {
+		in, out := &in.IncludeNamedCookies, &out.IncludeNamedCookies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDNCacheKeyPolicy.
+func (in *CDNCacheKeyPolicy) DeepCopy() *CDNCacheKeyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CDNCacheKeyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDNConfig) DeepCopyInto(out *CDNConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CacheMode != nil {
+		in, out := &in.CacheMode, &out.CacheMode
+		*out = new(CDNCacheMode)
+		**out = **in
+	}
+	if in.DefaultTTL != nil {
+		in, out := &in.DefaultTTL, &out.DefaultTTL
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.MaxTTL != nil {
+		in, out := &in.MaxTTL, &out.MaxTTL
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.ClientTTL != nil {
+		in, out := &in.ClientTTL, &out.ClientTTL
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.NegativeCaching != nil {
+		in, out := &in.NegativeCaching, &out.NegativeCaching
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NegativeCachingPolicy != nil {
+		in, out := &in.NegativeCachingPolicy, &out.NegativeCachingPolicy
+		*out = make([]CDNNegativeCachingPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.CacheKeyPolicy != nil {
+		in, out := &in.CacheKeyPolicy, &out.CacheKeyPolicy
+		*out = new(CDNCacheKeyPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SignedURLKeys != nil {
+		in, out := &in.SignedURLKeys, &out.SignedURLKeys
+		*out = make([]CDNSignedURLKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SignedURLCacheMaxAge != nil {
+		in, out := &in.SignedURLCacheMaxAge, &out.SignedURLCacheMaxAge
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.ServeWhileStale != nil {
+		in, out := &in.ServeWhileStale, &out.ServeWhileStale
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.RequestCoalescing != nil {
+		in, out := &in.RequestCoalescing, &out.RequestCoalescing
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDNConfig.
+func (in *CDNConfig) DeepCopy() *CDNConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CDNConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDNNegativeCachingPolicy) DeepCopyInto(out *CDNNegativeCachingPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDNNegativeCachingPolicy.
+func (in *CDNNegativeCachingPolicy) DeepCopy() *CDNNegativeCachingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CDNNegativeCachingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CDNSignedURLKey) DeepCopyInto(out *CDNSignedURLKey) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CDNSignedURLKey.
+func (in *CDNSignedURLKey) DeepCopy() *CDNSignedURLKey {
+	if in == nil {
+		return nil
+	}
+	out := new(CDNSignedURLKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CELExpression) DeepCopyInto(out *CELExpression) {
 	*out = *in
@@ -66,6 +264,41 @@ func (in *CELExpression) DeepCopy() *CELExpression {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakers) DeepCopyInto(out *CircuitBreakers) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPendingRequests != nil {
+		in, out := &in.MaxPendingRequests, &out.MaxPendingRequests
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRequests != nil {
+		in, out := &in.MaxRequests, &out.MaxRequests
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakers.
+func (in *CircuitBreakers) DeepCopy() *CircuitBreakers {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakers)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonHTTPHealthCheck) DeepCopyInto(out *CommonHTTPHealthCheck) {
 	*out = *in
@@ -151,6 +384,76 @@ func (in *ConnectionDraining) DeepCopy() *ConnectionDraining {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistentHashConfig) DeepCopyInto(out *ConsistentHashConfig) {
+	*out = *in
+	if in.HTTPHeaderName != nil {
+		in, out := &in.HTTPHeaderName, &out.HTTPHeaderName
+		*out = new(HTTPHeaderName)
+		**out = **in
+	}
+	if in.HTTPCookie != nil {
+		in, out := &in.HTTPCookie, &out.HTTPCookie
+		*out = new(ConsistentHashHTTPCookie)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinimumRingSize != nil {
+		in, out := &in.MinimumRingSize, &out.MinimumRingSize
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistentHashConfig.
+func (in *ConsistentHashConfig) DeepCopy() *ConsistentHashConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistentHashConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistentHashHTTPCookie) DeepCopyInto(out *ConsistentHashHTTPCookie) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistentHashHTTPCookie.
+func (in *ConsistentHashHTTPCookie) DeepCopy() *ConsistentHashHTTPCookie {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistentHashHTTPCookie)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomHeader) DeepCopyInto(out *CustomHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomHeader.
+func (in *CustomHeader) DeepCopy() *CustomHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Extension) DeepCopyInto(out *Extension) {
 	*out = *in
@@ -239,6 +542,11 @@ func (in *FailoverConfig) DeepCopyInto(out *FailoverConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.FailoverRegions != nil {
+		in, out := &in.FailoverRegions, &out.FailoverRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverConfig.
@@ -281,6 +589,148 @@ func (in *GCPAuthPolicyRule) DeepCopy() *GCPAuthPolicyRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPAuthzExtension) DeepCopyInto(out *GCPAuthzExtension) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPAuthzExtension.
+func (in *GCPAuthzExtension) DeepCopy() *GCPAuthzExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPAuthzExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPAuthzExtension) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPAuthzExtensionList) DeepCopyInto(out *GCPAuthzExtensionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPAuthzExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPAuthzExtensionList.
+func (in *GCPAuthzExtensionList) DeepCopy() *GCPAuthzExtensionList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPAuthzExtensionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPAuthzExtensionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPAuthzExtensionRequestBody) DeepCopyInto(out *GCPAuthzExtensionRequestBody) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPAuthzExtensionRequestBody.
+func (in *GCPAuthzExtensionRequestBody) DeepCopy() *GCPAuthzExtensionRequestBody {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPAuthzExtensionRequestBody)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPAuthzExtensionSpec) DeepCopyInto(out *GCPAuthzExtensionSpec) {
+	*out = *in
+	out.BackendRef = in.BackendRef
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.StatusOnError != nil {
+		in, out := &in.StatusOnError, &out.StatusOnError
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ForwardHeaders != nil {
+		in, out := &in.ForwardHeaders, &out.ForwardHeaders
+		*out = make([]HTTPHeaderName, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeadersToUpstreamOnAllow != nil {
+		in, out := &in.HeadersToUpstreamOnAllow, &out.HeadersToUpstreamOnAllow
+		*out = make([]HTTPHeaderName, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeadersToDownstreamOnDeny != nil {
+		in, out := &in.HeadersToDownstreamOnDeny, &out.HeadersToDownstreamOnDeny
+		*out = make([]HTTPHeaderName, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeRequestBody != nil {
+		in, out := &in.IncludeRequestBody, &out.IncludeRequestBody
+		*out = new(GCPAuthzExtensionRequestBody)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPAuthzExtensionSpec.
+func (in *GCPAuthzExtensionSpec) DeepCopy() *GCPAuthzExtensionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPAuthzExtensionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPAuthzExtensionStatus) DeepCopyInto(out *GCPAuthzExtensionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPAuthzExtensionStatus.
+func (in *GCPAuthzExtensionStatus) DeepCopy() *GCPAuthzExtensionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPAuthzExtensionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPAuthzPolicy) DeepCopyInto(out *GCPAuthzPolicy) {
 	*out = *in
@@ -424,6 +874,68 @@ func (in *GCPAuthzPolicyOperation) DeepCopy() *GCPAuthzPolicyOperation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPAuthzPolicyRequestAuth) DeepCopyInto(out *GCPAuthzPolicyRequestAuth) {
+	*out = *in
+	if in.Issuers != nil {
+		in, out := &in.Issuers, &out.Issuers
+		*out = make([]StringMatchCriteria, len(*in))
+		copy(*out, *in)
+	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]StringMatchCriteria, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestPrincipals != nil {
+		in, out := &in.RequestPrincipals, &out.RequestPrincipals
+		*out = make([]StringMatchCriteria, len(*in))
+		copy(*out, *in)
+	}
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]JWTClaimMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPAuthzPolicyRequestAuth.
+func (in *GCPAuthzPolicyRequestAuth) DeepCopy() *GCPAuthzPolicyRequestAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPAuthzPolicyRequestAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPAuthzPolicyResource) DeepCopyInto(out *GCPAuthzPolicyResource) {
+	*out = *in
+	if in.TagValueIDSet != nil {
+		in, out := &in.TagValueIDSet, &out.TagValueIDSet
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	if in.IAMServiceAccount != nil {
+		in, out := &in.IAMServiceAccount, &out.IAMServiceAccount
+		*out = new(StringMatchCriteria)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPAuthzPolicyResource.
+func (in *GCPAuthzPolicyResource) DeepCopy() *GCPAuthzPolicyResource {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPAuthzPolicyResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPAuthzPolicySource) DeepCopyInto(out *GCPAuthzPolicySource) {
 	*out = *in
@@ -441,6 +953,21 @@ func (in *GCPAuthzPolicySource) DeepCopyInto(out *GCPAuthzPolicySource) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.IPBlocks != nil {
+		in, out := &in.IPBlocks, &out.IPBlocks
+		*out = make([]CIDR, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotIPBlocks != nil {
+		in, out := &in.NotIPBlocks, &out.NotIPBlocks
+		*out = make([]CIDR, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestAuth != nil {
+		in, out := &in.RequestAuth, &out.RequestAuth
+		*out = new(GCPAuthzPolicyRequestAuth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPAuthzPolicySource.
@@ -473,6 +1000,11 @@ func (in *GCPAuthzPolicySpec) DeepCopyInto(out *GCPAuthzPolicySpec) {
 		*out = new(GCPAuthzPolicyCustomProviders)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EnforcementMode != nil {
+		in, out := &in.EnforcementMode, &out.EnforcementMode
+		*out = new(GCPAuthzPolicyEnforcementMode)
+		**out = **in
+	}
 	if in.TargetRefs != nil {
 		in, out := &in.TargetRefs, &out.TargetRefs
 		*out = make([]LocalObjectReference, len(*in))
@@ -576,6 +1108,11 @@ func (in *GCPBackendPolicyConfig) DeepCopyInto(out *GCPBackendPolicyConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.EdgeSecurityPolicy != nil {
+		in, out := &in.EdgeSecurityPolicy, &out.EdgeSecurityPolicy
+		*out = new(string)
+		**out = **in
+	}
 	if in.IAP != nil {
 		in, out := &in.IAP, &out.IAP
 		*out = new(IdentityAwareProxyConfig)
@@ -586,11 +1123,51 @@ func (in *GCPBackendPolicyConfig) DeepCopyInto(out *GCPBackendPolicyConfig) {
 		*out = new(int64)
 		**out = **in
 	}
-	if in.BackendPreference != nil {
-		in, out := &in.BackendPreference, &out.BackendPreference
-		*out = new(string)
+	if in.BackendPreference != nil {
+		in, out := &in.BackendPreference, &out.BackendPreference
+		*out = new(string)
+		**out = **in
+	}
+	if in.CircuitBreakers != nil {
+		in, out := &in.CircuitBreakers, &out.CircuitBreakers
+		*out = new(CircuitBreakers)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OutlierDetection != nil {
+		in, out := &in.OutlierDetection, &out.OutlierDetection
+		*out = new(OutlierDetection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConsistentHash != nil {
+		in, out := &in.ConsistentHash, &out.ConsistentHash
+		*out = new(ConsistentHashConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomRequestHeaders != nil {
+		in, out := &in.CustomRequestHeaders, &out.CustomRequestHeaders
+		*out = make([]CustomHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomResponseHeaders != nil {
+		in, out := &in.CustomResponseHeaders, &out.CustomResponseHeaders
+		*out = make([]CustomHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.CDN != nil {
+		in, out := &in.CDN, &out.CDN
+		*out = new(CDNConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(BackendProtocol)
 		**out = **in
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(BackendTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPBackendPolicyConfig.
@@ -892,6 +1469,94 @@ func (in *GCPGatewayPolicyStatus) DeepCopy() *GCPGatewayPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPRequestAuthenticationPolicy) DeepCopyInto(out *GCPRequestAuthenticationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPRequestAuthenticationPolicy.
+func (in *GCPRequestAuthenticationPolicy) DeepCopy() *GCPRequestAuthenticationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPRequestAuthenticationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPRequestAuthenticationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPRequestAuthenticationPolicyList) DeepCopyInto(out *GCPRequestAuthenticationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPRequestAuthenticationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPRequestAuthenticationPolicyList.
+func (in *GCPRequestAuthenticationPolicyList) DeepCopy() *GCPRequestAuthenticationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPRequestAuthenticationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPRequestAuthenticationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPRequestAuthenticationPolicySpec) DeepCopyInto(out *GCPRequestAuthenticationPolicySpec) {
+	*out = *in
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]LocalObjectReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JWTRules != nil {
+		in, out := &in.JWTRules, &out.JWTRules
+		*out = make([]JWTRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPRequestAuthenticationPolicySpec.
+func (in *GCPRequestAuthenticationPolicySpec) DeepCopy() *GCPRequestAuthenticationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPRequestAuthenticationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPServerTLSPolicy) DeepCopyInto(out *GCPServerTLSPolicy) {
 	*out = *in
@@ -1222,6 +1887,11 @@ func (in *GCPTrafficDistributionPolicyConfig) DeepCopyInto(out *GCPTrafficDistri
 		*out = new(string)
 		**out = **in
 	}
+	if in.ConsistentHash != nil {
+		in, out := &in.ConsistentHash, &out.ConsistentHash
+		*out = new(ConsistentHashConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AutoCapacityDrain != nil {
 		in, out := &in.AutoCapacityDrain, &out.AutoCapacityDrain
 		*out = new(AutoCapacityDrain)
@@ -1232,6 +1902,13 @@ func (in *GCPTrafficDistributionPolicyConfig) DeepCopyInto(out *GCPTrafficDistri
 		*out = new(FailoverConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LocalityWeights != nil {
+		in, out := &in.LocalityWeights, &out.LocalityWeights
+		*out = make([]LocalityWeight, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPTrafficDistributionPolicyConfig.
@@ -1569,6 +2246,23 @@ func (in *HealthCheckPolicyConfig) DeepCopyInto(out *HealthCheckPolicyConfig) {
 		*out = new(HealthCheck)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]NamedHealthCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Combination != nil {
+		in, out := &in.Combination, &out.Combination
+		*out = new(HealthCheckCombination)
+		**out = **in
+	}
+	if in.FromReadinessProbe != nil {
+		in, out := &in.FromReadinessProbe, &out.FromReadinessProbe
+		*out = new(bool)
+		**out = **in
+	}
 	if in.LogConfig != nil {
 		in, out := &in.LogConfig, &out.LogConfig
 		*out = new(LogConfig)
@@ -1691,6 +2385,153 @@ func (in *IdentityAwareProxyConfig) DeepCopy() *IdentityAwareProxyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWKSConfigMapReference) DeepCopyInto(out *JWKSConfigMapReference) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWKSConfigMapReference.
+func (in *JWKSConfigMapReference) DeepCopy() *JWKSConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(JWKSConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimMatch) DeepCopyInto(out *JWTClaimMatch) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]StringMatchCriteria, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimMatch.
+func (in *JWTClaimMatch) DeepCopy() *JWTClaimMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTClaimToHeader) DeepCopyInto(out *JWTClaimToHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaimToHeader.
+func (in *JWTClaimToHeader) DeepCopy() *JWTClaimToHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTClaimToHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTHeaderLocation) DeepCopyInto(out *JWTHeaderLocation) {
+	*out = *in
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTHeaderLocation.
+func (in *JWTHeaderLocation) DeepCopy() *JWTHeaderLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTHeaderLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTRule) DeepCopyInto(out *JWTRule) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.JWKSURI != nil {
+		in, out := &in.JWKSURI, &out.JWKSURI
+		*out = new(string)
+		**out = **in
+	}
+	if in.JWKS != nil {
+		in, out := &in.JWKS, &out.JWKS
+		*out = new(JWKSConfigMapReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenLocations != nil {
+		in, out := &in.TokenLocations, &out.TokenLocations
+		*out = make([]JWTTokenLocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ForwardOriginalToken != nil {
+		in, out := &in.ForwardOriginalToken, &out.ForwardOriginalToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ClaimToHeaders != nil {
+		in, out := &in.ClaimToHeaders, &out.ClaimToHeaders
+		*out = make([]JWTClaimToHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTRule.
+func (in *JWTRule) DeepCopy() *JWTRule {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTTokenLocation) DeepCopyInto(out *JWTTokenLocation) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(JWTHeaderLocation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cookie != nil {
+		in, out := &in.Cookie, &out.Cookie
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTTokenLocation.
+func (in *JWTTokenLocation) DeepCopy() *JWTTokenLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTTokenLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalObjectReference) DeepCopyInto(out *LocalObjectReference) {
 	*out = *in
@@ -1727,21 +2568,36 @@ func (in *LocalPolicyTargetReference) DeepCopy() *LocalPolicyTargetReference {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogConfig) DeepCopyInto(out *LogConfig) {
+func (in *LocalityWeight) DeepCopyInto(out *LocalityWeight) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(string)
+		**out = **in
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CapacityScalerPercent != nil {
+		in, out := &in.CapacityScalerPercent, &out.CapacityScalerPercent
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogConfig.
-func (in *LogConfig) DeepCopy() *LogConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalityWeight.
+func (in *LocalityWeight) DeepCopy() *LocalityWeight {
 	if in == nil {
 		return nil
 	}
-	out := new(LogConfig)
+	out := new(LocalityWeight)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1759,6 +2615,16 @@ func (in *LoggingConfig) DeepCopyInto(out *LoggingConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.OptionalMode != nil {
+		in, out := &in.OptionalMode, &out.OptionalMode
+		*out = new(LoggingOptionalMode)
+		**out = **in
+	}
+	if in.OptionalFields != nil {
+		in, out := &in.OptionalFields, &out.OptionalFields
+		*out = make([]LoggingOptionalField, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingConfig.
@@ -1793,6 +2659,22 @@ func (in *MatchCondition) DeepCopy() *MatchCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedHealthCheck) DeepCopyInto(out *NamedHealthCheck) {
+	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedHealthCheck.
+func (in *NamedHealthCheck) DeepCopy() *NamedHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Oauth2ClientSecret) DeepCopyInto(out *Oauth2ClientSecret) {
 	*out = *in
@@ -1801,6 +2683,11 @@ func (in *Oauth2ClientSecret) DeepCopyInto(out *Oauth2ClientSecret) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Oauth2ClientSecret.
@@ -1813,6 +2700,46 @@ func (in *Oauth2ClientSecret) DeepCopy() *Oauth2ClientSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetection) DeepCopyInto(out *OutlierDetection) {
+	*out = *in
+	if in.ConsecutiveErrors != nil {
+		in, out := &in.ConsecutiveErrors, &out.ConsecutiveErrors
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EnforcingConsecutiveErrors != nil {
+		in, out := &in.EnforcingConsecutiveErrors, &out.EnforcingConsecutiveErrors
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.BaseEjectionTime != nil {
+		in, out := &in.BaseEjectionTime, &out.BaseEjectionTime
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.MaxEjectionPercent != nil {
+		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetection.
+func (in *OutlierDetection) DeepCopy() *OutlierDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(OutlierDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyAncestorStatus) DeepCopyInto(out *PolicyAncestorStatus) {
 	*out = *in
@@ -1911,26 +2838,21 @@ func (in *Principal) DeepCopy() *Principal {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPAuthzPolicyResource) DeepCopyInto(out *GCPAuthzPolicyResource) {
+func (in *SecretObjectReference) DeepCopyInto(out *SecretObjectReference) {
 	*out = *in
-	if in.TagValueIDSet != nil {
-		in, out := &in.TagValueIDSet, &out.TagValueIDSet
-		*out = make([]int64, len(*in))
-		copy(*out, *in)
-	}
-	if in.IAMServiceAccount != nil {
-		in, out := &in.IAMServiceAccount, &out.IAMServiceAccount
-		*out = new(StringMatchCriteria)
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resource.
-func (in *GCPAuthzPolicyResource) DeepCopy() *GCPAuthzPolicyResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretObjectReference.
+func (in *SecretObjectReference) DeepCopy() *SecretObjectReference {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPAuthzPolicyResource)
+	out := new(SecretObjectReference)
 	in.DeepCopyInto(out)
 	return out
 }
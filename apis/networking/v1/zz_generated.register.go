@@ -61,6 +61,8 @@ func init() {
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&GCPAuthzExtension{},
+		&GCPAuthzExtensionList{},
 		&GCPAuthzPolicy{},
 		&GCPAuthzPolicyList{},
 		&GCPBackendPolicy{},
@@ -69,6 +71,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&GCPClientTLSPolicyList{},
 		&GCPGatewayPolicy{},
 		&GCPGatewayPolicyList{},
+		&GCPRequestAuthenticationPolicy{},
+		&GCPRequestAuthenticationPolicyList{},
 		&GCPServerTLSPolicy{},
 		&GCPServerTLSPolicyList{},
 		&GCPSessionAffinityFilter{},
@@ -0,0 +1,178 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Command authz-diff compares two versions of a GCPAuthzPolicy, or of the
+// set of GCPAuthzPolicies that apply to a target, and prints the semantic
+// changes and example requests whose decision flips.
+//
+//	authz-diff -old main/policy.yaml -new policy.yaml
+//
+// Reordered rules and match values are not reported, while changes such as
+// an ignoreCase flip or a Prefix match becoming Exact are. If both versions
+// hold a single policy they are compared even if their names differ;
+// otherwise policies are paired by namespace and name.
+//
+// Example requests are evaluated separately for each target, i.e. for each
+// namespace and targetRef, against the policies that apply to that target
+// only. Each flip is reported with its target.
+//
+// It exits with status 0 if there are no semantic changes, 1 if there are,
+// and 2 on usage errors.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz/diff"
+)
+
+type files []string
+
+func (f *files) String() string     { return strings.Join(*f, ",") }
+func (f *files) Set(v string) error { *f = append(*f, v); return nil }
+
+type outcome struct {
+	Result authz.Result `json:"result"`
+	Reason string       `json:"reason,omitempty"`
+}
+
+type flip struct {
+	Target  string  `json:"target"`
+	Request string  `json:"request"`
+	Old     outcome `json:"old"`
+	New     outcome `json:"new"`
+}
+
+type result struct {
+	Changes []diff.PolicyDiff `json:"changes"`
+	Flips   []flip            `json:"flips"`
+}
+
+func main() {
+	var oldFiles, newFiles files
+	flag.Var(&oldFiles, "old", "path of a YAML or JSON file of the old GCPAuthzPolicies (required, can be repeated)")
+	flag.Var(&newFiles, "new", "path of a YAML or JSON file of the new GCPAuthzPolicies (required, can be repeated)")
+	var (
+		output = flag.String("o", "text", "output format, text or json")
+		limit  = flag.Int("max", diff.DefaultLimit, "maximum number of example requests whose decision flips")
+	)
+	flag.Parse()
+	if len(oldFiles) == 0 || len(newFiles) == 0 {
+		usage("-old and -new are required")
+	}
+	if *output != "text" && *output != "json" {
+		usage("-o must be text or json")
+	}
+
+	before, err := readPolicies(oldFiles)
+	if err != nil {
+		fail(err)
+	}
+	after, err := readPolicies(newFiles)
+	if err != nil {
+		fail(err)
+	}
+	res := result{Changes: diff.Compare(before, after)}
+	flips, err := diff.Flips(before, after, *limit)
+	if err != nil {
+		fail(err)
+	}
+	for _, f := range flips {
+		res.Flips = append(res.Flips, flip{
+			Target:  f.Target,
+			Request: diff.Describe(f.Request),
+			Old:     outcome{Result: f.Old.Result, Reason: f.Old.Reason},
+			New:     outcome{Result: f.New.Result, Reason: f.New.Reason},
+		})
+	}
+
+	if *output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(res); err != nil {
+			fail(err)
+		}
+	} else {
+		printResult(res)
+	}
+	if len(res.Changes) > 0 {
+		os.Exit(1)
+	}
+}
+
+func readPolicies(paths []string) ([]*networkingv1.GCPAuthzPolicy, error) {
+	var all []*networkingv1.GCPAuthzPolicy
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		policies, err := authz.DecodePolicies(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		all = append(all, policies...)
+	}
+	return all, nil
+}
+
+func printResult(r result) {
+	if len(r.Changes) == 0 {
+		fmt.Println("no semantic changes")
+		return
+	}
+	if err := diff.Format(os.Stdout, r.Changes); err != nil {
+		fail(err)
+	}
+	if len(r.Flips) == 0 {
+		fmt.Println("no example request changes its decision")
+		return
+	}
+	fmt.Println("requests whose decision flips:")
+	target := ""
+	for _, f := range r.Flips {
+		if f.Target != target {
+			target = f.Target
+			fmt.Printf("  %s\n", target)
+		}
+		fmt.Printf("    %s\n      %s -> %s\n", f.Request, describe(f.Old), describe(f.New))
+	}
+}
+
+func describe(o outcome) string {
+	if o.Reason == "" {
+		return string(o.Result)
+	}
+	return fmt.Sprintf("%s (%s)", o.Result, o.Reason)
+}
+
+func usage(msg string) {
+	fmt.Fprintf(os.Stderr, "authz-diff: %s\n", msg)
+	flag.Usage()
+	os.Exit(2)
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "authz-diff: %v\n", err)
+	os.Exit(2)
+}
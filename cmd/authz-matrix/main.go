@@ -0,0 +1,141 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Command authz-matrix prints the access matrix of the GCPAuthzPolicies of a
+// cluster: whether each principal is allowed or denied access to each
+// workload, port and path prefix.
+//
+//	authz-matrix -f policies.yaml -f pods.yaml -f serviceaccounts.yaml
+//	authz-matrix -kubeconfig ~/.kube/config -n shop -o json
+//
+// The snapshot is read from YAML or JSON files of GCPAuthzPolicies, Pods and
+// ServiceAccounts, such as the output of kubectl get -o yaml, or from the
+// cluster of a kubeconfig. Cells are UNKNOWN if the decision depends on
+// attributes that the matrix does not model, such as methods, headers,
+// client addresses or JWT claims.
+//
+// It exits with status 0 on success and 2 on usage errors or if the
+// snapshot cannot be read.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz/matrix"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/client/clientset/versioned"
+)
+
+type files []string
+
+func (f *files) String() string     { return strings.Join(*f, ",") }
+func (f *files) Set(v string) error { *f = append(*f, v); return nil }
+
+func main() {
+	var paths files
+	flag.Var(&paths, "f", "path of a YAML or JSON file of GCPAuthzPolicies, Pods and ServiceAccounts (can be repeated)")
+	var (
+		kubeconfig  = flag.String("kubeconfig", "", "path of a kubeconfig to read the snapshot from a cluster")
+		namespace   = flag.String("n", "", "namespace of the workloads, all namespaces if empty")
+		trustDomain = flag.String("trust-domain", matrix.DefaultTrustDomain, "trust domain of the SPIFFE IDs of ServiceAccounts")
+		output      = flag.String("o", "csv", "output format, csv or json")
+	)
+	flag.Parse()
+	if (len(paths) == 0) == (*kubeconfig == "") {
+		usage("exactly one of -f and -kubeconfig is required")
+	}
+	if *output != "csv" && *output != "json" {
+		usage("-o must be csv or json")
+	}
+
+	var (
+		s   *matrix.Snapshot
+		err error
+	)
+	if *kubeconfig != "" {
+		s, err = fromCluster(*kubeconfig, *namespace)
+	} else {
+		s, err = readSnapshot(paths)
+	}
+	if err != nil {
+		fail(err)
+	}
+	m, err := matrix.Build(s, matrix.Options{TrustDomain: *trustDomain, Namespace: *namespace})
+	if err != nil {
+		fail(err)
+	}
+
+	if *output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(m)
+	} else {
+		err = matrix.WriteCSV(os.Stdout, m)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+func readSnapshot(paths []string) (*matrix.Snapshot, error) {
+	s := &matrix.Snapshot{}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		err = s.Decode(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	return s, nil
+}
+
+func fromCluster(kubeconfig, namespace string) (*matrix.Snapshot, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	kube, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	networking, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return matrix.FromCluster(context.Background(), kube, networking, namespace)
+}
+
+func usage(msg string) {
+	fmt.Fprintf(os.Stderr, "authz-matrix: %s\n", msg)
+	flag.Usage()
+	os.Exit(2)
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "authz-matrix: %v\n", err)
+	os.Exit(2)
+}
@@ -0,0 +1,185 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Command authz-replay replays a JSON lines request log through the
+// GCPAuthzPolicies that apply to a Gateway or workload, and reports the
+// requests that the policies in DryRun mode would deny or allow if they were
+// enforced.
+//
+//	authz-replay -f policies.yaml -log requests.jsonl
+//
+// Each line of the log is a request, for example
+//
+//	{"method":"GET","host":"api.example.com","path":"/v1/orders","sourceIp":"203.0.113.7","headers":{"x-tenant":"acme"}}
+//
+// or a Cloud Logging entry of a load balancer request log. See the replay
+// package for the format.
+//
+// It exits with status 0 if no request would be denied, 1 if some would, and
+// 2 on usage errors.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"k8s.io/utils/ptr"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz/replay"
+)
+
+type files []string
+
+func (f *files) String() string     { return strings.Join(*f, ",") }
+func (f *files) Set(v string) error { *f = append(*f, v); return nil }
+
+func main() {
+	var policyFiles files
+	flag.Var(&policyFiles, "f", "path of a YAML or JSON file of GCPAuthzPolicies (required, can be repeated)")
+	var (
+		logFile = flag.String("log", "-", "path of the JSON lines request log, - for stdin")
+		dryRun  = flag.Bool("dry-run", false, "evaluate all policies in DryRun mode, to test policies that are not deployed yet")
+		output  = flag.String("o", "text", "output format, text or json")
+		limit   = flag.Int("max", 20, "maximum number of requests listed per finding in text output; 0 lists all")
+	)
+	flag.Parse()
+	if len(policyFiles) == 0 {
+		usage("-f is required")
+	}
+	if *output != "text" && *output != "json" {
+		usage("-o must be text or json")
+	}
+
+	e := &authz.Evaluator{}
+	for _, path := range policyFiles {
+		policies, err := readPolicies(path)
+		if err != nil {
+			fail(err)
+		}
+		for _, p := range policies {
+			if *dryRun {
+				p.Spec.EnforcementMode = ptr.To(networkingv1.DryRun)
+			}
+			cp, err := authz.Compile(p)
+			if err != nil {
+				fail(err)
+			}
+			e.Policies = append(e.Policies, cp)
+		}
+	}
+
+	var in io.Reader = os.Stdin
+	if *logFile != "-" {
+		f, err := os.Open(*logFile)
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		in = f
+	}
+	report, err := replay.Replay(in, e)
+	if err != nil {
+		fail(err)
+	}
+
+	if *output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fail(err)
+		}
+	} else {
+		printReport(report, *limit)
+	}
+	if len(report.WouldDeny) > 0 {
+		os.Exit(1)
+	}
+}
+
+func readPolicies(path string) ([]*networkingv1.GCPAuthzPolicy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	policies, err := authz.DecodePolicies(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("%s contains no GCPAuthzPolicy", path)
+	}
+	return policies, nil
+}
+
+func printReport(r *replay.Report, limit int) {
+	fmt.Printf("replayed %d requests\n", r.Requests)
+	fmt.Printf("enforced: %s\n", counts(r.Enforced))
+	fmt.Printf("dry run:  %s\n", counts(r.DryRun))
+	printFindings("would be denied", r.WouldDeny, limit)
+	if len(r.DeniedBy) > 0 {
+		var names []string
+		for name := range r.DeniedBy {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Println("denials by policy:")
+		for _, name := range names {
+			fmt.Printf("  %s: %d\n", name, r.DeniedBy[name])
+		}
+	}
+	printFindings("would be allowed", r.WouldAllow, limit)
+	for _, e := range r.Errors {
+		fmt.Printf("line %d: %s\n", e.Line, e.Error)
+	}
+}
+
+func counts(m map[authz.Result]int) string {
+	return fmt.Sprintf("%d allowed, %d denied, %d delegated", m[authz.Allowed], m[authz.Denied], m[authz.Delegated])
+}
+
+func printFindings(title string, findings []replay.Finding, limit int) {
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Printf("%d requests %s:\n", len(findings), title)
+	for i, f := range findings {
+		if limit > 0 && i == limit {
+			fmt.Printf("  ... %d more\n", len(findings)-limit)
+			break
+		}
+		req, _ := f.Record.Request()
+		fmt.Printf("  line %d: %s %s%s: %s\n", f.Line, req.Method, req.Host, req.Path, f.DryRun.Reason)
+	}
+}
+
+func usage(msg string) {
+	fmt.Fprintf(os.Stderr, "authz-replay: %s\n", msg)
+	flag.Usage()
+	os.Exit(2)
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "authz-replay: %v\n", err)
+	os.Exit(2)
+}
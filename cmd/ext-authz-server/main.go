@@ -0,0 +1,93 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Command ext-authz-server runs the reference ext_authz gRPC server of the
+// extauthz package, to exercise GCPAuthzExtensions and CUSTOM
+// GCPAuthzPolicies locally.
+//
+//	ext-authz-server -addr :9000
+//
+// Requests with the header `x-ext-authz: allow` are allowed, all others are
+// denied with status 403. The server also implements the gRPC health
+// service, so that it can back a Service with appProtocol
+// kubernetes.io/h2c and a GRPC HealthCheckPolicy.
+//
+// It exits with status 2 on usage errors or if the server fails.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz/extauthz"
+)
+
+func main() {
+	var (
+		addr   = flag.String("addr", ":9000", "address to listen on")
+		header = flag.String("header", extauthz.DefaultDecisionHeader, "request header that carries the decision")
+		quiet  = flag.Bool("quiet", false, "do not log checks")
+	)
+	flag.Parse()
+	if flag.NArg() > 0 {
+		usage("unexpected arguments")
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fail(err)
+	}
+	srv := grpc.NewServer()
+	authz := &extauthz.Server{DecisionHeader: *header}
+	if !*quiet {
+		authz.Logf = log.Printf
+	}
+	authz.Register(srv)
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		hs.Shutdown()
+		srv.GracefulStop()
+	}()
+	log.Printf("ext_authz server listening on %s", lis.Addr())
+	if err := srv.Serve(lis); err != nil {
+		fail(err)
+	}
+}
+
+func usage(msg string) {
+	fmt.Fprintf(os.Stderr, "ext-authz-server: %s\n", msg)
+	flag.Usage()
+	os.Exit(2)
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "ext-authz-server: %v\n", err)
+	os.Exit(2)
+}
@@ -0,0 +1,153 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Command healthcheck-probe runs the health check described by a
+// HealthCheckPolicy against a local or port-forwarded endpoint, and reports
+// the state transitions the load balancer would observe.
+//
+//	healthcheck-probe -f policy.yaml -port 8080 -count 5
+//
+// It exits with status 0 if the endpoint is healthy after the last probe, 1
+// if it is not, and 2 on usage errors or if the policy cannot be read or is
+// invalid.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/healthcheck"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/healthcheck/probe"
+)
+
+func main() {
+	var (
+		file     = flag.String("f", "", "path of the HealthCheckPolicy manifest (required)")
+		host     = flag.String("host", "127.0.0.1", "host or IP address of the endpoint")
+		port     = flag.Int("port", 0, "port of the endpoint; defaults to the fixed port of the policy")
+		count    = flag.Int("count", 0, "number of probes to run; 0 runs until interrupted or -until is reached")
+		until    = flag.String("until", "", "stop once the endpoint reaches this state, HEALTHY or UNHEALTHY")
+		interval = flag.Duration("interval", 0, "override checkIntervalSec of the policy")
+		timeout  = flag.Duration("timeout", 0, "override timeoutSec of the policy")
+	)
+	flag.Parse()
+	if *file == "" {
+		usage("-f is required")
+	}
+	if *until != "" && *until != string(probe.Healthy) && *until != string(probe.Unhealthy) {
+		usage("-until must be HEALTHY or UNHEALTHY")
+	}
+
+	policy, err := readPolicy(*file)
+	if err != nil {
+		fail(err)
+	}
+	cfg := policy.Spec.Default
+	if errs := healthcheck.Validate(cfg, field.NewPath("spec", "default")); len(errs) > 0 {
+		fail(errs.ToAggregate())
+	}
+	if *port == 0 {
+		fixed, ok := fixedPort(cfg)
+		if !ok {
+			usage("-port is required unless all health checks of the policy use USE_FIXED_PORT")
+		}
+		*port = fixed
+	}
+	r, err := probe.NewRunner(cfg, probe.Address(*host, *port))
+	if err != nil {
+		fail(err)
+	}
+	if *interval > 0 {
+		r.Interval = *interval
+	}
+	if *timeout > 0 {
+		r.Timeout = *timeout
+	}
+
+	fmt.Printf("probing %s every %s, timeout %s, healthy threshold %d, unhealthy threshold %d\n",
+		r.Address, r.Interval, r.Timeout, r.Tracker.HealthyThreshold, r.Tracker.UnhealthyThreshold)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	state := r.Run(ctx, *count, func(ev probe.Event) bool {
+		result := "ok"
+		if ev.Err != nil {
+			result = "failed: " + ev.Err.Error()
+		}
+		fmt.Printf("%s probe %s (%s) state %s\n", ev.Time.Format(time.RFC3339Nano), result, ev.Duration.Round(time.Millisecond), ev.State)
+		if ev.Transition {
+			fmt.Printf("%s transition %s -> %s\n", ev.Time.Format(time.RFC3339Nano), ev.Previous, ev.State)
+		}
+		return *until == "" || string(ev.State) != *until
+	})
+	fmt.Printf("final state %s\n", state)
+	if state != probe.Healthy {
+		os.Exit(1)
+	}
+}
+
+// fixedPort returns the port of the first health check of the given
+// configuration if all of its health checks use USE_FIXED_PORT.
+func fixedPort(cfg *networkingv1.HealthCheckPolicyConfig) (int, bool) {
+	eff, err := healthcheck.Resolve(cfg, nil)
+	if err != nil {
+		return 0, false
+	}
+	first := 0
+	for _, c := range eff.Checks {
+		port, ok := probe.FixedPort(&c.Config)
+		if !ok {
+			return 0, false
+		}
+		if first == 0 {
+			first = port
+		}
+	}
+	return first, true
+}
+
+func readPolicy(path string) (*networkingv1.HealthCheckPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	policy := &networkingv1.HealthCheckPolicy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if policy.Kind != "" && policy.Kind != "HealthCheckPolicy" {
+		return nil, fmt.Errorf("%s contains a %s, want a HealthCheckPolicy", path, policy.Kind)
+	}
+	return policy, nil
+}
+
+func usage(msg string) {
+	fmt.Fprintf(os.Stderr, "healthcheck-probe: %s\n", msg)
+	flag.Usage()
+	os.Exit(2)
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "healthcheck-probe: %v\n", err)
+	os.Exit(2)
+}
@@ -0,0 +1,151 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Command mtls-posture reports the Service ports whose clients and servers
+// have incompatible mTLS settings, or whose servers accept plaintext.
+//
+//	mtls-posture -f snapshot.yaml
+//	mtls-posture -kubeconfig ~/.kube/config -n shop -o json
+//
+// The client settings come from GCPClientTLSPolicies and the server
+// settings from GCPServerTLSPolicies. They are joined through the Services,
+// EndpointSlices and Pods of the snapshot, which is read from YAML or JSON
+// files, such as the output of kubectl get -o yaml, or from the cluster of a
+// kubeconfig.
+//
+// It exits with status 0 if there are no findings, 1 if there are findings
+// of the -fail-on severity or higher, and 2 on usage errors or if the
+// snapshot cannot be read.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/client/clientset/versioned"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/mtls"
+)
+
+type files []string
+
+func (f *files) String() string     { return strings.Join(*f, ",") }
+func (f *files) Set(v string) error { *f = append(*f, v); return nil }
+
+func main() {
+	var paths files
+	flag.Var(&paths, "f", "path of a YAML or JSON file of TLS policies, Services, Pods and EndpointSlices (can be repeated)")
+	var (
+		kubeconfig = flag.String("kubeconfig", "", "path of a kubeconfig to read the snapshot from a cluster")
+		namespace  = flag.String("n", "", "namespace to read from the cluster, all namespaces if empty")
+		output     = flag.String("o", "text", "output format, text or json")
+		failOn     = flag.String("fail-on", "warning", "minimum severity of the findings that make the command fail, warning or error")
+	)
+	flag.Parse()
+	if (len(paths) == 0) == (*kubeconfig == "") {
+		usage("exactly one of -f and -kubeconfig is required")
+	}
+	if *output != "text" && *output != "json" {
+		usage("-o must be text or json")
+	}
+	if *failOn != "warning" && *failOn != "error" {
+		usage("-fail-on must be warning or error")
+	}
+
+	var (
+		s   *mtls.Snapshot
+		err error
+	)
+	if *kubeconfig != "" {
+		s, err = fromCluster(*kubeconfig, *namespace)
+	} else {
+		s, err = readSnapshot(paths)
+	}
+	if err != nil {
+		fail(err)
+	}
+	findings := mtls.Analyze(s)
+	if findings == nil {
+		findings = []mtls.Finding{}
+	}
+
+	if *output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(findings)
+	} else if len(findings) == 0 {
+		fmt.Println("no findings")
+	} else {
+		err = mtls.Format(os.Stdout, findings)
+	}
+	if err != nil {
+		fail(err)
+	}
+	for _, f := range findings {
+		if *failOn == "warning" || f.Severity == mtls.SeverityError {
+			os.Exit(1)
+		}
+	}
+}
+
+func readSnapshot(paths []string) (*mtls.Snapshot, error) {
+	s := &mtls.Snapshot{}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		err = s.Decode(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	return s, nil
+}
+
+func fromCluster(kubeconfig, namespace string) (*mtls.Snapshot, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	kube, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	networking, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return mtls.FromCluster(context.Background(), kube, networking, namespace)
+}
+
+func usage(msg string) {
+	fmt.Fprintf(os.Stderr, "mtls-posture: %s\n", msg)
+	flag.Usage()
+	os.Exit(2)
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "mtls-posture: %v\n", err)
+	os.Exit(2)
+}
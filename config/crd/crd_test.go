@@ -0,0 +1,129 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package crd holds the generated CustomResourceDefinitions. Its tests run
+// the OpenAPI schema and CEL validation of the API server against them.
+//
+// The tests are a module of their own, so that the module of the API types
+// does not require the API server and its dependencies.
+package crd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	crdvalidation "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/validation"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	apiservervalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"sigs.k8s.io/yaml"
+)
+
+// loadCRD reads the CustomResourceDefinition of the given resource of the
+// networking.gke.io group.
+func loadCRD(t *testing.T, resource string) *apiextensions.CustomResourceDefinition {
+	t.Helper()
+	b, err := os.ReadFile("networking.gke.io_" + resource + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v1crd apiextensionsv1.CustomResourceDefinition
+	if err := yaml.UnmarshalStrict(b, &v1crd); err != nil {
+		t.Fatalf("%s: %v", resource, err)
+	}
+	apiextensionsv1.SetObjectDefaults_CustomResourceDefinition(&v1crd)
+	crd := &apiextensions.CustomResourceDefinition{}
+	if err := apiextensionsv1.Convert_v1_CustomResourceDefinition_To_apiextensions_CustomResourceDefinition(&v1crd, crd, nil); err != nil {
+		t.Fatalf("%s: %v", resource, err)
+	}
+	return crd
+}
+
+// validate defaults and validates the given YAML object against the schema
+// of the given resource, as the API server does on create, and returns the
+// errors.
+func validate(t *testing.T, resource, object string) field.ErrorList {
+	t.Helper()
+	crd := loadCRD(t, resource)
+	schema := crd.Spec.Validation.OpenAPIV3Schema
+	if schema == nil {
+		schema = crd.Spec.Versions[0].Schema.OpenAPIV3Schema
+	}
+	ss, err := structuralschema.NewStructural(schema)
+	if err != nil {
+		t.Fatalf("%s: %v", resource, err)
+	}
+	var obj map[string]any
+	if err := yaml.Unmarshal([]byte(object), &obj); err != nil {
+		t.Fatalf("invalid object: %v", err)
+	}
+	structuraldefaulting.Default(obj, ss)
+
+	validator, _, err := apiservervalidation.NewSchemaValidator(schema)
+	if err != nil {
+		t.Fatalf("%s: %v", resource, err)
+	}
+	errs := apiservervalidation.ValidateCustomResource(nil, obj, validator)
+	celErrs, _ := cel.NewValidator(ss, true, celconfig.PerCallLimit).Validate(context.Background(), nil, ss, obj, nil, celconfig.RuntimeCELCostBudget)
+	return append(errs, celErrs...)
+}
+
+// testCase is an object to validate against a CustomResourceDefinition.
+type testCase struct {
+	desc   string
+	object string
+	// wantErr is a substring of the expected error, or empty if the object
+	// is valid.
+	wantErr string
+}
+
+func runTests(t *testing.T, resource, header string, tests []testCase) {
+	t.Helper()
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			errs := validate(t, resource, header+tc.object)
+			switch {
+			case tc.wantErr == "" && len(errs) > 0:
+				t.Errorf("validation failed: %v", errs.ToAggregate())
+			case tc.wantErr != "" && !strings.Contains(errs.ToAggregate().Error(), tc.wantErr):
+				t.Errorf("validation errors = %v, want an error containing %q", errs.ToAggregate(), tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCRDs(t *testing.T) {
+	files, err := filepath.Glob("networking.gke.io_*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		resource := strings.TrimSuffix(strings.TrimPrefix(f, "networking.gke.io_"), ".yaml")
+		t.Run(resource, func(t *testing.T) {
+			for _, err := range crdvalidation.ValidateCustomResourceDefinition(context.Background(), loadCRD(t, resource)) {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package crd
+
+import "testing"
+
+const authzPolicyHeader = `
+apiVersion: networking.gke.io/v1
+kind: GCPAuthzPolicy
+metadata:
+  name: policy
+  namespace: default
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: gateway
+  enforcementLevel: L7
+  action: ALLOW
+`
+
+func TestGCPAuthzPolicyIgnoreCase(t *testing.T) {
+	runTests(t, "gcpauthzpolicies", authzPolicyHeader, []testCase{
+		{
+			desc: "path template without ignoreCase",
+			object: `
+  rules:
+  - to:
+      operations:
+      - paths:
+        - type: PathTemplate
+          value: /v1/{name}
+`,
+		},
+		{
+			desc: "regex header without ignoreCase",
+			object: `
+  rules:
+  - to:
+      operations:
+      - headers:
+        - type: SafeRegex
+          name: X-Tenant
+          value: ^acme-.*$
+`,
+		},
+		{
+			desc: "regex path without ignoreCase",
+			object: `
+  rules:
+  - to:
+      operations:
+      - paths:
+        - type: SafeRegex
+          value: ^/v[0-9]+/.*$
+`,
+		},
+		{
+			desc: "exact header with ignoreCase",
+			object: `
+  rules:
+  - to:
+      operations:
+      - headers:
+        - type: Exact
+          name: X-Tenant
+          value: acme
+          ignoreCase: true
+`,
+		},
+		{
+			desc: "regex header with ignoreCase",
+			object: `
+  rules:
+  - to:
+      operations:
+      - headers:
+        - type: SafeRegex
+          name: X-Tenant
+          value: ^acme-.*$
+          ignoreCase: true
+`,
+			wantErr: "IgnoreCase cannot be set for SafeRegex, use the (?i) flag instead",
+		},
+		{
+			desc: "path template with ignoreCase",
+			object: `
+  rules:
+  - to:
+      operations:
+      - paths:
+        - type: PathTemplate
+          value: /v1/{name}
+          ignoreCase: true
+`,
+			wantErr: "IgnoreCase cannot be set for SafeRegex or PathTemplate",
+		},
+	})
+}
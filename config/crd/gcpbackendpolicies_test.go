@@ -0,0 +1,299 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package crd
+
+import "testing"
+
+const backendPolicyHeader = `
+apiVersion: networking.gke.io/v1
+kind: GCPBackendPolicy
+metadata:
+  name: policy
+  namespace: default
+spec:
+  targetRef:
+    group: ""
+    kind: Service
+    name: store
+`
+
+func TestGCPBackendPolicyCircuitBreaking(t *testing.T) {
+	runTests(t, "gcpbackendpolicies", backendPolicyHeader, []testCase{
+		{
+			desc: "valid",
+			object: `
+  default:
+    circuitBreakers:
+      maxConnections: 1
+      maxPendingRequests: 1000
+      maxRequests: 2000
+      maxRetries: 3
+    outlierDetection:
+      consecutiveErrors: 5
+      enforcingConsecutiveErrors: 100
+      interval: 500ms
+      baseEjectionTime: 30s
+      maxEjectionPercent: 0
+`,
+		},
+		{
+			desc: "maxConnections below 1",
+			object: `
+  default:
+    circuitBreakers:
+      maxConnections: 0
+`,
+			wantErr: "maxConnections",
+		},
+		{
+			desc: "maxRetries below 1",
+			object: `
+  default:
+    circuitBreakers:
+      maxRetries: 0
+`,
+			wantErr: "maxRetries",
+		},
+		{
+			desc: "consecutiveErrors below 1",
+			object: `
+  default:
+    outlierDetection:
+      consecutiveErrors: 0
+`,
+			wantErr: "consecutiveErrors",
+		},
+		{
+			desc: "enforcingConsecutiveErrors above 100",
+			object: `
+  default:
+    outlierDetection:
+      enforcingConsecutiveErrors: 101
+`,
+			wantErr: "enforcingConsecutiveErrors",
+		},
+		{
+			desc: "maxEjectionPercent below 0",
+			object: `
+  default:
+    outlierDetection:
+      maxEjectionPercent: -1
+`,
+			wantErr: "maxEjectionPercent",
+		},
+		{
+			desc: "maxEjectionPercent above 100",
+			object: `
+  default:
+    outlierDetection:
+      maxEjectionPercent: 101
+`,
+			wantErr: "maxEjectionPercent",
+		},
+		{
+			desc: "zero interval",
+			object: `
+  default:
+    outlierDetection:
+      interval: 0s
+`,
+			wantErr: "interval must be greater than 0s",
+		},
+		{
+			desc: "zero baseEjectionTime",
+			object: `
+  default:
+    outlierDetection:
+      baseEjectionTime: 0s
+`,
+			wantErr: "baseEjectionTime must be greater than 0s",
+		},
+	})
+}
+
+func TestGCPBackendPolicyCDN(t *testing.T) {
+	runTests(t, "gcpbackendpolicies", backendPolicyHeader, []testCase{
+		{
+			desc: "valid",
+			object: `
+  default:
+    cdn:
+      enabled: true
+      cacheMode: CACHE_ALL_STATIC
+      defaultTTL: 1h
+      maxTTL: 24h
+      clientTTL: 1h
+      negativeCaching: true
+      negativeCachingPolicy:
+      - code: 404
+        ttl: 2m
+      cacheKeyPolicy:
+        includeQueryString: true
+        queryStringIncludeList:
+        - page
+      signedURLKeys:
+      - keyName: key
+        secretRef:
+          name: signed-url-key
+      serveWhileStale: 1h
+`,
+		},
+		{
+			desc: "disabled",
+			object: `
+  default:
+    cdn:
+      enabled: false
+`,
+		},
+		{
+			desc: "settings without enabled",
+			object: `
+  default:
+    cdn:
+      cacheMode: FORCE_CACHE_ALL
+`,
+			wantErr: "CDN settings can only be specified if enabled is true",
+		},
+		{
+			desc: "settings when disabled",
+			object: `
+  default:
+    cdn:
+      enabled: false
+      requestCoalescing: true
+`,
+			wantErr: "CDN settings can only be specified if enabled is true",
+		},
+		{
+			desc: "TTL with USE_ORIGIN_HEADERS",
+			object: `
+  default:
+    cdn:
+      enabled: true
+      cacheMode: USE_ORIGIN_HEADERS
+      clientTTL: 1h
+`,
+			wantErr: "defaultTTL, maxTTL and clientTTL cannot be specified if cacheMode is USE_ORIGIN_HEADERS",
+		},
+		{
+			desc: "maxTTL with FORCE_CACHE_ALL",
+			object: `
+  default:
+    cdn:
+      enabled: true
+      cacheMode: FORCE_CACHE_ALL
+      maxTTL: 1h
+`,
+			wantErr: "maxTTL can only be specified if cacheMode is CACHE_ALL_STATIC",
+		},
+		{
+			desc: "defaultTTL greater than maxTTL",
+			object: `
+  default:
+    cdn:
+      enabled: true
+      defaultTTL: 2h
+      maxTTL: 1h
+`,
+			wantErr: "defaultTTL must not be greater than maxTTL",
+		},
+		{
+			desc: "clientTTL greater than maxTTL",
+			object: `
+  default:
+    cdn:
+      enabled: true
+      clientTTL: 2h
+      maxTTL: 1h
+`,
+			wantErr: "clientTTL must not be greater than maxTTL",
+		},
+		{
+			desc: "defaultTTL greater than 1 year",
+			object: `
+  default:
+    cdn:
+      enabled: true
+      defaultTTL: 8761h
+`,
+			wantErr: "defaultTTL must not be greater than 1 year",
+		},
+		{
+			desc: "negativeCachingPolicy without negativeCaching",
+			object: `
+  default:
+    cdn:
+      enabled: true
+      negativeCachingPolicy:
+      - code: 404
+        ttl: 2m
+`,
+			wantErr: "negativeCachingPolicy can only be specified if negativeCaching is true",
+		},
+		{
+			desc: "negative caching ttl greater than 30m",
+			object: `
+  default:
+    cdn:
+      enabled: true
+      negativeCaching: true
+      negativeCachingPolicy:
+      - code: 404
+        ttl: 31m
+`,
+			wantErr: "ttl must not be greater than 30m",
+		},
+		{
+			desc: "serveWhileStale greater than 1 day",
+			object: `
+  default:
+    cdn:
+      enabled: true
+      serveWhileStale: 25h
+`,
+			wantErr: "serveWhileStale must not be greater than 1 day",
+		},
+		{
+			desc: "query string include and exclude lists",
+			object: `
+  default:
+    cdn:
+      enabled: true
+      cacheKeyPolicy:
+        queryStringIncludeList:
+        - page
+        queryStringExcludeList:
+        - session
+`,
+			wantErr: "only one of queryStringIncludeList and queryStringExcludeList can be specified",
+		},
+		{
+			desc: "query string list without query string",
+			object: `
+  default:
+    cdn:
+      enabled: true
+      cacheKeyPolicy:
+        includeQueryString: false
+        queryStringExcludeList:
+        - session
+`,
+			wantErr: "query string lists can only be specified if includeQueryString is true",
+		},
+	})
+}
@@ -0,0 +1,239 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package crd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const serverTLSPolicyHeader = `
+apiVersion: networking.gke.io/v1
+kind: GCPServerTLSPolicy
+metadata:
+  name: policy
+  namespace: default
+spec:
+`
+
+// expressions returns n matchExpressions of a selector in a targetRef.
+func expressions(n int) string {
+	var b strings.Builder
+	for i := range n {
+		fmt.Fprintf(&b, "\n      - key: label-%d\n        operator: Exists", i)
+	}
+	return b.String()
+}
+
+// values returns the given values of a matchExpression in a targetRef.
+func values(vs ...string) string {
+	return "\n        - " + strings.Join(vs, "\n        - ")
+}
+
+func TestGCPServerTLSPolicySelector(t *testing.T) {
+	manyValues := make([]string, 65)
+	for i := range manyValues {
+		manyValues[i] = fmt.Sprintf("v%d", i)
+	}
+	var manyLabels strings.Builder
+	for i := range 33 {
+		fmt.Fprintf(&manyLabels, "\n        label-%d: v", i)
+	}
+
+	runTests(t, "gcpservertlspolicies", serverTLSPolicyHeader, []testCase{
+		{
+			desc: "labels and expressions",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchLabels:
+        app: store
+      matchExpressions:
+      - key: track
+        operator: In
+        values: [stable, canary]
+      - key: legacy
+        operator: DoesNotExist
+  portOverrides:
+  - port: 8080
+    mtlsMode: Permissive
+`,
+		},
+		{
+			desc: "workload kinds",
+			object: `
+  targetRefs:
+  - group: apps
+    kind: Deployment
+    selector:
+      matchLabels:
+        app: store
+  - group: apps
+    kind: StatefulSet
+    selector:
+      matchExpressions:
+      - key: app
+        operator: NotIn
+        values: [db]
+`,
+		},
+		{
+			desc: "whole namespace",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector: {}
+`,
+		},
+		{
+			desc: "selector for a Service",
+			object: `
+  targetRefs:
+  - kind: Service
+    selector:
+      matchLabels:
+        app: store
+`,
+			wantErr: "selector can only be used when targeting Pods, or apps Deployments, StatefulSets and DaemonSets.",
+		},
+		{
+			desc: "port overrides for the whole namespace",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector: {}
+  portOverrides:
+  - port: 8080
+    mtlsMode: Permissive
+`,
+			wantErr: "portOverrides cannot be set when targeting a whole namespace",
+		},
+		{
+			desc: "unknown operator",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchExpressions:
+      - key: version
+        operator: Gt
+        values: ["1"]
+`,
+			wantErr: "selector.matchExpressions operator must be In, NotIn, Exists or DoesNotExist.",
+		},
+		{
+			desc: "In without values",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchExpressions:
+      - key: track
+        operator: In
+`,
+			wantErr: "selector.matchExpressions values must be set for the In and NotIn operators, and only for them.",
+		},
+		{
+			desc: "Exists with values",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchExpressions:
+      - key: track
+        operator: Exists
+        values: [stable]
+`,
+			wantErr: "selector.matchExpressions values must be set for the In and NotIn operators, and only for them.",
+		},
+		{
+			desc: "16 expressions",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchExpressions:` + expressions(16) + "\n",
+		},
+		{
+			desc: "too many expressions",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchExpressions:` + expressions(17) + "\n",
+			wantErr: "selector can have at most 32 matchLabels and 16 matchExpressions.",
+		},
+		{
+			desc: "too many labels",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchLabels:` + manyLabels.String() + "\n",
+			wantErr: "selector can have at most 32 matchLabels and 16 matchExpressions.",
+		},
+		{
+			desc: "64 values",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchExpressions:
+      - key: track
+        operator: In
+        values:` + values(manyValues[:64]...) + "\n",
+		},
+		{
+			desc: "too many values",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchExpressions:
+      - key: track
+        operator: In
+        values:` + values(manyValues...) + "\n",
+			wantErr: "selector.matchExpressions keys can have at most 317 characters, and values at most 64 items of at most 63 characters.",
+		},
+		{
+			desc: "long value",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchExpressions:
+      - key: track
+        operator: NotIn
+        values:` + values("stable", strings.Repeat("v", 64)) + "\n",
+			wantErr: "selector.matchExpressions keys can have at most 317 characters, and values at most 64 items of at most 63 characters.",
+		},
+		{
+			desc: "long key",
+			object: `
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchExpressions:
+      - key: ` + strings.Repeat("k", 318) + `
+        operator: Exists
+`,
+			wantErr: "selector.matchExpressions keys can have at most 317 characters",
+		},
+	})
+}
@@ -0,0 +1,98 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package crd
+
+import "testing"
+
+const trafficDistributionPolicyHeader = `
+apiVersion: networking.gke.io/v1
+kind: GCPTrafficDistributionPolicy
+metadata:
+  name: policy
+  namespace: default
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: store
+`
+
+func TestGCPTrafficDistributionPolicyConsistentHash(t *testing.T) {
+	runTests(t, "gcptrafficdistributionpolicies", trafficDistributionPolicyHeader, []testCase{
+		{
+			desc: "ring hash with minimum ring size",
+			object: `
+  default:
+    localityLbAlgorithm: RING_HASH
+    consistentHash:
+      httpHeaderName: X-User
+      minimumRingSize: 2048
+`,
+		},
+		{
+			desc: "maglev",
+			object: `
+  default:
+    localityLbAlgorithm: MAGLEV
+    consistentHash:
+      httpCookie:
+        name: session
+        ttl: 1h
+`,
+		},
+		{
+			desc: "minimum ring size with maglev",
+			object: `
+  default:
+    localityLbAlgorithm: MAGLEV
+    consistentHash:
+      minimumRingSize: 2048
+`,
+			wantErr: "consistentHash.minimumRingSize can only be specified if localityLbAlgorithm is RING_HASH",
+		},
+		{
+			desc: "minimum ring size without algorithm",
+			object: `
+  default:
+    consistentHash:
+      minimumRingSize: 2048
+`,
+			wantErr: "consistentHash.minimumRingSize can only be specified if localityLbAlgorithm is RING_HASH",
+		},
+		{
+			desc: "consistent hash without algorithm",
+			object: `
+  default:
+    consistentHash:
+      httpHeaderName: X-User
+`,
+			wantErr: "consistentHash can only be specified if localityLbAlgorithm is RING_HASH or MAGLEV",
+		},
+		{
+			desc: "header and cookie",
+			object: `
+  default:
+    localityLbAlgorithm: RING_HASH
+    consistentHash:
+      httpHeaderName: X-User
+      httpCookie:
+        name: session
+`,
+			wantErr: "only one of httpHeaderName and httpCookie can be specified",
+		},
+	})
+}
@@ -0,0 +1,658 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package authz
+
+import (
+	"errors"
+	"net/http"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	networkingv1listers "github.com/GoogleCloudPlatform/gke-gateway-api/pkg/client/listers/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/policyref"
+)
+
+func TestPathTemplate(t *testing.T) {
+	for _, tc := range []struct {
+		template string
+		wantErr  bool
+		match    []string
+		noMatch  []string
+		bind     string
+		wantVars map[string]string
+	}{
+		{
+			template: "/v1/users/*/orders",
+			match:    []string{"/v1/users/42/orders"},
+			noMatch:  []string{"/v1/users/orders", "/v1/users/4/2/orders", "/v1/users/42/orders/1"},
+		},
+		{
+			template: "/static/**",
+			match:    []string{"/static/", "/static/css/site.css"},
+			noMatch:  []string{"/static", "/public/site.css"},
+		},
+		{
+			template: "/v1/users/{id}/orders/{rest=**}",
+			match:    []string{"/v1/users/42/orders/1/items"},
+			bind:     "/v1/users/42/orders/1/items",
+			wantVars: map[string]string{"id": "42", "rest": "1/items"},
+		},
+		{template: "/v1/a.b", match: []string{"/v1/a.b"}, noMatch: []string{"/v1/axb"}},
+		{template: "/", match: []string{"/"}, noMatch: []string{"/a"}},
+		{template: "v1/users", wantErr: true},
+		{template: "/**/users", wantErr: true},
+		{template: "/v1//users", wantErr: true},
+		{template: "/{id}/{id}", wantErr: true},
+		{template: "/{1id}", wantErr: true},
+		{template: "/v1/user?", wantErr: true},
+		{template: "/*/*/*/*/*/*", wantErr: true},
+		{template: "/" + strings.Repeat("a", MaxPathTemplateLength), wantErr: true},
+	} {
+		t.Run(tc.template, func(t *testing.T) {
+			pt, err := CompilePathTemplate(tc.template)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("CompilePathTemplate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for _, p := range tc.match {
+				if !pt.Match(p) {
+					t.Errorf("Match(%q) = false, want true", p)
+				}
+			}
+			for _, p := range tc.noMatch {
+				if pt.Match(p) {
+					t.Errorf("Match(%q) = true, want false", p)
+				}
+			}
+			if tc.bind != "" {
+				if got := pt.Bind(tc.bind); !reflect.DeepEqual(got, tc.wantVars) {
+					t.Errorf("Bind(%q) = %v, want %v", tc.bind, got, tc.wantVars)
+				}
+			}
+		})
+	}
+}
+
+func TestStringMatch(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		m       networkingv1.StringMatchCriteria
+		wantErr bool
+		match   []string
+		noMatch []string
+	}{
+		{
+			desc:    "exact ignore case",
+			m:       networkingv1.StringMatchCriteria{Type: networkingv1.StringExact, Value: "API.example.com", IgnoreCase: true},
+			match:   []string{"api.example.com"},
+			noMatch: []string{"www.example.com"},
+		},
+		{
+			desc:    "suffix ignore case",
+			m:       networkingv1.StringMatchCriteria{Type: networkingv1.StringSuffix, Value: ".Example.com", IgnoreCase: true},
+			match:   []string{"api.example.COM"},
+			noMatch: []string{"example.com"},
+		},
+		{
+			desc:    "regex matches the whole string",
+			m:       networkingv1.StringMatchCriteria{Type: networkingv1.StringSafeRegex, Value: `[a-z]+\.example\.com`},
+			match:   []string{"api.example.com"},
+			noMatch: []string{"api.example.com.evil.net", "API.example.com"},
+		},
+		{
+			desc:  "regex case insensitive flag",
+			m:     networkingv1.StringMatchCriteria{Type: networkingv1.StringSafeRegex, Value: `(?i)[a-z]+\.example\.com`},
+			match: []string{"API.example.com"},
+		},
+		{
+			desc:    "invalid regex",
+			m:       networkingv1.StringMatchCriteria{Type: networkingv1.StringSafeRegex, Value: `(a`},
+			wantErr: true,
+		},
+		{
+			desc:    "regex does not support backreferences",
+			m:       networkingv1.StringMatchCriteria{Type: networkingv1.StringSafeRegex, Value: `(a)\1`},
+			wantErr: true,
+		},
+		{
+			desc:    "regex too long",
+			m:       networkingv1.StringMatchCriteria{Type: networkingv1.StringSafeRegex, Value: strings.Repeat("a", MaxRegexLength+1)},
+			wantErr: true,
+		},
+		{
+			desc:    "regex ignore case",
+			m:       networkingv1.StringMatchCriteria{Type: networkingv1.StringSafeRegex, Value: "a", IgnoreCase: true},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			m, err := CompileStringMatch(tc.m)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("CompileStringMatch() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for _, s := range tc.match {
+				if !m.Match(s) {
+					t.Errorf("Match(%q) = false, want true", s)
+				}
+			}
+			for _, s := range tc.noMatch {
+				if m.Match(s) {
+					t.Errorf("Match(%q) = true, want false", s)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSpec(t *testing.T) {
+	spec := &networkingv1.GCPAuthzPolicySpec{
+		EnforcementLevel: networkingv1.L7,
+		Rules: []networkingv1.GCPAuthPolicyRule{{
+			From: &networkingv1.GCPAuthzPolicyFrom{Sources: []networkingv1.GCPAuthzPolicySource{{
+				Principals: []networkingv1.Principal{{Principal: networkingv1.StringMatchCriteria{Type: networkingv1.StringSafeRegex, Value: ".*"}}},
+			}}},
+			To: &networkingv1.GCPAuthzPolicyTo{Operations: []networkingv1.GCPAuthzPolicyOperation{{
+				Headers: []networkingv1.HTTPHeaderMatch{{Type: networkingv1.StringSafeRegex, Name: "x-id", Value: "[0-9"}},
+				Hosts:   []networkingv1.StringMatchCriteria{{Type: networkingv1.StringPathTemplate, Value: "/a"}},
+				Paths: []networkingv1.StringMatchCriteria{
+					{Type: networkingv1.StringPathTemplate, Value: "/v1/**/orders"},
+					{Type: networkingv1.StringPathTemplate, Value: "/v1/users/*/orders"},
+				},
+			}}},
+		}},
+	}
+	want := []string{
+		"spec.rules[0].from.sources[0].principals[0].principal.type",
+		"spec.rules[0].to.operations[0].headers[0].value",
+		"spec.rules[0].to.operations[0].hosts[0].type",
+		"spec.rules[0].to.operations[0].paths[0].value",
+	}
+	var got []string
+	for _, err := range ValidateSpec(spec, field.NewPath("spec")) {
+		got = append(got, err.Field)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateSpec() fields = %v, want %v", got, want)
+	}
+}
+
+func TestValidateCustomProviders(t *testing.T) {
+	spec := &networkingv1.GCPAuthzPolicySpec{
+		EnforcementLevel: networkingv1.L4,
+		Action:           ptr.To(networkingv1.Custom),
+		CustomProviders: &networkingv1.GCPAuthzPolicyCustomProviders{ExtensionRefs: []gatewayv1.LocalObjectReference{
+			{Group: networkingv1.GroupName, Kind: TrafficExtensionKind, Name: "authz"},
+			{Group: networkingv1.GroupName, Kind: "GCPRoutingExtension", Name: "routing"},
+		}},
+	}
+	want := []string{
+		"spec.enforcementLevel",
+		"spec.customProviders.extensionRefs[1].kind",
+	}
+	var got []string
+	for _, err := range ValidateSpec(spec, field.NewPath("spec")) {
+		got = append(got, err.Field)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateSpec() fields = %v, want %v", got, want)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	extensions := []gatewayv1.LocalObjectReference{{Group: networkingv1.GroupName, Kind: "GCPAuthzExtension", Name: "ext-authz"}}
+	policy := func(name string, action networkingv1.GCPAuthzPolicyAction, rules ...networkingv1.GCPAuthPolicyRule) *Policy {
+		spec := networkingv1.GCPAuthzPolicySpec{EnforcementLevel: networkingv1.L7, Action: &action, Rules: rules}
+		if action == networkingv1.Custom {
+			spec.CustomProviders = &networkingv1.GCPAuthzPolicyCustomProviders{ExtensionRefs: extensions}
+		}
+		p, err := Compile(&networkingv1.GCPAuthzPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: name}, Spec: spec})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	paths := func(m ...networkingv1.StringMatchCriteria) networkingv1.GCPAuthPolicyRule {
+		return networkingv1.GCPAuthPolicyRule{To: &networkingv1.GCPAuthzPolicyTo{Operations: []networkingv1.GCPAuthzPolicyOperation{{Paths: m}}}}
+	}
+	frontend := networkingv1.GCPAuthPolicyRule{
+		From: &networkingv1.GCPAuthzPolicyFrom{Sources: []networkingv1.GCPAuthzPolicySource{{
+			Principals: []networkingv1.Principal{{Principal: networkingv1.StringMatchCriteria{Type: networkingv1.StringExact, Value: "spiffe://example/ns/app/sa/frontend"}}},
+		}}},
+		To: &networkingv1.GCPAuthzPolicyTo{Operations: []networkingv1.GCPAuthzPolicyOperation{{
+			Methods: []networkingv1.HTTPMethod{networkingv1.HTTPMethodGet},
+			Paths:   []networkingv1.StringMatchCriteria{{Type: networkingv1.StringPathTemplate, Value: "/v1/users/*/orders"}},
+			Headers: []networkingv1.HTTPHeaderMatch{{Type: networkingv1.StringSafeRegex, Name: "x-tenant", Value: "t-[0-9]+"}},
+		}}},
+	}
+	e := &Evaluator{Policies: []*Policy{
+		policy("allow-frontend", networkingv1.Allow, frontend),
+		policy("deny-admin", networkingv1.Deny, paths(networkingv1.StringMatchCriteria{Type: networkingv1.StringSafeRegex, Value: "/admin(/.*)?"})),
+		policy("ext-authz", networkingv1.Custom, paths(networkingv1.StringMatchCriteria{Type: networkingv1.StringPrefix, Value: "/admin/ext/"})),
+	}}
+
+	for _, tc := range []struct {
+		desc       string
+		req        Request
+		wantResult Result
+		wantPolicy string
+	}{
+		{
+			desc:       "delegated",
+			req:        Request{Method: "GET", Path: "/admin/ext/users"},
+			wantResult: Delegated,
+			wantPolicy: "ext-authz",
+		},
+		{
+			desc: "allowed",
+			req: Request{Method: "GET", Path: "/v1/users/42/orders?page=2", Headers: http.Header{"X-Tenant": {"t-1"}},
+				Peer: Peer{URISANs: []string{"spiffe://example/ns/app/sa/frontend"}}},
+			wantResult: Allowed,
+			wantPolicy: "allow-frontend",
+		},
+		{
+			desc: "header mismatch",
+			req: Request{Method: "GET", Path: "/v1/users/42/orders", Headers: http.Header{"X-Tenant": {"acme"}},
+				Peer: Peer{URISANs: []string{"spiffe://example/ns/app/sa/frontend"}}},
+			wantResult: Denied,
+			wantPolicy: "allow-frontend",
+		},
+		{
+			desc:       "denied",
+			req:        Request{Method: "GET", Path: "/admin/users"},
+			wantResult: Denied,
+			wantPolicy: "deny-admin",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			d, err := e.Evaluate(&tc.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Result != tc.wantResult || d.Policy.Name != tc.wantPolicy {
+				t.Errorf("Evaluate() = %+v, want %s by %s", d, tc.wantResult, tc.wantPolicy)
+			}
+			if tc.wantResult == Delegated && !reflect.DeepEqual(d.Extensions, extensions) {
+				t.Errorf("Evaluate() extensions = %v, want %v", d.Extensions, extensions)
+			}
+		})
+	}
+
+	if d, err := (&Evaluator{}).Evaluate(&Request{Path: "/"}); err != nil || d.Result != Allowed {
+		t.Errorf("Evaluate() without policies = %+v, %v, want %s", d, err, Allowed)
+	}
+	custom := &Evaluator{Policies: []*Policy{policy("ext-authz", networkingv1.Custom), e.Policies[1]}}
+	if d, err := custom.Evaluate(&Request{Path: "/admin/users"}); err != nil || d.Result != Delegated || d.Policy.Name != "ext-authz" || d.Rule != -1 {
+		t.Errorf("Evaluate() with a CUSTOM policy without rules = %+v, %v, want %s by ext-authz", d, err, Delegated)
+	}
+	when := &Evaluator{Policies: []*Policy{policy("when", networkingv1.Allow, networkingv1.GCPAuthPolicyRule{When: ptr.To("request.time < now")})}}
+	if _, err := when.Evaluate(&Request{Path: "/"}); err == nil {
+		t.Errorf("Evaluate() with a when condition and no ConditionFunc succeeded, want error")
+	}
+}
+
+func TestEvaluateSources(t *testing.T) {
+	p, err := Compile(&networkingv1.GCPAuthzPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "partners"},
+		Spec: networkingv1.GCPAuthzPolicySpec{
+			EnforcementLevel: networkingv1.L7,
+			Rules: []networkingv1.GCPAuthPolicyRule{{From: &networkingv1.GCPAuthzPolicyFrom{Sources: []networkingv1.GCPAuthzPolicySource{{
+				IPBlocks:    []networkingv1.CIDR{"203.0.113.0/24", "2001:db8::/32"},
+				NotIPBlocks: []networkingv1.CIDR{"203.0.113.13"},
+				RequestAuth: &networkingv1.GCPAuthzPolicyRequestAuth{
+					Audiences:         []networkingv1.StringMatchCriteria{{Type: networkingv1.StringExact, Value: "orders"}},
+					RequestPrincipals: []networkingv1.StringMatchCriteria{{Type: networkingv1.StringPrefix, Value: "https://issuer.example.com/"}},
+					Claims: []networkingv1.JWTClaimMatch{{
+						Name:   "realm_access.roles",
+						Values: []networkingv1.StringMatchCriteria{{Type: networkingv1.StringExact, Value: "partner"}},
+					}},
+				},
+			}}}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := &Evaluator{Policies: []*Policy{p}}
+	claims := map[string]any{
+		"iss":          "https://issuer.example.com",
+		"sub":          "acme",
+		"aud":          []any{"billing", "orders"},
+		"realm_access": map[string]any{"roles": []any{"partner"}},
+	}
+
+	for _, tc := range []struct {
+		desc   string
+		addr   string
+		claims map[string]any
+		want   Result
+	}{
+		{desc: "partner", addr: "203.0.113.7", claims: claims, want: Allowed},
+		{desc: "IPv4-mapped IPv6", addr: "::ffff:203.0.113.7", claims: claims, want: Allowed},
+		{desc: "IPv6", addr: "2001:db8::1", claims: claims, want: Allowed},
+		{desc: "excluded address", addr: "203.0.113.13", claims: claims, want: Denied},
+		{desc: "outside range", addr: "198.51.100.1", claims: claims, want: Denied},
+		{desc: "no credential", addr: "203.0.113.7", want: Denied},
+		{desc: "missing claim", addr: "203.0.113.7", claims: map[string]any{"iss": "https://issuer.example.com", "sub": "acme", "aud": "orders"}, want: Denied},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := &Request{Path: "/", Claims: tc.claims, Peer: Peer{Address: netip.MustParseAddr(tc.addr)}}
+			d, err := e.Evaluate(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Result != tc.want {
+				t.Errorf("Evaluate() = %+v, want %s", d, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateSources(t *testing.T) {
+	spec := &networkingv1.GCPAuthzPolicySpec{
+		EnforcementLevel: networkingv1.L4,
+		Rules: []networkingv1.GCPAuthPolicyRule{{From: &networkingv1.GCPAuthzPolicyFrom{Sources: []networkingv1.GCPAuthzPolicySource{{
+			IPBlocks:    []networkingv1.CIDR{"10.0.0.1/8", "10.0.0.0/8"},
+			NotIPBlocks: []networkingv1.CIDR{"fe80::1%eth0"},
+			RequestAuth: &networkingv1.GCPAuthzPolicyRequestAuth{Issuers: []networkingv1.StringMatchCriteria{{Value: "https://issuer.example.com"}}},
+		}}}}},
+	}
+	want := []string{
+		"spec.rules[0].from.sources[0].ipBlocks[0]",
+		"spec.rules[0].from.sources[0].notIpBlocks[0]",
+		"spec.rules[0].from.sources[0].requestAuth",
+	}
+	var got []string
+	for _, err := range ValidateSpec(spec, field.NewPath("spec")) {
+		got = append(got, err.Field)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateSpec() fields = %v, want %v", got, want)
+	}
+}
+
+func TestSetEnforcedCondition(t *testing.T) {
+	p := &networkingv1.GCPAuthzPolicy{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec:       networkingv1.GCPAuthzPolicySpec{EnforcementMode: ptr.To(networkingv1.DryRun)},
+		Status:     gatewayv1.PolicyStatus{Ancestors: []gatewayv1.PolicyAncestorStatus{{}, {}}},
+	}
+	SetEnforcedCondition(p, metav1.Now())
+	for _, a := range p.Status.Ancestors {
+		c := meta.FindStatusCondition(a.Conditions, string(networkingv1.PolicyConditionEnforced))
+		if c == nil || c.Status != metav1.ConditionFalse || c.Reason != string(networkingv1.PolicyReasonDryRun) || c.ObservedGeneration != 2 {
+			t.Errorf("Enforced condition = %+v, want False with reason DryRun", c)
+		}
+	}
+}
+
+func TestResolveExtensions(t *testing.T) {
+	indexer := func() cache.Indexer {
+		return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	}
+	extensions := indexer()
+	chain := func(name string, events []networkingv1.EventType, kind gatewayv1.Kind) networkingv1.ExtensionChain {
+		return networkingv1.ExtensionChain{Name: name, Extensions: []networkingv1.Extension{{
+			Name:            name,
+			BackendRef:      &networkingv1.ExtensionServiceReference{Kind: kind, Name: "callout", Port: 443},
+			SupportedEvents: events,
+		}}}
+	}
+	for _, ext := range []*networkingv1.GCPTrafficExtension{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "authz"},
+			Spec: networkingv1.GCPTrafficExtensionSpec{ExtensionChains: []networkingv1.ExtensionChain{
+				chain("headers", []networkingv1.EventType{networkingv1.EventTypeRequestHeaders, networkingv1.EventTypeResponseHeaders}, "Service"),
+				chain("default", nil, "Service"),
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "body"},
+			Spec: networkingv1.GCPTrafficExtensionSpec{ExtensionChains: []networkingv1.ExtensionChain{
+				chain("body", []networkingv1.EventType{networkingv1.EventTypeRequestBody}, "Service"),
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "wasm"},
+			Spec: networkingv1.GCPTrafficExtensionSpec{ExtensionChains: []networkingv1.ExtensionChain{
+				chain("wasm", []networkingv1.EventType{networkingv1.EventTypeRequestHeaders}, "GCPWasmPlugin"),
+			}},
+		},
+	} {
+		extensions.Add(ext)
+	}
+	authzExtensions := indexer()
+	for _, ext := range []*networkingv1.GCPAuthzExtension{
+		authzExtension("ext-authz", "ext-authz", 9000),
+		authzExtension("metrics", "ext-authz", 9090),
+		authzExtension("missing-service", "missing", 9000),
+	} {
+		authzExtensions.Add(ext)
+	}
+	services := indexer()
+	for _, svc := range []*corev1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "ext-authz"},
+			Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+				{Name: "metrics", Port: 9090},
+				{Name: "grpc", Port: 9000, AppProtocol: ptr.To("kubernetes.io/h2c")},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "http"},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+		},
+	} {
+		services.Add(svc)
+	}
+
+	for _, tc := range []struct {
+		desc           string
+		refs           []gatewayv1.LocalObjectReference
+		wantWireFormat []WireFormat
+		wantReason     networkingv1.PolicyConditionReason
+	}{
+		{
+			desc:           "traffic extension and service",
+			refs:           []gatewayv1.LocalObjectReference{{Group: networkingv1.GroupName, Kind: TrafficExtensionKind, Name: "authz"}, {Kind: ServiceKind, Name: "ext-authz"}},
+			wantWireFormat: []WireFormat{ExtProcGRPC, ExtAuthzGRPC},
+		},
+		{
+			desc:           "authz extension",
+			refs:           []gatewayv1.LocalObjectReference{{Group: networkingv1.GroupName, Kind: AuthzExtensionKind, Name: "ext-authz"}},
+			wantWireFormat: []WireFormat{ExtAuthzGRPC},
+		},
+		{
+			desc:       "missing authz extension",
+			refs:       []gatewayv1.LocalObjectReference{{Group: networkingv1.GroupName, Kind: AuthzExtensionKind, Name: "missing"}},
+			wantReason: networkingv1.PolicyReasonExtensionNotFound,
+		},
+		{
+			desc:       "authz extension without service",
+			refs:       []gatewayv1.LocalObjectReference{{Group: networkingv1.GroupName, Kind: AuthzExtensionKind, Name: "missing-service"}},
+			wantReason: networkingv1.PolicyReasonExtensionNotFound,
+		},
+		{
+			desc:       "authz extension on a port without grpc",
+			refs:       []gatewayv1.LocalObjectReference{{Group: networkingv1.GroupName, Kind: AuthzExtensionKind, Name: "metrics"}},
+			wantReason: networkingv1.PolicyReasonUnsupportedWireFormat,
+		},
+		{
+			desc:       "missing traffic extension",
+			refs:       []gatewayv1.LocalObjectReference{{Group: networkingv1.GroupName, Kind: TrafficExtensionKind, Name: "missing"}},
+			wantReason: networkingv1.PolicyReasonExtensionNotFound,
+		},
+		{
+			desc:       "missing service",
+			refs:       []gatewayv1.LocalObjectReference{{Kind: ServiceKind, Name: "missing"}},
+			wantReason: networkingv1.PolicyReasonExtensionNotFound,
+		},
+		{
+			desc:       "no request headers event",
+			refs:       []gatewayv1.LocalObjectReference{{Group: networkingv1.GroupName, Kind: TrafficExtensionKind, Name: "body"}},
+			wantReason: networkingv1.PolicyReasonUnsupportedEvent,
+		},
+		{
+			desc:       "wasm plugin",
+			refs:       []gatewayv1.LocalObjectReference{{Group: networkingv1.GroupName, Kind: TrafficExtensionKind, Name: "wasm"}},
+			wantReason: networkingv1.PolicyReasonUnsupportedWireFormat,
+		},
+		{
+			desc:       "service without grpc port",
+			refs:       []gatewayv1.LocalObjectReference{{Kind: ServiceKind, Name: "http"}},
+			wantReason: networkingv1.PolicyReasonUnsupportedWireFormat,
+		},
+		{
+			desc:       "invalid kind",
+			refs:       []gatewayv1.LocalObjectReference{{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Name: "authz"}},
+			wantReason: networkingv1.PolicyReasonInvalidKind,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			p := &networkingv1.GCPAuthzPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "custom"},
+				Spec: networkingv1.GCPAuthzPolicySpec{
+					EnforcementLevel: networkingv1.L7,
+					Action:           ptr.To(networkingv1.Custom),
+					CustomProviders:  &networkingv1.GCPAuthzPolicyCustomProviders{ExtensionRefs: tc.refs},
+				},
+			}
+			got, err := ResolveExtensions(p, networkingv1listers.NewGCPAuthzExtensionLister(authzExtensions), networkingv1listers.NewGCPTrafficExtensionLister(extensions), corev1listers.NewServiceLister(services))
+			c := ResolvedRefsCondition(p, err, metav1.Now())
+			if tc.wantReason != "" {
+				var refErr *policyref.RefError
+				if !errors.As(err, &refErr) || refErr.Reason != tc.wantReason {
+					t.Fatalf("ResolveExtensions() = %v, want RefError with reason %s", err, tc.wantReason)
+				}
+				if c.Status != metav1.ConditionFalse || c.Reason != string(tc.wantReason) {
+					t.Errorf("ResolvedRefsCondition() = %+v, want False with reason %s", c, tc.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveExtensions() = %v", err)
+			}
+			var formats []WireFormat
+			for _, r := range got {
+				formats = append(formats, r.WireFormat)
+			}
+			if !reflect.DeepEqual(formats, tc.wantWireFormat) {
+				t.Errorf("ResolveExtensions() wire formats = %v, want %v", formats, tc.wantWireFormat)
+			}
+			if c.Status != metav1.ConditionTrue {
+				t.Errorf("ResolvedRefsCondition() = %+v, want True", c)
+			}
+		})
+	}
+}
+
+func authzExtension(name, service string, port networkingv1.PortNumber) *networkingv1.GCPAuthzExtension {
+	return &networkingv1.GCPAuthzExtension{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: name},
+		Spec: networkingv1.GCPAuthzExtensionSpec{
+			BackendRef: networkingv1.ExtensionServiceReference{Kind: ServiceKind, Name: gatewayv1.ObjectName(service), Port: port},
+			Authority:  "ext-authz.example.com",
+		},
+	}
+}
+
+func TestValidateAuthzExtensionSpec(t *testing.T) {
+	spec := &networkingv1.GCPAuthzExtensionSpec{
+		BackendRef:               networkingv1.ExtensionServiceReference{Kind: "GCPWasmPlugin", Name: "plugin"},
+		Timeout:                  ptr.To(gatewayv1.Duration("20s")),
+		FailOpen:                 true,
+		StatusOnError:            ptr.To(int32(503)),
+		HeadersToUpstreamOnAllow: []networkingv1.HTTPHeaderName{"x-user", "X-User"},
+	}
+	want := []string{
+		"spec.backendRef.kind",
+		"spec.timeout",
+		"spec.statusOnError",
+		"spec.headersToUpstreamOnAllow[1]",
+	}
+	var got []string
+	for _, err := range ValidateAuthzExtensionSpec(spec, field.NewPath("spec")) {
+		got = append(got, err.Field)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateAuthzExtensionSpec() fields = %v, want %v", got, want)
+	}
+}
+
+func TestSetAuthzExtensionConditions(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		errs         field.ErrorList
+		resolveErr   error
+		wantAccepted networkingv1.ExtensionConditionReason
+		wantResolved networkingv1.ExtensionConditionReason
+	}{
+		{
+			desc:         "resolved",
+			wantAccepted: networkingv1.ExtensionReasonAccepted,
+			wantResolved: networkingv1.ExtensionReasonResolvedRefs,
+		},
+		{
+			desc:         "invalid",
+			errs:         field.ErrorList{field.Forbidden(field.NewPath("spec", "statusOnError"), "cannot be set when failOpen is true")},
+			wantAccepted: networkingv1.ExtensionReasonInvalid,
+			wantResolved: networkingv1.ExtensionReasonResolvedRefs,
+		},
+		{
+			desc:         "service not found",
+			resolveErr:   &policyref.RefError{Reason: networkingv1.PolicyReasonExtensionNotFound, Message: "Service app/ext-authz not found"},
+			wantAccepted: networkingv1.ExtensionReasonAccepted,
+			wantResolved: networkingv1.ExtensionReasonExtensionServiceNotFound,
+		},
+		{
+			desc:         "not grpc",
+			resolveErr:   &policyref.RefError{Reason: networkingv1.PolicyReasonUnsupportedWireFormat, Message: "Service app/ext-authz has no gRPC port"},
+			wantAccepted: networkingv1.ExtensionReasonInvalidExtensionService,
+			wantResolved: networkingv1.ExtensionReasonResolvedRefs,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ext := authzExtension("ext-authz", "ext-authz", 9000)
+			SetAuthzExtensionConditions(ext, tc.errs, tc.resolveErr, metav1.Now())
+			for condType, want := range map[networkingv1.ExtensionConditionType]networkingv1.ExtensionConditionReason{
+				networkingv1.ExtensionConditionAccepted:     tc.wantAccepted,
+				networkingv1.ExtensionConditionResolvedRefs: tc.wantResolved,
+			} {
+				c := meta.FindStatusCondition(ext.Status.Conditions, string(condType))
+				if c == nil || c.Reason != string(want) {
+					t.Errorf("%s condition = %+v, want reason %s", condType, c, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package authz
+
+import (
+	"io"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/manifest"
+)
+
+// DecodePolicies reads the GCPAuthzPolicies of a multi-document YAML or JSON
+// stream, such as the output of `kubectl get gcpauthzpolicies -o yaml`.
+// Lists are expanded, and documents of other kinds are skipped.
+func DecodePolicies(r io.Reader) ([]*networkingv1.GCPAuthzPolicy, error) {
+	var out []*networkingv1.GCPAuthzPolicy
+	if err := manifest.Decode(r, manifest.Kinds{"GCPAuthzPolicy": manifest.Strict(&out)}); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
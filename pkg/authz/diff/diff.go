@@ -0,0 +1,335 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package diff compares two versions of a set of GCPAuthzPolicy resources.
+//
+// Compare reports the semantic changes between the versions: reordered or
+// duplicated rules, sources and match values are not changes, while an
+// ignoreCase flip or a change from Prefix to Exact is. Flips generates
+// example requests from the match criteria of both versions and returns
+// those whose authorization decision differs.
+package diff
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// ChangeKind is the kind of a change to a policy or rule.
+type ChangeKind string
+
+const (
+	Added    ChangeKind = "Added"
+	Removed  ChangeKind = "Removed"
+	Modified ChangeKind = "Modified"
+)
+
+// PolicyDiff describes the changes to a single policy.
+type PolicyDiff struct {
+	// Name is the namespace/name of the policy. If the policies are compared
+	// directly and their names differ, it is "old -> new".
+	Name string `json:"name"`
+	// Kind is Added, Removed or Modified.
+	Kind ChangeKind `json:"kind"`
+	// Fields lists the changed policy level fields.
+	Fields []FieldChange `json:"fields,omitempty"`
+	// Rules lists the added, removed and modified rules.
+	Rules []RuleDiff `json:"rules,omitempty"`
+}
+
+// FieldChange is a change of a policy level field, in normalized form.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// RuleDiff describes the changes to a rule. A modified rule is a removed
+// rule paired with the most similar added rule.
+type RuleDiff struct {
+	Kind ChangeKind `json:"kind"`
+	// OldIndex and NewIndex are the indexes of the rule in the old and new
+	// policy, or -1.
+	OldIndex int `json:"oldIndex"`
+	NewIndex int `json:"newIndex"`
+	// Removed and Added are the normalized clauses that only the old and only
+	// the new rule have.
+	Removed []string `json:"removed,omitempty"`
+	Added   []string `json:"added,omitempty"`
+}
+
+// Compare returns the semantic differences between the old and new
+// policies, sorted by name. Policies are paired by namespace and name,
+// except when both sides hold exactly one policy, which are compared
+// directly. Policies without changes are omitted.
+func Compare(before, after []*networkingv1.GCPAuthzPolicy) []PolicyDiff {
+	if len(before) == 1 && len(after) == 1 {
+		o, n := normalizePolicy(before[0]), normalizePolicy(after[0])
+		d := comparePolicy(o, n)
+		if o.name != n.name {
+			d.Name = o.name + " -> " + n.name
+		}
+		if d.Kind == "" {
+			return nil
+		}
+		return []PolicyDiff{d}
+	}
+
+	oldByName := map[string]normalizedPolicy{}
+	for _, p := range before {
+		n := normalizePolicy(p)
+		oldByName[n.name] = n
+	}
+	newByName := map[string]normalizedPolicy{}
+	for _, p := range after {
+		n := normalizePolicy(p)
+		newByName[n.name] = n
+	}
+
+	var diffs []PolicyDiff
+	for name, o := range oldByName {
+		n, ok := newByName[name]
+		if !ok {
+			diffs = append(diffs, PolicyDiff{Name: name, Kind: Removed, Rules: wholeRules(o, Removed)})
+			continue
+		}
+		if d := comparePolicy(o, n); d.Kind != "" {
+			diffs = append(diffs, d)
+		}
+	}
+	for name, n := range newByName {
+		if _, ok := oldByName[name]; !ok {
+			diffs = append(diffs, PolicyDiff{Name: name, Kind: Added, Rules: wholeRules(n, Added)})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+	return diffs
+}
+
+// comparePolicy returns the differences between two versions of a policy.
+// Kind is empty if there are none.
+func comparePolicy(o, n normalizedPolicy) PolicyDiff {
+	d := PolicyDiff{Name: n.name}
+	var fields []string
+	for f := range o.fields {
+		fields = append(fields, f)
+	}
+	for f := range n.fields {
+		if _, ok := o.fields[f]; !ok {
+			fields = append(fields, f)
+		}
+	}
+	sort.Strings(fields)
+	for _, f := range fields {
+		if o.fields[f] != n.fields[f] {
+			d.Fields = append(d.Fields, FieldChange{Field: f, Old: o.fields[f], New: n.fields[f]})
+		}
+	}
+
+	// Rules are ORed, so equal rules cancel out regardless of their position.
+	// Duplicate rules in either version are ignored.
+	oldRules, newRules := dedupRules(o.rules), dedupRules(n.rules)
+	newKeys := map[string]bool{}
+	for _, r := range newRules {
+		newKeys[r.key()] = true
+	}
+	oldKeys := map[string]bool{}
+	var removed, added []normalizedRule
+	for _, r := range oldRules {
+		oldKeys[r.key()] = true
+		if !newKeys[r.key()] {
+			removed = append(removed, r)
+		}
+	}
+	for _, r := range newRules {
+		if !oldKeys[r.key()] {
+			added = append(added, r)
+		}
+	}
+	d.Rules = pairRules(removed, added)
+
+	if len(d.Fields) > 0 || len(d.Rules) > 0 {
+		d.Kind = Modified
+	}
+	return d
+}
+
+func dedupRules(rules []normalizedRule) []normalizedRule {
+	seen := map[string]bool{}
+	var out []normalizedRule
+	for _, r := range rules {
+		if !seen[r.key()] {
+			seen[r.key()] = true
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// pairRules pairs removed and added rules into modifications, most similar
+// first. Rules without any clause in common are paired in order, so that a
+// rule whose only clause changed is still reported as modified.
+func pairRules(removed, added []normalizedRule) []RuleDiff {
+	type pair struct{ i, j, shared int }
+	var pairs []pair
+	for i, r := range removed {
+		for j, a := range added {
+			if s := len(intersect(r.clauses, a.clauses)); s > 0 {
+				pairs = append(pairs, pair{i, j, s})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(a, b int) bool { return pairs[a].shared > pairs[b].shared })
+
+	usedOld := make([]bool, len(removed))
+	usedNew := make([]bool, len(added))
+	var diffs []RuleDiff
+	modify := func(i, j int) {
+		usedOld[i], usedNew[j] = true, true
+		diffs = append(diffs, RuleDiff{
+			Kind:     Modified,
+			OldIndex: removed[i].index,
+			NewIndex: added[j].index,
+			Removed:  subtract(removed[i].clauses, added[j].clauses),
+			Added:    subtract(added[j].clauses, removed[i].clauses),
+		})
+	}
+	for _, p := range pairs {
+		if !usedOld[p.i] && !usedNew[p.j] {
+			modify(p.i, p.j)
+		}
+	}
+	j := 0
+	for i := range removed {
+		if usedOld[i] {
+			continue
+		}
+		for j < len(added) && usedNew[j] {
+			j++
+		}
+		if j == len(added) {
+			break
+		}
+		modify(i, j)
+	}
+	for i, r := range removed {
+		if !usedOld[i] {
+			diffs = append(diffs, RuleDiff{Kind: Removed, OldIndex: r.index, NewIndex: -1, Removed: r.clauses})
+		}
+	}
+	for j, a := range added {
+		if !usedNew[j] {
+			diffs = append(diffs, RuleDiff{Kind: Added, OldIndex: -1, NewIndex: a.index, Added: a.clauses})
+		}
+	}
+	sort.SliceStable(diffs, func(a, b int) bool {
+		return ruleOrder(diffs[a]) < ruleOrder(diffs[b])
+	})
+	return diffs
+}
+
+func ruleOrder(d RuleDiff) int {
+	if d.NewIndex >= 0 {
+		return d.NewIndex
+	}
+	return d.OldIndex
+}
+
+func wholeRules(p normalizedPolicy, kind ChangeKind) []RuleDiff {
+	var diffs []RuleDiff
+	for _, r := range p.rules {
+		d := RuleDiff{Kind: kind, OldIndex: -1, NewIndex: -1}
+		if kind == Removed {
+			d.OldIndex, d.Removed = r.index, r.clauses
+		} else {
+			d.NewIndex, d.Added = r.index, r.clauses
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
+
+func intersect(a, b []string) []string {
+	set := map[string]bool{}
+	for _, s := range b {
+		set[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if set[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func subtract(a, b []string) []string {
+	set := map[string]bool{}
+	for _, s := range b {
+		set[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if !set[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Format writes a human readable rendering of the differences to w.
+func Format(w io.Writer, diffs []PolicyDiff) error {
+	var b strings.Builder
+	for _, d := range diffs {
+		fmt.Fprintf(&b, "%s %s\n", strings.ToLower(string(d.Kind)), d.Name)
+		for _, f := range d.Fields {
+			fmt.Fprintf(&b, "  %s: %s -> %s\n", f.Field, orNone(f.Old), orNone(f.New))
+		}
+		for _, r := range d.Rules {
+			switch r.Kind {
+			case Added:
+				fmt.Fprintf(&b, "  rule %d added\n", r.NewIndex)
+			case Removed:
+				fmt.Fprintf(&b, "  rule %d removed\n", r.OldIndex)
+			default:
+				if r.OldIndex == r.NewIndex {
+					fmt.Fprintf(&b, "  rule %d modified\n", r.NewIndex)
+				} else {
+					fmt.Fprintf(&b, "  rule %d (was %d) modified\n", r.NewIndex, r.OldIndex)
+				}
+			}
+			for _, c := range r.Removed {
+				fmt.Fprintf(&b, "    - %s\n", c)
+			}
+			for _, c := range r.Added {
+				fmt.Fprintf(&b, "    + %s\n", c)
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
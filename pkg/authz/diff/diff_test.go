@@ -0,0 +1,315 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package diff
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz"
+)
+
+const header = `
+apiVersion: networking.gke.io/v1
+kind: GCPAuthzPolicy
+metadata:
+  name: api
+  namespace: default
+spec:
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: api
+`
+
+func decode(t *testing.T, docs ...string) []*networkingv1.GCPAuthzPolicy {
+	t.Helper()
+	policies, err := authz.DecodePolicies(strings.NewReader(strings.Join(docs, "\n---\n")))
+	if err != nil {
+		t.Fatalf("DecodePolicies() error = %v", err)
+	}
+	return policies
+}
+
+const twoRules = header + `
+  rules:
+  - to:
+      operations:
+      - paths:
+        - type: Prefix
+          value: /v1
+        methods: [GET, POST]
+  - from:
+      sources:
+      - principals:
+        - principal:
+            value: spiffe://example.com/a
+        ipBlocks: [10.0.0.0/24]
+`
+
+func TestCompare(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		before      string
+		after       string
+		wantChanges []string
+	}{
+		{
+			name:   "reordered rules and values",
+			before: twoRules,
+			after: header + `
+  action: ALLOW
+  rules:
+  - from:
+      sources:
+      - ipBlocks: [10.0.0.0/24, 10.0.0.0/24]
+        principals:
+        - principalSelector: CLIENT_CERT_URI_SAN
+          principal:
+            type: Exact
+            value: spiffe://example.com/a
+  - to:
+      operations:
+      - methods: [POST, GET]
+        paths:
+        - type: Prefix
+          value: /v1
+`,
+		},
+		{
+			name:   "prefix to exact",
+			before: twoRules,
+			after:  strings.Replace(twoRules, "type: Prefix", "type: Exact", 1),
+			wantChanges: []string{
+				"modified default/api",
+				"  rule 0 modified",
+				`    - operation {methods=[GET, POST] paths=[Prefix:"/v1"]}`,
+				`    + operation {methods=[GET, POST] paths=[Exact:"/v1"]}`,
+			},
+		},
+		{
+			name:   "ignore case",
+			before: twoRules,
+			after:  strings.Replace(twoRules, "value: /v1", "value: /V1\n          ignoreCase: true", 1),
+			wantChanges: []string{
+				"modified default/api",
+				"  rule 0 modified",
+				`    - operation {methods=[GET, POST] paths=[Prefix:"/v1"]}`,
+				`    + operation {methods=[GET, POST] paths=[Prefix/i:"/v1"]}`,
+			},
+		},
+		{
+			name:   "action and removed rule",
+			before: twoRules,
+			after: header + `
+  action: DENY
+  rules:
+  - to:
+      operations:
+      - paths:
+        - type: Prefix
+          value: /v1
+        methods: [GET, POST]
+`,
+			wantChanges: []string{
+				"modified default/api",
+				"  action: ALLOW -> DENY",
+				"  rule 1 removed",
+				`    - source {principals=[CLIENT_CERT_URI_SAN Exact:"spiffe://example.com/a"] ipBlocks=[10.0.0.0/24]}`,
+			},
+		},
+		{
+			name:   "added policy",
+			before: twoRules,
+			after:  twoRules + "\n---\n" + strings.Replace(header, "name: api\n  namespace", "name: other\n  namespace", 1),
+			wantChanges: []string{
+				"added default/other",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diffs := Compare(decode(t, tc.before), decode(t, tc.after))
+			var b strings.Builder
+			if err := Format(&b, diffs); err != nil {
+				t.Fatal(err)
+			}
+			got := strings.TrimSuffix(b.String(), "\n")
+			if want := strings.Join(tc.wantChanges, "\n"); got != want {
+				t.Errorf("Compare() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestFlips(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		before string
+		after  string
+		// want are regular expressions matching the description of the
+		// flipped requests, and their old and new results.
+		want []string
+	}{
+		{
+			name:   "reordered",
+			before: twoRules,
+			after:  twoRules,
+		},
+		{
+			name:   "prefix to exact",
+			before: twoRules,
+			after:  strings.Replace(twoRules, "type: Prefix", "type: Exact", 1),
+			want:   []string{`^GET example.com/v1x ALLOWED DENIED$`},
+		},
+		{
+			name:   "narrower ip block",
+			before: twoRules,
+			after:  strings.Replace(twoRules, "10.0.0.0/24", "10.0.0.0/25", 1),
+			want:   []string{`^GET example.com/ from 10.0.0.255 uriSan=spiffe://example.com/a ALLOWED DENIED$`},
+		},
+		{
+			name:   "method removed",
+			before: twoRules,
+			after:  strings.Replace(twoRules, "[GET, POST]", "[GET]", 1),
+			want:   []string{`^POST example.com/v1 ALLOWED DENIED$`},
+		},
+		{
+			name:   "regex",
+			before: twoRules,
+			after:  strings.Replace(twoRules, "type: Prefix\n          value: /v1", "type: SafeRegex\n          value: /v[0-9]+/.*", 1),
+			want: []string{
+				`^GET example.com/v1 ALLOWED DENIED$`,
+				`^GET example.com/v0/x? DENIED ALLOWED$`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			flips, err := Flips(decode(t, tc.before), decode(t, tc.after), 0)
+			if err != nil {
+				t.Fatalf("Flips() error = %v", err)
+			}
+			var got []string
+			for _, f := range flips {
+				got = append(got, Describe(f.Request)+" "+string(f.Old.Result)+" "+string(f.New.Result))
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("Flips() = %q, want %d flips", got, len(tc.want))
+			}
+			for i, re := range tc.want {
+				if !regexp.MustCompile(re).MatchString(got[i]) {
+					t.Errorf("Flips()[%d] = %q, want match of %q", i, got[i], re)
+				}
+			}
+		})
+	}
+}
+
+func TestFlipsByTarget(t *testing.T) {
+	gateway := func(policy, gateway, path string) string {
+		return `
+apiVersion: networking.gke.io/v1
+kind: GCPAuthzPolicy
+metadata:
+  name: ` + policy + `
+  namespace: default
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: ` + gateway + `
+  rules:
+  - to:
+      operations:
+      - paths:
+        - type: Prefix
+          value: ` + path + `
+`
+	}
+	internal := gateway("internal", "internal", "/v1")
+	external := gateway("external", "external", "/v2")
+
+	for _, tc := range []struct {
+		name   string
+		before []string
+		after  []string
+		want   []string
+	}{
+		{
+			name:   "policy added for another target",
+			before: []string{internal},
+			after:  []string{internal, external},
+			want:   []string{`^default gateway.networking.k8s.io/Gateway external: GET example.com/ ALLOWED DENIED$`},
+		},
+		{
+			name:   "policy moved to another target",
+			before: []string{internal},
+			after:  []string{strings.Replace(internal, "name: internal\n  rules", "name: external\n  rules", 1)},
+			want: []string{
+				`^default gateway.networking.k8s.io/Gateway external: GET example.com/ ALLOWED DENIED$`,
+				`^default gateway.networking.k8s.io/Gateway internal: GET example.com/ DENIED ALLOWED$`,
+			},
+		},
+		{
+			name:   "unrelated targets",
+			before: []string{internal, external},
+			after:  []string{external, internal},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			flips, err := Flips(decode(t, tc.before...), decode(t, tc.after...), 0)
+			if err != nil {
+				t.Fatalf("Flips() error = %v", err)
+			}
+			var got []string
+			for _, f := range flips {
+				got = append(got, f.Target+": "+Describe(f.Request)+" "+string(f.Old.Result)+" "+string(f.New.Result))
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("Flips() = %q, want %d flips", got, len(tc.want))
+			}
+			for i, re := range tc.want {
+				if !regexp.MustCompile(re).MatchString(got[i]) {
+					t.Errorf("Flips()[%d] = %q, want match of %q", i, got[i], re)
+				}
+			}
+		})
+	}
+}
+
+func TestRegexExample(t *testing.T) {
+	for _, expr := range []string{
+		`/v[0-9]+/.*`,
+		`[^/]+\.example\.com`,
+		`(foo|bar){2,3}`,
+		`\d{3}-\w+`,
+		`spiffe://[a-z]+\.svc\.id\.goog/ns/[^/]+/sa/.+`,
+	} {
+		s, ok := regexExample(expr)
+		if !ok {
+			t.Errorf("regexExample(%q) failed", expr)
+			continue
+		}
+		if !regexp.MustCompile(`^(?:` + expr + `)$`).MatchString(s) {
+			t.Errorf("regexExample(%q) = %q, which does not match", expr, s)
+		}
+	}
+	if s, ok := regexExample(`a\bb`); ok {
+		t.Errorf("regexExample() = %q for an expression without matches", s)
+	}
+}
@@ -0,0 +1,698 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz"
+)
+
+// DefaultLimit is the default maximum number of flips returned by Flips.
+const DefaultLimit = 20
+
+// Flip is an example request whose decision differs between the old and new
+// versions of the policies that apply to a target.
+type Flip struct {
+	// Target is the namespace and target reference of the policies that
+	// decide the request, e.g.
+	// "default gateway.networking.k8s.io/Gateway gateway".
+	Target  string
+	Request *authz.Request
+	Old     authz.Decision
+	New     authz.Decision
+}
+
+// mutation changes one attribute of a request.
+type mutation func(*authz.Request)
+
+// Flips returns example requests whose decision differs between the old and
+// new policies, at most limit of them, or DefaultLimit if limit is not
+// positive.
+//
+// Policies are grouped by target, i.e. by namespace and target reference, and
+// each group is evaluated on its own, as only the policies that apply to the
+// same workload decide a request together. A policy with several targetRefs
+// is part of several groups. Flips are returned in order of target.
+//
+// The requests are generated from the match criteria of both versions of the
+// policies of a target. For every rule a request that matches it is built,
+// and then varied one attribute at a time using values that match, or nearly
+// match, any of the criteria: for example the value of a Prefix match and the
+// value followed by another character, or the first and last addresses of an
+// IP block and the addresses next to it. When conditions are assumed to hold.
+// Only one example is returned for each target and distinct pair of old and
+// new decisions.
+func Flips(before, after []*networkingv1.GCPAuthzPolicy, limit int) ([]Flip, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	type group struct {
+		before, after []*networkingv1.GCPAuthzPolicy
+	}
+	groups := map[string]*group{}
+	add := func(p *networkingv1.GCPAuthzPolicy, after bool) {
+		seen := map[string]bool{}
+		for _, t := range p.Spec.TargetRefs {
+			key := p.Namespace + " " + target(t)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			g := groups[key]
+			if g == nil {
+				g = &group{}
+				groups[key] = g
+			}
+			if after {
+				g.after = append(g.after, p)
+			} else {
+				g.before = append(g.before, p)
+			}
+		}
+	}
+	for _, p := range before {
+		add(p, false)
+	}
+	for _, p := range after {
+		add(p, true)
+	}
+
+	var flips []Flip
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		g := groups[key]
+		tf, err := targetFlips(g.before, g.after, limit-len(flips))
+		if err != nil {
+			return nil, fmt.Errorf("target %s: %w", key, err)
+		}
+		for _, f := range tf {
+			f.Target = key
+			flips = append(flips, f)
+		}
+		if len(flips) >= limit {
+			break
+		}
+	}
+	return flips, nil
+}
+
+// targetFlips returns at most limit example requests whose decision differs
+// between the old and new policies of a single target.
+func targetFlips(before, after []*networkingv1.GCPAuthzPolicy, limit int) ([]Flip, error) {
+	oldEval, err := evaluator(before)
+	if err != nil {
+		return nil, err
+	}
+	newEval, err := evaluator(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutations []mutation
+	bases := []*authz.Request{defaultRequest()}
+	for _, p := range append(append([]*networkingv1.GCPAuthzPolicy{}, before...), after...) {
+		for _, r := range p.Spec.Rules {
+			sources := []*networkingv1.GCPAuthzPolicySource{nil}
+			var operations []*networkingv1.GCPAuthzPolicyOperation
+			if r.From != nil {
+				for i := range r.From.Sources {
+					sources = append(sources, &r.From.Sources[i])
+					mutations = append(mutations, sourceMutations(r.From.Sources[i])...)
+				}
+				for _, s := range r.From.NotSources {
+					mutations = append(mutations, sourceMutations(s)...)
+				}
+			}
+			if r.To != nil {
+				for i := range r.To.Operations {
+					operations = append(operations, &r.To.Operations[i])
+					mutations = append(mutations, operationMutations(r.To.Operations[i])...)
+				}
+				for _, o := range r.To.NotOperations {
+					mutations = append(mutations, operationMutations(o)...)
+				}
+			}
+			if len(operations) == 0 {
+				operations = append(operations, nil)
+			}
+			if len(sources) > 1 {
+				// Every request matches the nil source, so only use it
+				// for rules without sources.
+				sources = sources[1:]
+			}
+			for _, s := range sources {
+				for _, o := range operations {
+					bases = append(bases, matchingRequest(s, o))
+				}
+			}
+		}
+	}
+
+	var flips []Flip
+	seenRequests := map[string]bool{}
+	seenFlips := map[string]bool{}
+	try := func(req *authz.Request) error {
+		key := Describe(req)
+		if seenRequests[key] {
+			return nil
+		}
+		seenRequests[key] = true
+		o, err := oldEval.Evaluate(req)
+		if err != nil {
+			return err
+		}
+		n, err := newEval.Evaluate(req)
+		if err != nil {
+			return err
+		}
+		if o.Result == n.Result {
+			return nil
+		}
+		flipKey := fmt.Sprintf("%s %s %d %s %s %d", o.Result, o.Policy, o.Rule, n.Result, n.Policy, n.Rule)
+		if !seenFlips[flipKey] {
+			seenFlips[flipKey] = true
+			flips = append(flips, Flip{Request: req, Old: o, New: n})
+		}
+		return nil
+	}
+	for _, base := range bases {
+		if err := try(base); err != nil {
+			return nil, err
+		}
+		for _, m := range mutations {
+			if len(flips) >= limit {
+				return flips, nil
+			}
+			req := cloneRequest(base)
+			m(req)
+			if err := try(req); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(flips) > limit {
+		flips = flips[:limit]
+	}
+	return flips, nil
+}
+
+func evaluator(policies []*networkingv1.GCPAuthzPolicy) (*authz.Evaluator, error) {
+	e := &authz.Evaluator{
+		Condition: func(string, *authz.Request) (bool, error) { return true, nil },
+	}
+	for _, p := range policies {
+		c, err := authz.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s/%s: %w", p.Namespace, p.Name, err)
+		}
+		e.Policies = append(e.Policies, c)
+	}
+	return e, nil
+}
+
+func defaultRequest() *authz.Request {
+	return &authz.Request{
+		Host:    "example.com",
+		Method:  string(networkingv1.HTTPMethodGet),
+		Path:    "/",
+		Headers: http.Header{},
+	}
+}
+
+// matchingRequest returns a request that matches the given source and
+// operation, either of which may be nil.
+func matchingRequest(s *networkingv1.GCPAuthzPolicySource, o *networkingv1.GCPAuthzPolicyOperation) *authz.Request {
+	req := defaultRequest()
+	apply := func(ms []mutation) {
+		if len(ms) > 0 {
+			ms[0](req)
+		}
+	}
+	if s != nil {
+		if len(s.Principals) > 0 {
+			apply(principalMutations(s.Principals[0]))
+		}
+		if len(s.Resources) > 0 {
+			apply(resourceMutations(s.Resources[0]))
+		}
+		if len(s.IPBlocks) > 0 {
+			apply(addressMutations(s.IPBlocks[0]))
+		} else if len(s.NotIPBlocks) > 0 {
+			// The address just before the block, if any, is outside it.
+			if ms := addressMutations(s.NotIPBlocks[0]); len(ms) > 2 {
+				ms[2](req)
+			}
+		}
+		if a := s.RequestAuth; a != nil {
+			if len(a.Issuers) > 0 {
+				apply(claimMutations("iss", a.Issuers[0]))
+			}
+			if len(a.Audiences) > 0 {
+				apply(claimMutations("aud", a.Audiences[0]))
+			}
+			if len(a.RequestPrincipals) > 0 {
+				apply(requestPrincipalMutations(a.RequestPrincipals[0]))
+			}
+			for _, c := range a.Claims {
+				if len(c.Values) > 0 {
+					apply(claimMutations(c.Name, c.Values[0]))
+				}
+			}
+		}
+	}
+	if o != nil {
+		for _, h := range o.Headers {
+			apply(headerMutations(h))
+		}
+		if len(o.Hosts) > 0 {
+			apply(hostMutations(o.Hosts[0]))
+		}
+		if len(o.Methods) > 0 {
+			req.Method = string(o.Methods[0])
+		}
+		if len(o.Paths) > 0 {
+			apply(pathMutations(o.Paths[0]))
+		}
+	}
+	return req
+}
+
+func sourceMutations(s networkingv1.GCPAuthzPolicySource) []mutation {
+	var ms []mutation
+	for _, p := range s.Principals {
+		ms = append(ms, principalMutations(p)...)
+	}
+	for _, r := range s.Resources {
+		ms = append(ms, resourceMutations(r)...)
+	}
+	for _, c := range append(append([]networkingv1.CIDR{}, s.IPBlocks...), s.NotIPBlocks...) {
+		ms = append(ms, addressMutations(c)...)
+	}
+	if a := s.RequestAuth; a != nil {
+		for _, m := range a.Issuers {
+			ms = append(ms, claimMutations("iss", m)...)
+		}
+		for _, m := range a.Audiences {
+			ms = append(ms, claimMutations("aud", m)...)
+		}
+		for _, m := range a.RequestPrincipals {
+			ms = append(ms, requestPrincipalMutations(m)...)
+		}
+		for _, c := range a.Claims {
+			for _, m := range c.Values {
+				ms = append(ms, claimMutations(c.Name, m)...)
+			}
+		}
+	}
+	return ms
+}
+
+func operationMutations(o networkingv1.GCPAuthzPolicyOperation) []mutation {
+	var ms []mutation
+	for _, h := range o.Headers {
+		ms = append(ms, headerMutations(h)...)
+	}
+	for _, m := range o.Hosts {
+		ms = append(ms, hostMutations(m)...)
+	}
+	for _, m := range o.Methods {
+		method := string(m)
+		ms = append(ms, func(req *authz.Request) { req.Method = method })
+	}
+	if len(o.Methods) > 0 {
+		// A method that no operation is likely to list.
+		ms = append(ms, func(req *authz.Request) { req.Method = string(networkingv1.HTTPMethodTrace) })
+	}
+	for _, m := range o.Paths {
+		ms = append(ms, pathMutations(m)...)
+	}
+	return ms
+}
+
+func principalMutations(p networkingv1.Principal) []mutation {
+	selector := networkingv1.ClientCertURISAN
+	if p.PrincipalSelector != nil {
+		selector = *p.PrincipalSelector
+	}
+	var ms []mutation
+	for _, v := range examples(p.Principal) {
+		switch selector {
+		case networkingv1.ClientCertDNSNameSAN:
+			ms = append(ms, func(req *authz.Request) { req.Peer.DNSSANs = []string{v} })
+		case networkingv1.ClientCertCommonName:
+			ms = append(ms, func(req *authz.Request) { req.Peer.CommonName = v })
+		default:
+			ms = append(ms, func(req *authz.Request) { req.Peer.URISANs = []string{v} })
+		}
+	}
+	return ms
+}
+
+func resourceMutations(r networkingv1.GCPAuthzPolicyResource) []mutation {
+	ids := append([]int64{}, r.TagValueIDSet...)
+	setIDs := func(req *authz.Request) {
+		if len(ids) > 0 {
+			req.Peer.TagValueIDs = append([]int64{}, ids...)
+		}
+	}
+	if r.IAMServiceAccount == nil {
+		return []mutation{setIDs}
+	}
+	var ms []mutation
+	for _, v := range examples(*r.IAMServiceAccount) {
+		ms = append(ms, func(req *authz.Request) {
+			setIDs(req)
+			req.Peer.IAMServiceAccount = v
+		})
+	}
+	return ms
+}
+
+// addressMutations returns mutations that set the client address to the
+// first and last addresses of the block, and to the addresses just before
+// and after it.
+func addressMutations(c networkingv1.CIDR) []mutation {
+	p, err := authz.ParseCIDR(c)
+	if err != nil {
+		return nil
+	}
+	first := p.Addr()
+	last := lastAddr(p)
+	var ms []mutation
+	for _, a := range []netip.Addr{first, last, first.Prev(), last.Next()} {
+		if a.IsValid() {
+			ms = append(ms, func(req *authz.Request) { req.Peer.Address = a })
+		}
+	}
+	return ms
+}
+
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Addr().AsSlice()
+	for i := range b {
+		if bits := p.Bits() - i*8; bits < 8 {
+			b[i] |= 0xff >> max(bits, 0)
+		}
+	}
+	a, _ := netip.AddrFromSlice(b)
+	return a
+}
+
+func claimMutations(name string, m networkingv1.StringMatchCriteria) []mutation {
+	var ms []mutation
+	for _, v := range examples(m) {
+		ms = append(ms, func(req *authz.Request) { setClaim(req, name, v) })
+	}
+	return ms
+}
+
+// requestPrincipalMutations sets the issuer and subject claims, splitting the
+// example request principals at the last slash.
+func requestPrincipalMutations(m networkingv1.StringMatchCriteria) []mutation {
+	var ms []mutation
+	for _, v := range examples(m) {
+		i := strings.LastIndex(v, "/")
+		if i <= 0 || i == len(v)-1 {
+			continue
+		}
+		ms = append(ms, func(req *authz.Request) {
+			setClaim(req, "iss", v[:i])
+			setClaim(req, "sub", v[i+1:])
+		})
+	}
+	return ms
+}
+
+func setClaim(req *authz.Request, name string, value string) {
+	if req.Claims == nil {
+		req.Claims = map[string]any{}
+	}
+	claims := req.Claims
+	parts := strings.Split(name, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := claims[part].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			claims[part] = next
+		}
+		claims = next
+	}
+	claims[parts[len(parts)-1]] = value
+}
+
+func headerMutations(h networkingv1.HTTPHeaderMatch) []mutation {
+	var ms []mutation
+	for _, v := range examples(networkingv1.StringMatchCriteria{Type: h.Type, Value: h.Value, IgnoreCase: h.IgnoreCase}) {
+		ms = append(ms, func(req *authz.Request) { req.Headers.Set(h.Name, v) })
+	}
+	return ms
+}
+
+func hostMutations(m networkingv1.StringMatchCriteria) []mutation {
+	var ms []mutation
+	for _, v := range examples(m) {
+		ms = append(ms, func(req *authz.Request) { req.Host = v })
+	}
+	return ms
+}
+
+func pathMutations(m networkingv1.StringMatchCriteria) []mutation {
+	var ms []mutation
+	for _, v := range examples(m) {
+		if strings.HasPrefix(v, "/") {
+			ms = append(ms, func(req *authz.Request) { req.Path = v })
+		}
+	}
+	return ms
+}
+
+// examples returns values that match the given criteria, first, and values
+// that only match similar criteria, such as the value in a different case or
+// with an additional character.
+func examples(m networkingv1.StringMatchCriteria) []string {
+	v := m.Value
+	var out []string
+	switch m.Type {
+	case networkingv1.StringPrefix:
+		out = []string{v, v + "x", swapCase(v) + "x", v[:max(len(v)-1, 0)]}
+	case networkingv1.StringSuffix:
+		out = []string{"x" + v, v, "x" + swapCase(v)}
+	case networkingv1.StringContains:
+		out = []string{"x" + v + "x", v, "x" + swapCase(v) + "x"}
+	case networkingv1.StringSafeRegex:
+		if s, ok := regexExample(v); ok {
+			out = []string{s, swapCase(s)}
+		}
+	case networkingv1.StringPathTemplate:
+		s := pathTemplateExample(v)
+		out = []string{s, strings.TrimSuffix(s, "/x/x") + "/x/x/x"}
+	default:
+		out = []string{v, swapCase(v), v + "x"}
+	}
+	seen := map[string]bool{}
+	var unique []string
+	for _, s := range out {
+		if s != "" && !seen[s] {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+	return unique
+}
+
+func swapCase(s string) string {
+	if u := strings.ToUpper(s); u != s {
+		return u
+	}
+	return strings.ToLower(s)
+}
+
+// pathTemplateExample instantiates a path template, replacing every
+// operator with one or two segments.
+func pathTemplateExample(template string) string {
+	segments := strings.Split(template, "/")
+	for i, seg := range segments {
+		op := seg
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			_, op, _ = strings.Cut(seg[1:len(seg)-1], "=")
+			if op == "" {
+				op = "*"
+			}
+		}
+		switch op {
+		case "*":
+			segments[i] = "x"
+		case "**":
+			segments[i] = "x/x"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// regexExample returns a short string that the given regular expression
+// matches in full, preferring letters and digits.
+func regexExample(expr string) (string, bool) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	var b strings.Builder
+	if !writeRegexExample(&b, re.Simplify()) {
+		return "", false
+	}
+	if ok, _ := regexp.MatchString(`^(?:`+expr+`)$`, b.String()); !ok {
+		return "", false
+	}
+	return b.String(), true
+}
+
+func writeRegexExample(b *strings.Builder, re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary, syntax.OpStar, syntax.OpQuest:
+		return true
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+		return true
+	case syntax.OpCharClass:
+		r, ok := classExample(re.Rune)
+		b.WriteRune(r)
+		return ok
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteByte('x')
+		return true
+	case syntax.OpCapture, syntax.OpPlus:
+		return writeRegexExample(b, re.Sub[0])
+	case syntax.OpRepeat:
+		for range re.Min {
+			if !writeRegexExample(b, re.Sub[0]) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !writeRegexExample(b, sub) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpAlternate:
+		return writeRegexExample(b, re.Sub[0])
+	}
+	return false
+}
+
+// classExample returns a rune of the given character class ranges,
+// preferring letters and digits.
+func classExample(ranges []rune) (rune, bool) {
+	if len(ranges) == 0 {
+		return 0, false
+	}
+	for _, want := range []struct{ lo, hi rune }{{'a', 'z'}, {'A', 'Z'}, {'0', '9'}} {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			lo, hi := max(ranges[i], want.lo), min(ranges[i+1], want.hi)
+			if lo <= hi {
+				return lo, true
+			}
+		}
+	}
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := ranges[i]; r <= ranges[i+1] && r-ranges[i] < 256; r++ {
+			if unicode.IsPrint(r) {
+				return r, true
+			}
+		}
+	}
+	return ranges[0], true
+}
+
+func cloneRequest(req *authz.Request) *authz.Request {
+	c := *req
+	c.Headers = req.Headers.Clone()
+	c.Peer.URISANs = append([]string(nil), req.Peer.URISANs...)
+	c.Peer.DNSSANs = append([]string(nil), req.Peer.DNSSANs...)
+	c.Peer.TagValueIDs = append([]int64(nil), req.Peer.TagValueIDs...)
+	c.Claims = cloneClaims(req.Claims)
+	return &c
+}
+
+func cloneClaims(claims map[string]any) map[string]any {
+	if claims == nil {
+		return nil
+	}
+	c := make(map[string]any, len(claims))
+	for k, v := range claims {
+		if m, ok := v.(map[string]any); ok {
+			v = cloneClaims(m)
+		}
+		c[k] = v
+	}
+	return c
+}
+
+// Describe returns a single line description of a request, for example
+// `GET example.com/v1 from 10.0.0.1 uriSan=spiffe://example.com/a`.
+func Describe(req *authz.Request) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s%s", req.Method, req.Host, req.Path)
+	if req.Peer.Address.IsValid() {
+		fmt.Fprintf(&b, " from %s", req.Peer.Address)
+	}
+	for _, s := range req.Peer.URISANs {
+		fmt.Fprintf(&b, " uriSan=%s", s)
+	}
+	for _, s := range req.Peer.DNSSANs {
+		fmt.Fprintf(&b, " dnsSan=%s", s)
+	}
+	if req.Peer.CommonName != "" {
+		fmt.Fprintf(&b, " cn=%s", req.Peer.CommonName)
+	}
+	if len(req.Peer.TagValueIDs) > 0 {
+		fmt.Fprintf(&b, " tagValueIds=%v", req.Peer.TagValueIDs)
+	}
+	if req.Peer.IAMServiceAccount != "" {
+		fmt.Fprintf(&b, " serviceAccount=%s", req.Peer.IAMServiceAccount)
+	}
+	var names []string
+	for name := range req.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range req.Headers[name] {
+			fmt.Fprintf(&b, " %s=%q", strings.ToLower(name), v)
+		}
+	}
+	if req.Claims != nil {
+		// Map keys are sorted when marshaled.
+		claims, _ := json.Marshal(req.Claims)
+		fmt.Fprintf(&b, " claims=%s", claims)
+	}
+	return b.String()
+}
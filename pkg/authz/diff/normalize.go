@@ -0,0 +1,243 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package diff
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"sort"
+	"strings"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz"
+)
+
+// The normalized form of a policy describes each rule as a set of clauses,
+// one per source, not-source, operation, not-operation and condition. Lists
+// whose entries are ORed or ANDed are sorted and deduplicated, defaults are
+// applied and values that are matched case insensitively are lowercased, so
+// that two rules are semantically equal if their clause sets are equal.
+
+// Clause kinds.
+const (
+	clauseSource       = "source"
+	clauseNotSource    = "notSource"
+	clauseOperation    = "operation"
+	clauseNotOperation = "notOperation"
+	clauseWhen         = "when"
+)
+
+type normalizedPolicy struct {
+	name   string
+	fields map[string]string
+	rules  []normalizedRule
+}
+
+type normalizedRule struct {
+	index   int
+	clauses []string
+}
+
+func (r normalizedRule) key() string {
+	return strings.Join(r.clauses, "\n")
+}
+
+func normalizePolicy(p *networkingv1.GCPAuthzPolicy) normalizedPolicy {
+	n := normalizedPolicy{
+		name: p.Namespace + "/" + p.Name,
+		fields: map[string]string{
+			"action":           string(networkingv1.Allow),
+			"enforcementLevel": string(p.Spec.EnforcementLevel),
+			"enforcementMode":  string(networkingv1.Enforce),
+		},
+	}
+	if p.Spec.Action != nil {
+		n.fields["action"] = string(*p.Spec.Action)
+	}
+	if p.Spec.EnforcementMode != nil {
+		n.fields["enforcementMode"] = string(*p.Spec.EnforcementMode)
+	}
+	var targets []string
+	for _, t := range p.Spec.TargetRefs {
+		targets = append(targets, target(t))
+	}
+	n.fields["targetRefs"] = list(targets)
+	if p.Spec.CustomProviders != nil {
+		var refs []string
+		for _, r := range p.Spec.CustomProviders.ExtensionRefs {
+			refs = append(refs, fmt.Sprintf("%s/%s %s", r.Group, r.Kind, r.Name))
+		}
+		n.fields["customProviders"] = list(refs)
+	}
+
+	for i, r := range p.Spec.Rules {
+		nr := normalizedRule{index: i}
+		if r.From != nil {
+			for _, s := range r.From.Sources {
+				nr.clauses = append(nr.clauses, clauseSource+" "+source(s))
+			}
+			for _, s := range r.From.NotSources {
+				nr.clauses = append(nr.clauses, clauseNotSource+" "+source(s))
+			}
+		}
+		if r.To != nil {
+			for _, o := range r.To.Operations {
+				nr.clauses = append(nr.clauses, clauseOperation+" "+operation(o))
+			}
+			for _, o := range r.To.NotOperations {
+				nr.clauses = append(nr.clauses, clauseNotOperation+" "+operation(o))
+			}
+		}
+		if r.When != nil {
+			nr.clauses = append(nr.clauses, clauseWhen+" "+strings.TrimSpace(*r.When))
+		}
+		nr.clauses = sortedSet(nr.clauses)
+		n.rules = append(n.rules, nr)
+	}
+	return n
+}
+
+func source(s networkingv1.GCPAuthzPolicySource) string {
+	var parts []string
+	var principals []string
+	for _, p := range s.Principals {
+		selector := networkingv1.ClientCertURISAN
+		if p.PrincipalSelector != nil {
+			selector = *p.PrincipalSelector
+		}
+		principals = append(principals, string(selector)+" "+stringMatch(p.Principal))
+	}
+	parts = appendList(parts, "principals", principals)
+	var resources []string
+	for _, r := range s.Resources {
+		var ids []string
+		for _, id := range r.TagValueIDSet {
+			ids = append(ids, fmt.Sprint(id))
+		}
+		res := "tagValueIdSet=" + list(ids)
+		if r.IAMServiceAccount != nil {
+			res += " iamServiceAccount=" + stringMatch(*r.IAMServiceAccount)
+		}
+		resources = append(resources, "{"+res+"}")
+	}
+	parts = appendList(parts, "resources", resources)
+	parts = appendList(parts, "ipBlocks", cidrs(s.IPBlocks))
+	parts = appendList(parts, "notIpBlocks", cidrs(s.NotIPBlocks))
+	if a := s.RequestAuth; a != nil {
+		var auth []string
+		auth = appendList(auth, "issuers", stringMatches(a.Issuers))
+		auth = appendList(auth, "audiences", stringMatches(a.Audiences))
+		auth = appendList(auth, "requestPrincipals", stringMatches(a.RequestPrincipals))
+		var claims []string
+		for _, c := range a.Claims {
+			claims = append(claims, c.Name+":"+list(stringMatches(c.Values)))
+		}
+		auth = appendList(auth, "claims", claims)
+		parts = append(parts, "requestAuth={"+strings.Join(auth, " ")+"}")
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+func operation(o networkingv1.GCPAuthzPolicyOperation) string {
+	var parts []string
+	var headers []string
+	for _, h := range o.Headers {
+		headers = append(headers, strings.ToLower(h.Name)+" "+stringMatch(networkingv1.StringMatchCriteria{Type: h.Type, Value: h.Value, IgnoreCase: h.IgnoreCase}))
+	}
+	parts = appendList(parts, "headers", headers)
+	parts = appendList(parts, "hosts", stringMatches(o.Hosts))
+	var methods []string
+	for _, m := range o.Methods {
+		methods = append(methods, string(m))
+	}
+	parts = appendList(parts, "methods", methods)
+	parts = appendList(parts, "paths", stringMatches(o.Paths))
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// stringMatch returns the normalized form of a match criteria, for example
+// `Prefix:"/v1"` or `Exact/i:"example.com"` if the case is ignored.
+func stringMatch(m networkingv1.StringMatchCriteria) string {
+	typ := m.Type
+	if typ == "" {
+		typ = networkingv1.StringExact
+	}
+	value := m.Value
+	s := string(typ)
+	if m.IgnoreCase && typ != networkingv1.StringSafeRegex && typ != networkingv1.StringPathTemplate {
+		s += "/i"
+		value = strings.ToLower(value)
+	}
+	return fmt.Sprintf("%s:%q", s, value)
+}
+
+func stringMatches(ms []networkingv1.StringMatchCriteria) []string {
+	var out []string
+	for _, m := range ms {
+		out = append(out, stringMatch(m))
+	}
+	return out
+}
+
+func cidrs(in []networkingv1.CIDR) []string {
+	var out []string
+	for _, c := range in {
+		if p, err := authz.ParseCIDR(c); err == nil {
+			out = append(out, p.String())
+		} else if p, err := netip.ParsePrefix(string(c)); err == nil {
+			out = append(out, p.Masked().String())
+		} else {
+			out = append(out, string(c))
+		}
+	}
+	return out
+}
+
+func appendList(parts []string, name string, values []string) []string {
+	if len(values) == 0 {
+		return parts
+	}
+	return append(parts, name+"="+list(values))
+}
+
+// target describes the given target reference, e.g.
+// "gateway.networking.k8s.io/Gateway gateway" or "/Pod {app=api}".
+func target(t networkingv1.LocalObjectReference) string {
+	s := fmt.Sprintf("%s/%s", t.Group, t.Kind)
+	if t.Name != "" {
+		s += " " + string(t.Name)
+	}
+	if t.Selector != nil {
+		var labels []string
+		for k, v := range t.Selector.MatchLabels {
+			labels = append(labels, k+"="+v)
+		}
+		s += " {" + strings.Join(sortedSet(labels), ",") + "}"
+	}
+	return s
+}
+
+func list(values []string) string {
+	return "[" + strings.Join(sortedSet(values), ", ") + "]"
+}
+
+func sortedSet(values []string) []string {
+	out := slices.Clone(values)
+	sort.Strings(out)
+	return slices.Compact(out)
+}
@@ -0,0 +1,570 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package authz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// ErrConditionUnsupported is returned when a rule with a When condition
+// must be evaluated but no ConditionFunc is configured.
+var ErrConditionUnsupported = errors.New("when conditions cannot be evaluated without a ConditionFunc")
+
+// Request is the request an authorization decision is made for.
+type Request struct {
+	// Host is the Host header or :authority of the request.
+	Host string
+	// Method is the HTTP method of the request.
+	Method string
+	// Path is the path of the request. Its query and fragment are ignored.
+	Path string
+	// Headers are the request headers.
+	Headers http.Header
+	// Peer describes the client.
+	Peer Peer
+	// Claims are the claims of the validated JWT of the request, or nil if
+	// the request has no validated JWT.
+	Claims map[string]any
+}
+
+// Peer describes the authenticated client of a request.
+type Peer struct {
+	// Address is the IP address of the client.
+	Address netip.Addr
+	// URISANs are the URI SANs of the validated client certificate.
+	URISANs []string
+	// DNSSANs are the DNS name SANs of the validated client certificate.
+	DNSSANs []string
+	// CommonName is the common name of the validated client certificate.
+	CommonName string
+	// TagValueIDs are the resource manager tag value IDs of the source VM.
+	TagValueIDs []int64
+	// IAMServiceAccount is the IAM service account of the source VM.
+	IAMServiceAccount string
+}
+
+// ConditionFunc evaluates the When condition of a rule for a request.
+type ConditionFunc func(expr string, req *Request) (bool, error)
+
+// Result is the outcome of an authorization decision.
+type Result string
+
+const (
+	// Allowed means that the request is allowed.
+	Allowed Result = "ALLOWED"
+	// Denied means that the request is denied.
+	Denied Result = "DENIED"
+	// Delegated means that the request is sent to the authorization
+	// extensions of a CUSTOM policy, which make the decision.
+	Delegated Result = "DELEGATED"
+)
+
+// Decision is an authorization decision and its reason.
+type Decision struct {
+	Result Result
+	// Policy is the policy that made the decision. It is empty if the
+	// decision was made because no policy matched.
+	Policy types.NamespacedName
+	// Rule is the index of the matching rule of Policy, or -1 if the
+	// decision was not made by a rule.
+	Rule int
+	// Extensions are the authorization extensions of a CUSTOM policy if the
+	// request is Delegated.
+	Extensions []gatewayv1.LocalObjectReference
+	// Reason explains the decision.
+	Reason string
+	// DryRun is the decision that would be made if the policies in DryRun
+	// mode were enforced. It is nil if no policy is in DryRun mode.
+	DryRun *Decision
+}
+
+// Policy is a compiled GCPAuthzPolicy.
+type Policy struct {
+	Name             types.NamespacedName
+	Action           networkingv1.GCPAuthzPolicyAction
+	EnforcementLevel networkingv1.EnforcementLevel
+	EnforcementMode  networkingv1.GCPAuthzPolicyEnforcementMode
+	Extensions       []gatewayv1.LocalObjectReference
+	rules            []rule
+}
+
+type rule struct {
+	sources, notSources       []source
+	operations, notOperations []operation
+	when                      *string
+}
+
+type source struct {
+	principals  []principal
+	resources   []resource
+	ipBlocks    []netip.Prefix
+	notIPBlocks []netip.Prefix
+	auth        *requestAuth
+}
+
+type requestAuth struct {
+	issuers, audiences, principals []*StringMatcher
+	claims                         []claim
+}
+
+type claim struct {
+	name   string
+	values []*StringMatcher
+}
+
+type principal struct {
+	selector networkingv1.PrincipalSelector
+	value    *StringMatcher
+}
+
+type resource struct {
+	tagValueIDs    []int64
+	serviceAccount *StringMatcher
+}
+
+type operation struct {
+	headers []header
+	hosts   []*StringMatcher
+	methods []string
+	paths   []*StringMatcher
+}
+
+type header struct {
+	name  string
+	value *StringMatcher
+}
+
+// Compile validates and compiles the given policy.
+func Compile(p *networkingv1.GCPAuthzPolicy) (*Policy, error) {
+	if errs := ValidateSpec(&p.Spec, field.NewPath("spec")); len(errs) > 0 {
+		return nil, fmt.Errorf("GCPAuthzPolicy %s/%s is invalid: %w", p.Namespace, p.Name, errs.ToAggregate())
+	}
+	cp := &Policy{
+		Name:             types.NamespacedName{Namespace: p.Namespace, Name: p.Name},
+		Action:           networkingv1.Allow,
+		EnforcementLevel: p.Spec.EnforcementLevel,
+		EnforcementMode:  networkingv1.Enforce,
+	}
+	if p.Spec.Action != nil {
+		cp.Action = *p.Spec.Action
+	}
+	if p.Spec.EnforcementMode != nil {
+		cp.EnforcementMode = *p.Spec.EnforcementMode
+	}
+	if p.Spec.CustomProviders != nil {
+		cp.Extensions = p.Spec.CustomProviders.ExtensionRefs
+	}
+	// ValidateSpec compiled every matcher already, so errors are not
+	// expected below.
+	for _, r := range p.Spec.Rules {
+		cr := rule{when: r.When}
+		if r.From != nil {
+			cr.sources = compileSources(r.From.Sources)
+			cr.notSources = compileSources(r.From.NotSources)
+		}
+		if r.To != nil {
+			cr.operations = compileOperations(r.To.Operations)
+			cr.notOperations = compileOperations(r.To.NotOperations)
+		}
+		cp.rules = append(cp.rules, cr)
+	}
+	return cp, nil
+}
+
+func mustCompile(m *StringMatcher, err error) *StringMatcher {
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func compileSources(in []networkingv1.GCPAuthzPolicySource) []source {
+	var out []source
+	for _, s := range in {
+		var cs source
+		for _, p := range s.Principals {
+			selector := networkingv1.ClientCertURISAN
+			if p.PrincipalSelector != nil {
+				selector = *p.PrincipalSelector
+			}
+			cs.principals = append(cs.principals, principal{selector: selector, value: mustCompile(CompileStringMatch(p.Principal))})
+		}
+		for _, r := range s.Resources {
+			cr := resource{tagValueIDs: r.TagValueIDSet}
+			if r.IAMServiceAccount != nil {
+				cr.serviceAccount = mustCompile(CompileStringMatch(*r.IAMServiceAccount))
+			}
+			cs.resources = append(cs.resources, cr)
+		}
+		cs.ipBlocks = mustParseCIDRs(s.IPBlocks)
+		cs.notIPBlocks = mustParseCIDRs(s.NotIPBlocks)
+		if s.RequestAuth != nil {
+			cs.auth = compileRequestAuth(s.RequestAuth)
+		}
+		out = append(out, cs)
+	}
+	return out
+}
+
+func mustParseCIDRs(in []networkingv1.CIDR) []netip.Prefix {
+	var out []netip.Prefix
+	for _, c := range in {
+		p, err := ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func compileMatchers(in []networkingv1.StringMatchCriteria) []*StringMatcher {
+	var out []*StringMatcher
+	for _, m := range in {
+		out = append(out, mustCompile(CompileStringMatch(m)))
+	}
+	return out
+}
+
+func compileRequestAuth(in *networkingv1.GCPAuthzPolicyRequestAuth) *requestAuth {
+	a := &requestAuth{
+		issuers:    compileMatchers(in.Issuers),
+		audiences:  compileMatchers(in.Audiences),
+		principals: compileMatchers(in.RequestPrincipals),
+	}
+	for _, c := range in.Claims {
+		a.claims = append(a.claims, claim{name: c.Name, values: compileMatchers(c.Values)})
+	}
+	return a
+}
+
+// ParseCIDR parses the given IP range. A single address is parsed as a
+// range of one address. Ranges with host bits set are rejected, as they are
+// usually a mistake.
+func ParseCIDR(c networkingv1.CIDR) (netip.Prefix, error) {
+	s := string(c)
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		if addr.Zone() != "" {
+			return netip.Prefix{}, fmt.Errorf("IP address %q cannot have a zone", s)
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	p, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if p.Masked() != p {
+		return netip.Prefix{}, fmt.Errorf("CIDR %q has host bits set, use %s", s, p.Masked())
+	}
+	return p, nil
+}
+
+func compileOperations(in []networkingv1.GCPAuthzPolicyOperation) []operation {
+	var out []operation
+	for _, o := range in {
+		var co operation
+		for _, h := range o.Headers {
+			co.headers = append(co.headers, header{name: h.Name, value: mustCompile(CompileHeaderMatch(h))})
+		}
+		for _, h := range o.Hosts {
+			co.hosts = append(co.hosts, mustCompile(CompileStringMatch(h)))
+		}
+		for _, m := range o.Methods {
+			co.methods = append(co.methods, string(m))
+		}
+		for _, p := range o.Paths {
+			co.paths = append(co.paths, mustCompile(CompileStringMatch(p)))
+		}
+		out = append(out, co)
+	}
+	return out
+}
+
+// Match reports whether the policy matches the given request, and returns the
+// index of the first rule that matches it. A policy without rules matches
+// every request, with a rule index of -1.
+func (p *Policy) Match(req *Request, cond ConditionFunc) (int, bool, error) {
+	if len(p.rules) == 0 {
+		return -1, true, nil
+	}
+	for i, r := range p.rules {
+		ok, err := r.match(req, cond)
+		if err != nil {
+			return -1, false, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if ok {
+			return i, true, nil
+		}
+	}
+	return -1, false, nil
+}
+
+func (r *rule) match(req *Request, cond ConditionFunc) (bool, error) {
+	if len(r.sources) > 0 && !slices.ContainsFunc(r.sources, func(s source) bool { return s.match(req) }) {
+		return false, nil
+	}
+	if slices.ContainsFunc(r.notSources, func(s source) bool { return s.match(req) }) {
+		return false, nil
+	}
+	if len(r.operations) > 0 && !slices.ContainsFunc(r.operations, func(o operation) bool { return o.match(req) }) {
+		return false, nil
+	}
+	if slices.ContainsFunc(r.notOperations, func(o operation) bool { return o.match(req) }) {
+		return false, nil
+	}
+	if r.when == nil {
+		return true, nil
+	}
+	if cond == nil {
+		return false, ErrConditionUnsupported
+	}
+	return cond(*r.when, req)
+}
+
+func (s *source) match(req *Request) bool {
+	if len(s.principals) > 0 && !slices.ContainsFunc(s.principals, func(p principal) bool { return p.match(&req.Peer) }) {
+		return false
+	}
+	if len(s.resources) > 0 && !slices.ContainsFunc(s.resources, func(r resource) bool { return r.match(&req.Peer) }) {
+		return false
+	}
+	if len(s.ipBlocks) > 0 || len(s.notIPBlocks) > 0 {
+		// A client with an unknown address matches neither.
+		addr := req.Peer.Address.Unmap()
+		if !addr.IsValid() {
+			return false
+		}
+		contains := func(p netip.Prefix) bool { return p.Contains(addr) }
+		if len(s.ipBlocks) > 0 && !slices.ContainsFunc(s.ipBlocks, contains) {
+			return false
+		}
+		if slices.ContainsFunc(s.notIPBlocks, contains) {
+			return false
+		}
+	}
+	return s.auth == nil || s.auth.match(req.Claims)
+}
+
+func (a *requestAuth) match(claims map[string]any) bool {
+	if claims == nil {
+		return false
+	}
+	anyMatch := func(matchers []*StringMatcher, values []string) bool {
+		return len(matchers) == 0 || slices.ContainsFunc(matchers, func(m *StringMatcher) bool { return m.MatchAny(values) })
+	}
+	if !anyMatch(a.issuers, ClaimValues(claims, "iss")) || !anyMatch(a.audiences, ClaimValues(claims, "aud")) {
+		return false
+	}
+	if len(a.principals) > 0 {
+		p, ok := RequestPrincipal(claims)
+		if !ok || !anyMatch(a.principals, []string{p}) {
+			return false
+		}
+	}
+	for _, c := range a.claims {
+		values := ClaimValues(claims, c.name)
+		if len(values) == 0 || !anyMatch(c.values, values) {
+			return false
+		}
+	}
+	return true
+}
+
+// RequestPrincipal returns the request principal of the given JWT claims,
+// which is the issuer and subject joined by a slash.
+func RequestPrincipal(claims map[string]any) (string, bool) {
+	iss, ok1 := claims["iss"].(string)
+	sub, ok2 := claims["sub"].(string)
+	if !ok1 || !ok2 || iss == "" || sub == "" {
+		return "", false
+	}
+	return iss + "/" + sub, true
+}
+
+// ClaimValues returns the values of the given claim as strings. Nested
+// claims are separated by dots. List claims return their elements, and
+// numbers and booleans are returned in their JSON form. Objects and missing
+// claims return no values.
+func ClaimValues(claims map[string]any, name string) []string {
+	var v any = claims
+	for _, part := range strings.Split(name, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if v, ok = m[part]; !ok {
+			return nil
+		}
+	}
+	if list, ok := v.([]any); ok {
+		var out []string
+		for _, e := range list {
+			if s, ok := claimString(e); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	if s, ok := claimString(v); ok {
+		return []string{s}
+	}
+	return nil
+}
+
+func claimString(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool, float64, json.Number:
+		b, _ := json.Marshal(v)
+		return string(b), true
+	}
+	return "", false
+}
+
+func (p *principal) match(peer *Peer) bool {
+	switch p.selector {
+	case networkingv1.ClientCertDNSNameSAN:
+		return p.value.MatchAny(peer.DNSSANs)
+	case networkingv1.ClientCertCommonName:
+		return peer.CommonName != "" && p.value.Match(peer.CommonName)
+	default:
+		return p.value.MatchAny(peer.URISANs)
+	}
+}
+
+func (r *resource) match(peer *Peer) bool {
+	for _, id := range r.tagValueIDs {
+		if !slices.Contains(peer.TagValueIDs, id) {
+			return false
+		}
+	}
+	return r.serviceAccount == nil || (peer.IAMServiceAccount != "" && r.serviceAccount.Match(peer.IAMServiceAccount))
+}
+
+func (o *operation) match(req *Request) bool {
+	for _, h := range o.headers {
+		values := req.Headers.Values(h.name)
+		if len(values) == 0 || !h.value.MatchAny(values) {
+			return false
+		}
+	}
+	if len(o.hosts) > 0 && !slices.ContainsFunc(o.hosts, func(m *StringMatcher) bool { return m.Match(req.Host) }) {
+		return false
+	}
+	if len(o.methods) > 0 && !slices.Contains(o.methods, req.Method) {
+		return false
+	}
+	path, _, _ := strings.Cut(req.Path, "?")
+	path, _, _ = strings.Cut(path, "#")
+	if len(o.paths) > 0 && !slices.ContainsFunc(o.paths, func(m *StringMatcher) bool { return m.Match(path) }) {
+		return false
+	}
+	return true
+}
+
+// Evaluator makes authorization decisions for the policies that apply to a
+// workload or Gateway.
+type Evaluator struct {
+	Policies []*Policy
+	// Condition evaluates When conditions. If nil, evaluating a rule with a
+	// When condition fails with ErrConditionUnsupported.
+	Condition ConditionFunc
+}
+
+// Evaluate decides whether the given request is allowed. Policies are
+// evaluated in the order the load balancer evaluates them: CUSTOM policies
+// first, then DENY policies and finally ALLOW policies. A request that
+// matches no ALLOW policy is denied if there is any ALLOW or DENY_BY_DEFAULT
+// policy, and allowed otherwise.
+//
+// Policies in DryRun mode do not affect the decision. If there are any, the
+// decision they would lead to is returned as the DryRun decision.
+func (e *Evaluator) Evaluate(req *Request) (Decision, error) {
+	var enforced []*Policy
+	for _, p := range e.Policies {
+		if p.EnforcementMode != networkingv1.DryRun {
+			enforced = append(enforced, p)
+		}
+	}
+	d, err := e.evaluate(enforced, req)
+	if err != nil || len(enforced) == len(e.Policies) {
+		return d, err
+	}
+	dryRun, err := e.evaluate(e.Policies, req)
+	if err != nil {
+		return Decision{}, err
+	}
+	d.DryRun = &dryRun
+	return d, nil
+}
+
+func (e *Evaluator) evaluate(policies []*Policy, req *Request) (Decision, error) {
+	for _, action := range []networkingv1.GCPAuthzPolicyAction{networkingv1.Custom, networkingv1.Deny, networkingv1.Allow} {
+		for _, p := range policies {
+			if p.Action != action {
+				continue
+			}
+			i, ok, err := p.Match(req, e.Condition)
+			if err != nil {
+				return Decision{}, fmt.Errorf("GCPAuthzPolicy %s: %w", p.Name, err)
+			}
+			if !ok {
+				continue
+			}
+			d := Decision{Policy: p.Name, Rule: i, Reason: fmt.Sprintf("matched rule %d of %s policy %s", i, action, p.Name)}
+			if i < 0 {
+				d.Reason = fmt.Sprintf("matched %s policy %s, which has no rules", action, p.Name)
+			}
+			switch action {
+			case networkingv1.Custom:
+				d.Result, d.Extensions = Delegated, p.Extensions
+			case networkingv1.Deny:
+				d.Result = Denied
+			default:
+				d.Result = Allowed
+			}
+			return d, nil
+		}
+	}
+
+	for _, p := range policies {
+		switch p.Action {
+		case networkingv1.Allow:
+			return Decision{Result: Denied, Policy: p.Name, Rule: -1, Reason: fmt.Sprintf("matched no rule of ALLOW policy %s", p.Name)}, nil
+		case networkingv1.DenyByDefault:
+			return Decision{Result: Denied, Policy: p.Name, Rule: -1, Reason: fmt.Sprintf("denied by DENY_BY_DEFAULT policy %s", p.Name)}, nil
+		}
+	}
+	return Decision{Result: Allowed, Rule: -1, Reason: "no policy applies"}, nil
+}
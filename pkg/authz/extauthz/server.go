@@ -0,0 +1,158 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package extauthz implements a reference Envoy ext_authz gRPC server, to
+// exercise GCPAuthzExtensions and CUSTOM GCPAuthzPolicies locally and in
+// tests.
+//
+// The server allows a request if its decision header, x-ext-authz by
+// default, has the value "allow", and denies it with status 403 otherwise.
+// Allowed requests are sent to the backend with the x-ext-authz-check-result
+// header set to "allowed"; denied responses carry the same header set to
+// "denied". Both also carry x-ext-authz-check-received, which describes the
+// check request as the server received it, so that the forwardHeaders and
+// includeRequestBody settings of the extension can be verified.
+package extauthz
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// Headers used by the server.
+const (
+	// DefaultDecisionHeader is the default request header that carries the
+	// decision.
+	DefaultDecisionHeader = "x-ext-authz"
+	// AllowValue is the value of the decision header that allows a request.
+	AllowValue = "allow"
+	// ResultHeader is set to "allowed" on the upstream request, or to
+	// "denied" on the response to the client.
+	ResultHeader = "x-ext-authz-check-result"
+	// ReceivedHeader describes the check request the server received.
+	ReceivedHeader = "x-ext-authz-check-received"
+)
+
+// Server is a reference ext_authz gRPC server.
+type Server struct {
+	authv3.UnimplementedAuthorizationServer
+
+	// DecisionHeader is the request header that carries the decision. If
+	// empty, DefaultDecisionHeader is used.
+	DecisionHeader string
+	// Logf, if set, is called with a line for every check.
+	Logf func(format string, args ...any)
+}
+
+// Register registers the server with the given gRPC server.
+func (s *Server) Register(srv *grpc.Server) {
+	authv3.RegisterAuthorizationServer(srv, s)
+}
+
+// Check implements the ext_authz Authorization service.
+func (s *Server) Check(_ context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	decisionHeader := s.DecisionHeader
+	if decisionHeader == "" {
+		decisionHeader = DefaultDecisionHeader
+	}
+	decisionHeader = strings.ToLower(decisionHeader)
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+	received := Describe(req)
+	allowed := header(httpReq, decisionHeader) == AllowValue
+	if s.Logf != nil {
+		s.Logf("allowed=%t %s", allowed, received)
+	}
+
+	if allowed {
+		return &authv3.CheckResponse{
+			Status: &status.Status{Code: int32(codes.OK)},
+			HttpResponse: &authv3.CheckResponse_OkResponse{OkResponse: &authv3.OkHttpResponse{
+				Headers: []*corev3.HeaderValueOption{
+					headerValue(ResultHeader, "allowed"),
+					headerValue(ReceivedHeader, received),
+				},
+			}},
+		}, nil
+	}
+	return &authv3.CheckResponse{
+		Status: &status.Status{Code: int32(codes.PermissionDenied)},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{DeniedResponse: &authv3.DeniedHttpResponse{
+			Status: &typev3.HttpStatus{Code: typev3.StatusCode_Forbidden},
+			Headers: []*corev3.HeaderValueOption{
+				headerValue(ResultHeader, "denied"),
+				headerValue(ReceivedHeader, received),
+			},
+			Body: fmt.Sprintf("denied by ext_authz: header `%s: %s` not found in the request\n", decisionHeader, AllowValue),
+		}},
+	}, nil
+}
+
+// Describe returns a single line description of a check request: the
+// method, host and path, the sorted names of the headers and the size of
+// the body, if any.
+func Describe(req *authv3.CheckRequest) string {
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+	var names []string
+	for name := range httpReq.GetHeaders() {
+		names = append(names, name)
+	}
+	for _, h := range httpReq.GetHeaderMap().GetHeaders() {
+		names = append(names, h.GetKey())
+	}
+	sort.Strings(names)
+	s := fmt.Sprintf("%s %s%s headers=%s", httpReq.GetMethod(), httpReq.GetHost(), httpReq.GetPath(), strings.Join(names, ","))
+	if body := len(httpReq.GetBody()) + len(httpReq.GetRawBody()); body > 0 {
+		s += fmt.Sprintf(" body=%d", body)
+	}
+	if source := req.GetAttributes().GetSource().GetPrincipal(); source != "" {
+		s += " source=" + source
+	}
+	return s
+}
+
+// header returns the value of the given lowercase header, which the load
+// balancer sends either in the headers map or in the header map of the
+// request.
+func header(req *authv3.AttributeContext_HttpRequest, name string) string {
+	if v, ok := req.GetHeaders()[name]; ok {
+		return v
+	}
+	for _, h := range req.GetHeaderMap().GetHeaders() {
+		if strings.ToLower(h.GetKey()) == name {
+			if v := h.GetValue(); v != "" {
+				return v
+			}
+			return string(h.GetRawValue())
+		}
+	}
+	return ""
+}
+
+func headerValue(key, value string) *corev3.HeaderValueOption {
+	return &corev3.HeaderValueOption{
+		Header:       &corev3.HeaderValue{Key: key, Value: value},
+		AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+	}
+}
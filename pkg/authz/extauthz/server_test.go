@@ -0,0 +1,134 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package extauthz
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestCheck(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	(&Server{DecisionHeader: "X-Decision"}).Register(srv)
+	go srv.Serve(l)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := authv3.NewAuthorizationClient(conn)
+
+	for _, tc := range []struct {
+		desc         string
+		http         *authv3.AttributeContext_HttpRequest
+		wantAllowed  bool
+		wantReceived string
+	}{
+		{
+			desc: "allowed",
+			http: &authv3.AttributeContext_HttpRequest{
+				Method:  "GET",
+				Host:    "api.example.com",
+				Path:    "/v1/orders",
+				Headers: map[string]string{"x-decision": "allow", "x-tenant": "acme"},
+			},
+			wantAllowed:  true,
+			wantReceived: "GET api.example.com/v1/orders headers=x-decision,x-tenant",
+		},
+		{
+			desc: "allowed by header map",
+			http: &authv3.AttributeContext_HttpRequest{
+				Method:    "POST",
+				Host:      "api.example.com",
+				Path:      "/v1/orders",
+				HeaderMap: &corev3.HeaderMap{Headers: []*corev3.HeaderValue{{Key: "x-decision", RawValue: []byte("allow")}}},
+				Body:      `{"id":1}`,
+			},
+			wantAllowed:  true,
+			wantReceived: "POST api.example.com/v1/orders headers=x-decision body=8",
+		},
+		{
+			desc: "denied",
+			http: &authv3.AttributeContext_HttpRequest{
+				Method:  "GET",
+				Host:    "api.example.com",
+				Path:    "/admin",
+				Headers: map[string]string{"x-decision": "deny"},
+			},
+			wantReceived: "GET api.example.com/admin headers=x-decision",
+		},
+		{
+			desc:         "missing header",
+			http:         &authv3.AttributeContext_HttpRequest{Method: "GET", Host: "api.example.com", Path: "/"},
+			wantReceived: "GET api.example.com/ headers=",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, err := client.Check(context.Background(), &authv3.CheckRequest{
+				Attributes: &authv3.AttributeContext{Request: &authv3.AttributeContext_Request{Http: tc.http}},
+			})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			var headers []*corev3.HeaderValueOption
+			if tc.wantAllowed {
+				if resp.GetStatus().GetCode() != int32(codes.OK) || resp.GetOkResponse() == nil {
+					t.Fatalf("Check() = %v, want OK", resp)
+				}
+				headers = resp.GetOkResponse().GetHeaders()
+			} else {
+				denied := resp.GetDeniedResponse()
+				if resp.GetStatus().GetCode() != int32(codes.PermissionDenied) || denied.GetStatus().GetCode() != typev3.StatusCode_Forbidden {
+					t.Fatalf("Check() = %v, want PermissionDenied with status 403", resp)
+				}
+				if !strings.Contains(denied.GetBody(), "x-decision: allow") {
+					t.Errorf("Check() body = %q, want the decision header", denied.GetBody())
+				}
+				headers = denied.GetHeaders()
+			}
+			got := map[string]string{}
+			for _, h := range headers {
+				got[h.GetHeader().GetKey()] = h.GetHeader().GetValue()
+			}
+			wantResult := "denied"
+			if tc.wantAllowed {
+				wantResult = "allowed"
+			}
+			if got[ResultHeader] != wantResult {
+				t.Errorf("%s = %q, want %q", ResultHeader, got[ResultHeader], wantResult)
+			}
+			if got[ReceivedHeader] != tc.wantReceived {
+				t.Errorf("%s = %q, want %q", ReceivedHeader, got[ReceivedHeader], tc.wantReceived)
+			}
+		})
+	}
+}
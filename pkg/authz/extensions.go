@@ -0,0 +1,195 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package authz
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	networkingv1listers "github.com/GoogleCloudPlatform/gke-gateway-api/pkg/client/listers/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/policyref"
+)
+
+// Kinds of the extensions that CUSTOM policies can reference.
+const (
+	AuthzExtensionKind   = "GCPAuthzExtension"
+	TrafficExtensionKind = "GCPTrafficExtension"
+	ServiceKind          = "Service"
+)
+
+// WireFormat is the protocol the load balancer uses to call an
+// authorization extension.
+type WireFormat string
+
+const (
+	// ExtProcGRPC is the Envoy ext_proc gRPC protocol, used by
+	// GCPTrafficExtensions.
+	ExtProcGRPC WireFormat = "EXT_PROC_GRPC"
+	// ExtAuthzGRPC is the Envoy ext_authz gRPC protocol, used by
+	// GCPAuthzExtensions and Services.
+	ExtAuthzGRPC WireFormat = "EXT_AUTHZ_GRPC"
+)
+
+// grpcAppProtocols are the Service port appProtocols of gRPC backends.
+var grpcAppProtocols = []string{"HTTP2", "kubernetes.io/h2c", "grpc"}
+
+// ResolvedExtension is an extension referenced by a CUSTOM policy.
+type ResolvedExtension struct {
+	// Ref is the reference in the policy.
+	Ref gatewayv1.LocalObjectReference
+	// WireFormat is the protocol used to call the extension.
+	WireFormat WireFormat
+	// AuthzExtension is the referenced GCPAuthzExtension, if any.
+	AuthzExtension *networkingv1.GCPAuthzExtension
+	// TrafficExtension is the referenced GCPTrafficExtension, if any.
+	TrafficExtension *networkingv1.GCPTrafficExtension
+	// Extensions are the extensions of TrafficExtension that are called for
+	// the RequestHeaders event, one per extension chain.
+	Extensions []*networkingv1.Extension
+	// Service is the referenced Service, or the backend Service of
+	// AuthzExtension, if any.
+	Service *corev1.Service
+	// Port is the gRPC port of Service.
+	Port *corev1.ServicePort
+}
+
+// ResolveExtensions dereferences the extensions referenced by the
+// CustomProviders of the given policy. It returns nil if the policy is not
+// a CUSTOM policy. Any failure is returned as a *policyref.RefError.
+func ResolveExtensions(p *networkingv1.GCPAuthzPolicy, authzExtensions networkingv1listers.GCPAuthzExtensionLister, trafficExtensions networkingv1listers.GCPTrafficExtensionLister, services corev1listers.ServiceLister) ([]ResolvedExtension, error) {
+	if p.Spec.Action == nil || *p.Spec.Action != networkingv1.Custom || p.Spec.CustomProviders == nil {
+		return nil, nil
+	}
+	var resolved []ResolvedExtension
+	for _, ref := range p.Spec.CustomProviders.ExtensionRefs {
+		nn := types.NamespacedName{Namespace: p.Namespace, Name: string(ref.Name)}
+		var (
+			r   ResolvedExtension
+			err error
+		)
+		switch {
+		case ref.Group == networkingv1.GroupName && ref.Kind == AuthzExtensionKind:
+			r, err = resolveAuthzExtension(nn, authzExtensions, services)
+		case ref.Group == networkingv1.GroupName && ref.Kind == TrafficExtensionKind:
+			r, err = resolveTrafficExtension(nn, trafficExtensions)
+		case ref.Group == "" && ref.Kind == ServiceKind:
+			r.Service, r.Port, err = resolveService(nn, 0, services)
+			r.WireFormat = ExtAuthzGRPC
+		default:
+			err = &policyref.RefError{Reason: networkingv1.PolicyReasonInvalidKind, Ref: nn,
+				Message: fmt.Sprintf("extension %s has unsupported kind %s in group %q", nn, ref.Kind, ref.Group)}
+		}
+		if err != nil {
+			return nil, err
+		}
+		r.Ref = ref
+		resolved = append(resolved, r)
+	}
+	return resolved, nil
+}
+
+func resolveTrafficExtension(nn types.NamespacedName, lister networkingv1listers.GCPTrafficExtensionLister) (ResolvedExtension, error) {
+	ext, err := lister.GCPTrafficExtensions(nn.Namespace).Get(nn.Name)
+	if apierrors.IsNotFound(err) {
+		return ResolvedExtension{}, &policyref.RefError{Reason: networkingv1.PolicyReasonExtensionNotFound, Ref: nn, Message: fmt.Sprintf("GCPTrafficExtension %s not found", nn)}
+	}
+	if err != nil {
+		return ResolvedExtension{}, &policyref.RefError{Reason: networkingv1.PolicyReasonExtensionNotFound, Ref: nn, Message: fmt.Sprintf("failed to get GCPTrafficExtension %s", nn), Err: err}
+	}
+
+	// The load balancer runs the first chain whose match condition matches
+	// the request, so every chain must be able to make the decision.
+	r := ResolvedExtension{WireFormat: ExtProcGRPC, TrafficExtension: ext}
+	for i := range ext.Spec.ExtensionChains {
+		chain := &ext.Spec.ExtensionChains[i]
+		j := slices.IndexFunc(chain.Extensions, func(e networkingv1.Extension) bool {
+			return len(e.SupportedEvents) == 0 || slices.Contains(e.SupportedEvents, networkingv1.EventTypeRequestHeaders)
+		})
+		if j < 0 {
+			return ResolvedExtension{}, &policyref.RefError{Reason: networkingv1.PolicyReasonUnsupportedEvent, Ref: nn,
+				Message: fmt.Sprintf("extension chain %q of GCPTrafficExtension %s has no extension for the RequestHeaders event", chain.Name, nn)}
+		}
+		e := &chain.Extensions[j]
+		if e.BackendRef != nil && e.BackendRef.Kind == "GCPWasmPlugin" {
+			return ResolvedExtension{}, &policyref.RefError{Reason: networkingv1.PolicyReasonUnsupportedWireFormat, Ref: nn,
+				Message: fmt.Sprintf("extension %q of GCPTrafficExtension %s is a GCPWasmPlugin, not a gRPC callout", e.Name, nn)}
+		}
+		r.Extensions = append(r.Extensions, e)
+	}
+	return r, nil
+}
+
+func resolveAuthzExtension(nn types.NamespacedName, lister networkingv1listers.GCPAuthzExtensionLister, services corev1listers.ServiceLister) (ResolvedExtension, error) {
+	ext, err := lister.GCPAuthzExtensions(nn.Namespace).Get(nn.Name)
+	if apierrors.IsNotFound(err) {
+		return ResolvedExtension{}, &policyref.RefError{Reason: networkingv1.PolicyReasonExtensionNotFound, Ref: nn, Message: fmt.Sprintf("GCPAuthzExtension %s not found", nn)}
+	}
+	if err != nil {
+		return ResolvedExtension{}, &policyref.RefError{Reason: networkingv1.PolicyReasonExtensionNotFound, Ref: nn, Message: fmt.Sprintf("failed to get GCPAuthzExtension %s", nn), Err: err}
+	}
+	svc, port, err := ResolveAuthzExtensionBackend(ext, services)
+	if err != nil {
+		return ResolvedExtension{}, err
+	}
+	return ResolvedExtension{WireFormat: ExtAuthzGRPC, AuthzExtension: ext, Service: svc, Port: port}, nil
+}
+
+// ResolveAuthzExtensionBackend looks up the backend Service of the given
+// GCPAuthzExtension and its gRPC port. It returns nil if the backend is a
+// ServiceImport, which is resolved by the multi-cluster controller. Any
+// failure is returned as a *policyref.RefError.
+func ResolveAuthzExtensionBackend(ext *networkingv1.GCPAuthzExtension, services corev1listers.ServiceLister) (*corev1.Service, *corev1.ServicePort, error) {
+	ref := ext.Spec.BackendRef
+	if ref.Kind != ServiceKind {
+		return nil, nil, nil
+	}
+	return resolveService(types.NamespacedName{Namespace: ext.Namespace, Name: string(ref.Name)}, int32(ref.Port), services)
+}
+
+// resolveService looks up the given Service and its gRPC port, which must
+// be the given port unless it is 0.
+func resolveService(nn types.NamespacedName, port int32, lister corev1listers.ServiceLister) (*corev1.Service, *corev1.ServicePort, error) {
+	svc, err := lister.Services(nn.Namespace).Get(nn.Name)
+	if apierrors.IsNotFound(err) {
+		return nil, nil, &policyref.RefError{Reason: networkingv1.PolicyReasonExtensionNotFound, Ref: nn, Message: fmt.Sprintf("Service %s not found", nn)}
+	}
+	if err != nil {
+		return nil, nil, &policyref.RefError{Reason: networkingv1.PolicyReasonExtensionNotFound, Ref: nn, Message: fmt.Sprintf("failed to get Service %s", nn), Err: err}
+	}
+	for i := range svc.Spec.Ports {
+		p := &svc.Spec.Ports[i]
+		if port != 0 && p.Port != port {
+			continue
+		}
+		if p.AppProtocol != nil && slices.Contains(grpcAppProtocols, *p.AppProtocol) {
+			return svc, p, nil
+		}
+	}
+	if port != 0 {
+		return nil, nil, &policyref.RefError{Reason: networkingv1.PolicyReasonUnsupportedWireFormat, Ref: nn,
+			Message: fmt.Sprintf("port %d of Service %s does not exist or does not have appProtocol %v for the ext_authz gRPC protocol", port, nn, grpcAppProtocols)}
+	}
+	return nil, nil, &policyref.RefError{Reason: networkingv1.PolicyReasonUnsupportedWireFormat, Ref: nn,
+		Message: fmt.Sprintf("Service %s has no port with appProtocol %v for the ext_authz gRPC protocol", nn, grpcAppProtocols)}
+}
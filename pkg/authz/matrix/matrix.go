@@ -0,0 +1,572 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package matrix builds an access matrix from the GCPAuthzPolicies, Pods and
+// ServiceAccounts of a cluster: for every principal and every workload,
+// port and path prefix, whether the policies allow or deny the principal's
+// requests.
+//
+// Principals are the SPIFFE IDs of the ServiceAccounts, the principals that
+// the policies match exactly, and unauthenticated clients. Workloads are the
+// Pods, grouped by their controller, and the Gateways targeted by the
+// policies. A cell is UNKNOWN if the decision depends on attributes the
+// matrix does not model, such as methods, headers, client addresses, JWT
+// claims or conditions, or on a CUSTOM extension.
+package matrix
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz"
+)
+
+// DefaultTrustDomain is the default trust domain of the SPIFFE IDs of
+// ServiceAccounts.
+const DefaultTrustDomain = "cluster.local"
+
+// Decision is the content of a cell of the matrix.
+type Decision string
+
+const (
+	Allow   Decision = "ALLOW"
+	Deny    Decision = "DENY"
+	Unknown Decision = "UNKNOWN"
+)
+
+// Unauthenticated is the value of the principal of clients without a
+// certificate.
+const Unauthenticated = "(unauthenticated)"
+
+// Options configure how the matrix is built.
+type Options struct {
+	// TrustDomain is the trust domain of the SPIFFE IDs of ServiceAccounts,
+	// spiffe://<trust domain>/ns/<namespace>/sa/<name>. For GKE workload
+	// identity it is <project>.svc.id.goog. Defaults to DefaultTrustDomain.
+	TrustDomain string
+	// Namespace restricts the workloads to a namespace, if set.
+	Namespace string
+}
+
+// Principal is a row of the matrix.
+type Principal struct {
+	// Selector is the certificate attribute that carries Value. It is empty
+	// for unauthenticated clients.
+	Selector networkingv1.PrincipalSelector `json:"selector,omitempty"`
+	Value    string                         `json:"value"`
+	// ServiceAccount is the namespace/name of the ServiceAccount with this
+	// SPIFFE ID, if any.
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+func (p Principal) String() string {
+	if p.ServiceAccount != "" {
+		return p.ServiceAccount
+	}
+	return p.Value
+}
+
+// Column is a column of the matrix: the requests to a port of a workload
+// whose path starts with PathPrefix.
+type Column struct {
+	Namespace string `json:"namespace"`
+	// Kind is Pod or Gateway.
+	Kind string `json:"kind"`
+	// Name is the name of the controller of the Pods, or of the Gateway.
+	Name string `json:"name"`
+	// Port is the container port, or 0 if unknown.
+	Port       int32  `json:"port,omitempty"`
+	PathPrefix string `json:"pathPrefix"`
+}
+
+func (c Column) String() string {
+	port := "*"
+	if c.Port != 0 {
+		port = strconv.Itoa(int(c.Port))
+	}
+	kind := ""
+	if c.Kind == gatewayKind {
+		kind = "gateway:"
+	}
+	return fmt.Sprintf("%s%s/%s:%s%s", kind, c.Namespace, c.Name, port, c.PathPrefix)
+}
+
+// Cell is the decision for a principal and a column.
+type Cell struct {
+	Decision Decision `json:"decision"`
+	// Policy is the namespace/name of the policy that made the decision, if
+	// any.
+	Policy string `json:"policy,omitempty"`
+}
+
+// Matrix is an access matrix.
+type Matrix struct {
+	Principals []Principal `json:"principals"`
+	Columns    []Column    `json:"columns"`
+	// Cells holds a row per principal, with a cell per column.
+	Cells [][]Cell `json:"cells"`
+}
+
+const (
+	podKind     = "Pod"
+	gatewayKind = "Gateway"
+)
+
+// workload is a set of Pods with the same controller, or a Gateway.
+type workload struct {
+	namespace, kind, name string
+	labels                labels.Set
+	ports                 []int32
+	policies              []*compiledPolicy
+}
+
+// Build builds the access matrix of the given snapshot. Policies in DryRun
+// mode are ignored.
+func Build(s *Snapshot, opts Options) (*Matrix, error) {
+	if opts.TrustDomain == "" {
+		opts.TrustDomain = DefaultTrustDomain
+	}
+	var policies []*compiledPolicy
+	for _, p := range s.Policies {
+		if p.Spec.EnforcementMode != nil && *p.Spec.EnforcementMode == networkingv1.DryRun {
+			continue
+		}
+		cp, err := compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s/%s: %w", p.Namespace, p.Name, err)
+		}
+		policies = append(policies, cp)
+	}
+
+	m := &Matrix{Principals: principals(s, policies, opts.TrustDomain)}
+	var columnPolicies [][]*compiledPolicy
+	for _, w := range workloads(s, policies, opts.Namespace) {
+		ports := w.ports
+		if len(ports) == 0 {
+			ports = []int32{0}
+		}
+		for _, port := range ports {
+			for _, prefix := range pathPrefixes(w.policies) {
+				m.Columns = append(m.Columns, Column{Namespace: w.namespace, Kind: w.kind, Name: w.name, Port: port, PathPrefix: prefix})
+				columnPolicies = append(columnPolicies, w.policies)
+			}
+		}
+	}
+	for _, p := range m.Principals {
+		row := make([]Cell, 0, len(m.Columns))
+		for i, c := range m.Columns {
+			row = append(row, decide(columnPolicies[i], p, c.PathPrefix))
+		}
+		m.Cells = append(m.Cells, row)
+	}
+	return m, nil
+}
+
+// principals returns the rows of the matrix: the ServiceAccounts of the
+// snapshot and of its Pods, the principals matched exactly by the policies
+// and unauthenticated clients.
+func principals(s *Snapshot, policies []*compiledPolicy, trustDomain string) []Principal {
+	seen := map[Principal]bool{}
+	var out []Principal
+	add := func(p Principal) {
+		key := Principal{Selector: p.Selector, Value: p.Value}
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, p)
+		}
+	}
+	addServiceAccount := func(namespace, name string) {
+		add(Principal{
+			Selector:       networkingv1.ClientCertURISAN,
+			Value:          fmt.Sprintf("spiffe://%s/ns/%s/sa/%s", trustDomain, namespace, name),
+			ServiceAccount: namespace + "/" + name,
+		})
+	}
+	for _, sa := range s.ServiceAccounts {
+		addServiceAccount(sa.Namespace, sa.Name)
+	}
+	for _, pod := range s.Pods {
+		name := pod.Spec.ServiceAccountName
+		if name == "" {
+			name = "default"
+		}
+		addServiceAccount(pod.Namespace, name)
+	}
+	for _, p := range policies {
+		for _, r := range p.source.Spec.Rules {
+			if r.From == nil {
+				continue
+			}
+			for _, src := range append(slices.Clone(r.From.Sources), r.From.NotSources...) {
+				for _, pr := range src.Principals {
+					if pr.Principal.Type != "" && pr.Principal.Type != networkingv1.StringExact {
+						continue
+					}
+					add(Principal{Selector: selector(pr), Value: pr.Principal.Value})
+				}
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Selector != out[j].Selector {
+			return out[i].Selector < out[j].Selector
+		}
+		return out[i].Value < out[j].Value
+	})
+	return append(out, Principal{Value: Unauthenticated})
+}
+
+// workloads returns the columns of the matrix, with the policies that apply
+// to them, sorted by namespace, kind and name.
+func workloads(s *Snapshot, policies []*compiledPolicy, namespace string) []*workload {
+	byKey := map[string]*workload{}
+	for _, pod := range s.Pods {
+		if namespace != "" && pod.Namespace != namespace {
+			continue
+		}
+		name := controllerName(pod)
+		key := podKind + "/" + pod.Namespace + "/" + name
+		w := byKey[key]
+		if w == nil {
+			w = &workload{namespace: pod.Namespace, kind: podKind, name: name, labels: labels.Set(pod.Labels)}
+			byKey[key] = w
+		}
+		for _, c := range pod.Spec.Containers {
+			for _, p := range c.Ports {
+				if (p.Protocol == "" || p.Protocol == corev1.ProtocolTCP) && !slices.Contains(w.ports, p.ContainerPort) {
+					w.ports = append(w.ports, p.ContainerPort)
+				}
+			}
+		}
+	}
+	for _, p := range policies {
+		if namespace != "" && p.source.Namespace != namespace {
+			continue
+		}
+		for _, t := range p.source.Spec.TargetRefs {
+			if t.Kind != gatewayKind {
+				continue
+			}
+			key := gatewayKind + "/" + p.source.Namespace + "/" + string(t.Name)
+			if byKey[key] == nil {
+				byKey[key] = &workload{namespace: p.source.Namespace, kind: gatewayKind, name: string(t.Name)}
+			}
+		}
+	}
+
+	var out []*workload
+	for _, key := range slices.Sorted(maps.Keys(byKey)) {
+		w := byKey[key]
+		slices.Sort(w.ports)
+		for _, p := range policies {
+			if p.appliesTo(w) {
+				w.policies = append(w.policies, p)
+			}
+		}
+		out = append(out, w)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].namespace < out[j].namespace })
+	return out
+}
+
+// controllerName returns the name of the controller of a Pod, without the
+// pod-template-hash of ReplicaSets of Deployments, or the name of the Pod.
+func controllerName(pod *corev1.Pod) string {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Controller == nil || !*ref.Controller {
+			continue
+		}
+		if hash := pod.Labels["pod-template-hash"]; ref.Kind == "ReplicaSet" && hash != "" {
+			return strings.TrimSuffix(ref.Name, "-"+hash)
+		}
+		return ref.Name
+	}
+	return pod.Name
+}
+
+// pathPrefixes returns the path prefixes of the columns of a workload: "/"
+// and the Exact and Prefix paths that the policies match.
+func pathPrefixes(policies []*compiledPolicy) []string {
+	prefixes := []string{"/"}
+	for _, p := range policies {
+		for _, r := range p.source.Spec.Rules {
+			if r.To == nil {
+				continue
+			}
+			for _, op := range append(slices.Clone(r.To.Operations), r.To.NotOperations...) {
+				for _, m := range op.Paths {
+					if m.Type == "" || m.Type == networkingv1.StringExact || m.Type == networkingv1.StringPrefix {
+						prefixes = append(prefixes, m.Value)
+					}
+				}
+			}
+		}
+	}
+	slices.Sort(prefixes)
+	return slices.Compact(prefixes)
+}
+
+func selector(p networkingv1.Principal) networkingv1.PrincipalSelector {
+	if p.PrincipalSelector != nil {
+		return *p.PrincipalSelector
+	}
+	return networkingv1.ClientCertURISAN
+}
+
+// WriteCSV writes the matrix as CSV, with a header row of column labels
+// such as `app/frontend:8080/api` and a row per principal.
+func WriteCSV(w io.Writer, m *Matrix) error {
+	cw := csv.NewWriter(w)
+	header := []string{"principal"}
+	for _, c := range m.Columns {
+		header = append(header, c.String())
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i, p := range m.Principals {
+		row := []string{p.String()}
+		for _, c := range m.Cells[i] {
+			row = append(row, string(c.Decision))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// compiledPolicy is a policy with its principal matchers compiled.
+type compiledPolicy struct {
+	name       string
+	source     *networkingv1.GCPAuthzPolicy
+	action     networkingv1.GCPAuthzPolicyAction
+	principals map[networkingv1.StringMatchCriteria]*authz.StringMatcher
+}
+
+func compile(p *networkingv1.GCPAuthzPolicy) (*compiledPolicy, error) {
+	cp := &compiledPolicy{
+		name:       p.Namespace + "/" + p.Name,
+		source:     p,
+		action:     networkingv1.Allow,
+		principals: map[networkingv1.StringMatchCriteria]*authz.StringMatcher{},
+	}
+	if p.Spec.Action != nil {
+		cp.action = *p.Spec.Action
+	}
+	for i := range p.Spec.Rules {
+		r := &p.Spec.Rules[i]
+		if r.From == nil {
+			continue
+		}
+		for _, sources := range [][]networkingv1.GCPAuthzPolicySource{r.From.Sources, r.From.NotSources} {
+			for j := range sources {
+				for k := range sources[j].Principals {
+					c := sources[j].Principals[k].Principal
+					m, err := authz.CompileStringMatch(c)
+					if err != nil {
+						return nil, err
+					}
+					cp.principals[c] = m
+				}
+			}
+		}
+	}
+	return cp, nil
+}
+
+// appliesTo reports whether the policy targets the given workload.
+func (p *compiledPolicy) appliesTo(w *workload) bool {
+	if p.source.Namespace != w.namespace {
+		return false
+	}
+	for _, t := range p.source.Spec.TargetRefs {
+		switch {
+		case w.kind == gatewayKind && t.Kind == gatewayKind && string(t.Name) == w.name:
+			return true
+		case w.kind == podKind && t.Kind == podKind && t.Selector != nil:
+			if labels.SelectorFromSet(t.Selector.MatchLabels).Matches(w.labels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// match is the result of matching a policy, a rule or one of their clauses
+// against all the requests of a cell: they match none, some or all of them.
+type match int
+
+const (
+	no match = iota
+	maybe
+	yes
+)
+
+func (m match) not() match { return yes - m }
+
+// all returns the result of clauses that must all match.
+func all(ms ...match) match { return slices.Min(append(ms, yes)) }
+
+// anyOf returns the result of matching any of n items, where n = 0 matches
+// everything.
+func anyOf[T any](items []T, f func(T) match) match {
+	if len(items) == 0 {
+		return yes
+	}
+	m := no
+	for _, it := range items {
+		m = max(m, f(it))
+	}
+	return m
+}
+
+// noneOf returns the result of matching none of the items.
+func noneOf[T any](items []T, f func(T) match) match {
+	m := no
+	for _, it := range items {
+		m = max(m, f(it))
+	}
+	return m.not()
+}
+
+func unless(cond bool) match {
+	if cond {
+		return maybe
+	}
+	return yes
+}
+
+// decide returns the decision of the given policies for the requests of a
+// principal whose path starts with prefix. Policies are evaluated in the
+// order of the load balancer, CUSTOM, DENY and then ALLOW, collecting the
+// results of the policies that match some of the requests until a policy
+// matches all of them. The decision is only known if all the results agree.
+func decide(policies []*compiledPolicy, p Principal, prefix string) Cell {
+	results := map[Decision]bool{}
+	for _, action := range []networkingv1.GCPAuthzPolicyAction{networkingv1.Custom, networkingv1.Deny, networkingv1.Allow} {
+		for _, cp := range policies {
+			if cp.action != action {
+				continue
+			}
+			m := anyOf(cp.source.Spec.Rules, func(r networkingv1.GCPAuthPolicyRule) match { return cp.matchRule(&r, p, prefix) })
+			if m == no {
+				continue
+			}
+			result := Allow
+			switch action {
+			case networkingv1.Custom:
+				result = Unknown
+			case networkingv1.Deny:
+				result = Deny
+			}
+			results[result] = true
+			if m == yes {
+				return resolve(results, Cell{Decision: result, Policy: cp.name})
+			}
+		}
+	}
+	cell := Cell{Decision: Allow}
+	for _, cp := range policies {
+		if cp.action == networkingv1.Allow || cp.action == networkingv1.DenyByDefault {
+			cell = Cell{Decision: Deny, Policy: cp.name}
+			break
+		}
+	}
+	results[cell.Decision] = true
+	return resolve(results, cell)
+}
+
+func resolve(results map[Decision]bool, cell Cell) Cell {
+	if len(results) > 1 {
+		return Cell{Decision: Unknown}
+	}
+	return cell
+}
+
+func (cp *compiledPolicy) matchRule(r *networkingv1.GCPAuthPolicyRule, p Principal, prefix string) match {
+	m := unless(r.When != nil)
+	if r.From != nil {
+		matchSource := func(s networkingv1.GCPAuthzPolicySource) match { return cp.matchSource(&s, p) }
+		m = all(m, anyOf(r.From.Sources, matchSource), noneOf(r.From.NotSources, matchSource))
+	}
+	if r.To != nil {
+		matchOperation := func(o networkingv1.GCPAuthzPolicyOperation) match { return matchOperation(&o, prefix) }
+		m = all(m, anyOf(r.To.Operations, matchOperation), noneOf(r.To.NotOperations, matchOperation))
+	}
+	return m
+}
+
+// matchSource matches a source against a principal. Resources, IP blocks
+// and request authentication are not modelled and match some requests.
+func (cp *compiledPolicy) matchSource(s *networkingv1.GCPAuthzPolicySource, p Principal) match {
+	return all(
+		anyOf(s.Principals, func(pr networkingv1.Principal) match {
+			if p.Selector == "" || selector(pr) != p.Selector {
+				return no
+			}
+			if cp.principals[pr.Principal].Match(p.Value) {
+				return yes
+			}
+			return no
+		}),
+		unless(len(s.Resources) > 0 || len(s.IPBlocks) > 0 || len(s.NotIPBlocks) > 0 || s.RequestAuth != nil),
+	)
+}
+
+// matchOperation matches an operation against the requests whose path
+// starts with prefix. Headers, hosts and methods are not modelled and match
+// some requests.
+func matchOperation(o *networkingv1.GCPAuthzPolicyOperation, prefix string) match {
+	return all(
+		unless(len(o.Headers) > 0 || len(o.Hosts) > 0 || len(o.Methods) > 0),
+		anyOf(o.Paths, func(m networkingv1.StringMatchCriteria) match { return matchPath(m, prefix) }),
+	)
+}
+
+func matchPath(m networkingv1.StringMatchCriteria, prefix string) match {
+	value := m.Value
+	if m.IgnoreCase {
+		value, prefix = strings.ToLower(value), strings.ToLower(prefix)
+	}
+	switch m.Type {
+	case "", networkingv1.StringExact:
+		if strings.HasPrefix(value, prefix) {
+			return maybe
+		}
+	case networkingv1.StringPrefix:
+		if strings.HasPrefix(prefix, value) {
+			return yes
+		}
+		if strings.HasPrefix(value, prefix) {
+			return maybe
+		}
+	default:
+		return maybe
+	}
+	return no
+}
@@ -0,0 +1,233 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package matrix
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+const snapshot = `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: frontend
+  namespace: shop
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: cart-7d9f8b6c5-x2x4z
+    namespace: shop
+    labels:
+      app: cart
+      pod-template-hash: 7d9f8b6c5
+    ownerReferences:
+    - apiVersion: apps/v1
+      kind: ReplicaSet
+      name: cart-7d9f8b6c5
+      uid: "1"
+      controller: true
+  spec:
+    serviceAccountName: cart
+    containers:
+    - name: cart
+      image: cart
+      ports:
+      - containerPort: 8080
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: cart-7d9f8b6c5-q8w7e
+    namespace: shop
+    labels:
+      app: cart
+      pod-template-hash: 7d9f8b6c5
+    ownerReferences:
+    - apiVersion: apps/v1
+      kind: ReplicaSet
+      name: cart-7d9f8b6c5
+      uid: "1"
+      controller: true
+  spec:
+    serviceAccountName: cart
+    containers:
+    - name: cart
+      image: cart
+      ports:
+      - containerPort: 8080
+---
+apiVersion: networking.gke.io/v1
+kind: GCPAuthzPolicy
+metadata:
+  name: cart-allow
+  namespace: shop
+spec:
+  targetRefs:
+  - group: ""
+    kind: Pod
+    selector:
+      matchLabels:
+        app: cart
+  rules:
+  - from:
+      sources:
+      - principals:
+        - principal:
+            type: Exact
+            value: spiffe://cluster.local/ns/shop/sa/frontend
+    to:
+      operations:
+      - paths:
+        - type: Prefix
+          value: /api
+  - from:
+      sources:
+      - principals:
+        - principal:
+            type: Exact
+            value: spiffe://cluster.local/ns/shop/sa/cart
+    to:
+      operations:
+      - methods: [GET]
+---
+apiVersion: networking.gke.io/v1
+kind: GCPAuthzPolicy
+metadata:
+  name: cart-deny
+  namespace: shop
+spec:
+  action: DENY
+  targetRefs:
+  - group: ""
+    kind: Pod
+    selector:
+      matchLabels:
+        app: cart
+  rules:
+  - to:
+      operations:
+      - paths:
+        - type: Prefix
+          value: /api/admin
+---
+apiVersion: networking.gke.io/v1
+kind: GCPAuthzPolicy
+metadata:
+  name: dry-run
+  namespace: shop
+spec:
+  action: DENY
+  enforcementMode: DryRun
+  targetRefs:
+  - group: ""
+    kind: Pod
+    selector:
+      matchLabels:
+        app: cart
+  rules:
+  - to:
+      operations:
+      - paths:
+        - type: Prefix
+          value: /
+---
+apiVersion: networking.gke.io/v1
+kind: GCPAuthzPolicy
+metadata:
+  name: gateway
+  namespace: shop
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: external
+  rules:
+  - from:
+      sources:
+      - principals:
+        - principal:
+            type: Prefix
+            value: spiffe://cluster.local/ns/shop/
+`
+
+func TestBuild(t *testing.T) {
+	var s Snapshot
+	if err := s.Decode(strings.NewReader(snapshot)); err != nil {
+		t.Fatalf("Decode() = %v", err)
+	}
+	if len(s.Policies) != 4 || len(s.Pods) != 2 || len(s.ServiceAccounts) != 1 {
+		t.Fatalf("Decode() got %d policies, %d pods and %d service accounts, want 4, 2 and 1", len(s.Policies), len(s.Pods), len(s.ServiceAccounts))
+	}
+	m, err := Build(&s, Options{})
+	if err != nil {
+		t.Fatalf("Build() = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, m); err != nil {
+		t.Fatalf("WriteCSV() = %v", err)
+	}
+	want := `principal,gateway:shop/external:*/,shop/cart:8080/,shop/cart:8080/api,shop/cart:8080/api/admin
+shop/cart,ALLOW,UNKNOWN,UNKNOWN,DENY
+shop/frontend,ALLOW,UNKNOWN,UNKNOWN,DENY
+(unauthenticated),DENY,DENY,DENY,DENY
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteCSV() =\n%s\nwant\n%s", got, want)
+	}
+
+	for i, c := range m.Columns {
+		if c.PathPrefix == "/api/admin" {
+			if got := m.Cells[0][i].Policy; got != "shop/cart-deny" {
+				t.Errorf("policy of %s = %q, want shop/cart-deny", c, got)
+			}
+		}
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		typ        string
+		value      string
+		ignoreCase bool
+		prefix     string
+		want       match
+	}{
+		{typ: "Prefix", value: "/", prefix: "/api", want: yes},
+		{typ: "Prefix", value: "/api", prefix: "/api/v1", want: yes},
+		{typ: "Prefix", value: "/api/v1", prefix: "/api", want: maybe},
+		{typ: "Prefix", value: "/web", prefix: "/api", want: no},
+		{typ: "Prefix", value: "/API", ignoreCase: true, prefix: "/api/v1", want: yes},
+		{typ: "Exact", value: "/api", prefix: "/api", want: maybe},
+		{typ: "Exact", value: "/api", prefix: "/api/v1", want: no},
+		{typ: "", value: "/api/v1", prefix: "/", want: maybe},
+		{typ: "Suffix", value: ".png", prefix: "/api", want: maybe},
+	}
+	for _, tc := range tests {
+		m := networkingv1.StringMatchCriteria{Type: networkingv1.StringMatchCriteriaType(tc.typ), Value: tc.value, IgnoreCase: tc.ignoreCase}
+		if got := matchPath(m, tc.prefix); got != tc.want {
+			t.Errorf("matchPath(%+v, %q) = %d, want %d", m, tc.prefix, got, tc.want)
+		}
+	}
+}
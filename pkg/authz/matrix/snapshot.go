@@ -0,0 +1,78 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package matrix
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/client/clientset/versioned"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/manifest"
+)
+
+// Snapshot is the state of a cluster that the access matrix is built from.
+type Snapshot struct {
+	Policies        []*networkingv1.GCPAuthzPolicy
+	Pods            []*corev1.Pod
+	ServiceAccounts []*corev1.ServiceAccount
+}
+
+// Decode adds the GCPAuthzPolicies, Pods and ServiceAccounts of a
+// multi-document YAML or JSON stream, such as the output of
+// `kubectl get gcpauthzpolicies,pods,serviceaccounts -A -o yaml`, to the
+// snapshot. Lists are expanded, and documents of other kinds are skipped.
+func (s *Snapshot) Decode(r io.Reader) error {
+	return manifest.Decode(r, manifest.Kinds{
+		"GCPAuthzPolicy": manifest.Strict(&s.Policies),
+		"Pod":            manifest.Lenient(&s.Pods),
+		"ServiceAccount": manifest.Lenient(&s.ServiceAccounts),
+	})
+}
+
+// FromCluster reads a snapshot from a live cluster, in the given namespace
+// or in all namespaces if it is empty.
+func FromCluster(ctx context.Context, kube kubernetes.Interface, networking versioned.Interface, namespace string) (*Snapshot, error) {
+	s := &Snapshot{}
+	policies, err := networking.NetworkingV1().GCPAuthzPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list GCPAuthzPolicies: %w", err)
+	}
+	for i := range policies.Items {
+		s.Policies = append(s.Policies, &policies.Items[i])
+	}
+	pods, err := kube.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Pods: %w", err)
+	}
+	for i := range pods.Items {
+		s.Pods = append(s.Pods, &pods.Items[i])
+	}
+	serviceAccounts, err := kube.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ServiceAccounts: %w", err)
+	}
+	for i := range serviceAccounts.Items {
+		s.ServiceAccounts = append(s.ServiceAccounts, &serviceAccounts.Items[i])
+	}
+	return s, nil
+}
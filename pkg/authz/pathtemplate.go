@@ -0,0 +1,154 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package authz
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Limits of path templates.
+const (
+	MaxPathTemplateLength    = 255
+	MaxPathTemplateOperators = 5
+)
+
+var (
+	templateVariableName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+	templateLiteral      = regexp.MustCompile(`^[a-zA-Z0-9\-._~%!$&'()+,;=:@]+$`)
+)
+
+// Path template operators, and the expressions they match. Both exclude the
+// query and fragment of a URL.
+const (
+	segmentPattern      = `[^/?#]+`
+	multiSegmentPattern = `[^?#]*`
+)
+
+// PathTemplate is a compiled path template, for example
+// `/v1/users/{id}/orders/**`.
+//
+// A template consists of path segments that are either a literal, `*`, which
+// matches exactly one segment, `**`, which matches zero or more segments and
+// must be the last segment, or a variable `{name}`, `{name=*}` or
+// `{name=**}` that captures what the operator matches.
+type PathTemplate struct {
+	// Template is the template as written.
+	Template string
+	// Variables are the names of the variables of the template, in order.
+	Variables []string
+	re        *regexp.Regexp
+}
+
+// CompilePathTemplate parses the given path template.
+func CompilePathTemplate(template string) (*PathTemplate, error) {
+	if !strings.HasPrefix(template, "/") {
+		return nil, fmt.Errorf("path template %q must start with /", template)
+	}
+	if len(template) > MaxPathTemplateLength {
+		return nil, fmt.Errorf("path template is %d characters long, the maximum is %d", len(template), MaxPathTemplateLength)
+	}
+
+	t := &PathTemplate{Template: template}
+	var b strings.Builder
+	b.WriteString("^")
+	segments := strings.Split(template[1:], "/")
+	operators := 0
+	seen := map[string]bool{}
+	for i, seg := range segments {
+		b.WriteString("/")
+		last := i == len(segments)-1
+		if seg == "" {
+			// A trailing slash, or the root path.
+			if !last {
+				return nil, fmt.Errorf("path template %q contains an empty segment", template)
+			}
+			continue
+		}
+
+		name, op := "", seg
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			name, op, _ = strings.Cut(seg[1:len(seg)-1], "=")
+			if op == "" {
+				op = "*"
+			}
+			if !templateVariableName.MatchString(name) {
+				return nil, fmt.Errorf("path template %q has an invalid variable name %q", template, name)
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("path template %q uses variable %q more than once", template, name)
+			}
+			seen[name] = true
+			t.Variables = append(t.Variables, name)
+		}
+
+		var pattern string
+		switch op {
+		case "*":
+			pattern = segmentPattern
+		case "**":
+			if !last {
+				return nil, fmt.Errorf("path template %q can only use ** in the last segment", template)
+			}
+			pattern = multiSegmentPattern
+		default:
+			if name != "" || !templateLiteral.MatchString(seg) {
+				return nil, fmt.Errorf("path template %q has an invalid segment %q", template, seg)
+			}
+			b.WriteString(regexp.QuoteMeta(seg))
+			continue
+		}
+		if operators++; operators > MaxPathTemplateOperators {
+			return nil, fmt.Errorf("path template %q has more than %d operators", template, MaxPathTemplateOperators)
+		}
+		if name != "" {
+			pattern = "(?P<" + name + ">" + pattern + ")"
+		}
+		b.WriteString(pattern)
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("path template %q: %w", template, err)
+	}
+	t.re = re
+	return t, nil
+}
+
+// Match reports whether the given path matches the template. The query and
+// fragment of the path must be removed.
+func (t *PathTemplate) Match(path string) bool {
+	return t.re.MatchString(path)
+}
+
+// Bind returns the values of the variables of the template for the given
+// path, or nil if the path does not match.
+func (t *PathTemplate) Bind(path string) map[string]string {
+	m := t.re.FindStringSubmatch(path)
+	if m == nil {
+		return nil
+	}
+	vars := map[string]string{}
+	for i, name := range t.re.SubexpNames() {
+		if name != "" {
+			vars[name] = m[i]
+		}
+	}
+	return vars
+}
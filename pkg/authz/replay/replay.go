@@ -0,0 +1,297 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package replay replays recorded requests through the authz evaluator, to
+// find the requests that GCPAuthzPolicies in DryRun mode would change the
+// decision of before they are enforced.
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz"
+)
+
+// maxLineBytes is the maximum length of a line of a request log.
+const maxLineBytes = 1 << 20
+
+// Record is a line of a request log. It is either a request in the form
+// below, or a Cloud Logging entry of a load balancer request log, whose
+// httpRequest is used. Fields that are set take precedence over httpRequest.
+type Record struct {
+	Timestamp string            `json:"timestamp,omitempty"`
+	Method    string            `json:"method,omitempty"`
+	Host      string            `json:"host,omitempty"`
+	Path      string            `json:"path,omitempty"`
+	Headers   map[string]Values `json:"headers,omitempty"`
+	// SourceIP is the IP address of the client.
+	SourceIP string `json:"sourceIp,omitempty"`
+	// Peer is the authenticated identity of the client.
+	Peer *Peer `json:"peer,omitempty"`
+	// Claims are the claims of the validated JWT of the request.
+	Claims map[string]any `json:"claims,omitempty"`
+	// HTTPRequest is the httpRequest of a Cloud Logging entry.
+	HTTPRequest *HTTPRequest `json:"httpRequest,omitempty"`
+}
+
+// Peer is the authenticated identity of a client.
+type Peer struct {
+	URISANs           []string `json:"uriSans,omitempty"`
+	DNSSANs           []string `json:"dnsSans,omitempty"`
+	CommonName        string   `json:"commonName,omitempty"`
+	TagValueIDs       []int64  `json:"tagValueIds,omitempty"`
+	IAMServiceAccount string   `json:"iamServiceAccount,omitempty"`
+}
+
+// HTTPRequest is the httpRequest of a Cloud Logging entry.
+type HTTPRequest struct {
+	RequestMethod string `json:"requestMethod,omitempty"`
+	RequestURL    string `json:"requestUrl,omitempty"`
+	RemoteIP      string `json:"remoteIp,omitempty"`
+	UserAgent     string `json:"userAgent,omitempty"`
+	Referer       string `json:"referer,omitempty"`
+}
+
+// Values are the values of a header. They are written as a string or a list
+// of strings.
+type Values []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Values) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*v = Values{s}
+		return nil
+	}
+	var l []string
+	if err := json.Unmarshal(b, &l); err != nil {
+		return fmt.Errorf("header values must be a string or a list of strings")
+	}
+	*v = l
+	return nil
+}
+
+// Request converts the record to an authz.Request.
+func (rec *Record) Request() (*authz.Request, error) {
+	req := &authz.Request{Method: rec.Method, Host: rec.Host, Path: rec.Path, Headers: http.Header{}, Claims: rec.Claims}
+	sourceIP := rec.SourceIP
+	if h := rec.HTTPRequest; h != nil {
+		if req.Method == "" {
+			req.Method = h.RequestMethod
+		}
+		if h.RequestURL != "" {
+			u, err := url.Parse(h.RequestURL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid requestUrl: %w", err)
+			}
+			if req.Host == "" {
+				req.Host = u.Host
+			}
+			if req.Path == "" {
+				req.Path = u.EscapedPath()
+			}
+		}
+		if sourceIP == "" {
+			sourceIP = h.RemoteIP
+		}
+		if h.UserAgent != "" {
+			req.Headers.Set("User-Agent", h.UserAgent)
+		}
+		if h.Referer != "" {
+			req.Headers.Set("Referer", h.Referer)
+		}
+	}
+	for name, values := range rec.Headers {
+		req.Headers.Del(name)
+		for _, v := range values {
+			req.Headers.Add(name, v)
+		}
+	}
+	if req.Path == "" {
+		req.Path = "/"
+	}
+	if sourceIP != "" {
+		addr, err := netip.ParseAddr(sourceIP)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source IP: %w", err)
+		}
+		req.Peer.Address = addr
+	}
+	if p := rec.Peer; p != nil {
+		req.Peer.URISANs = p.URISANs
+		req.Peer.DNSSANs = p.DNSSANs
+		req.Peer.CommonName = p.CommonName
+		req.Peer.TagValueIDs = p.TagValueIDs
+		req.Peer.IAMServiceAccount = p.IAMServiceAccount
+	}
+	return req, nil
+}
+
+// Finding is a request whose decision would change if the policies in
+// DryRun mode were enforced.
+type Finding struct {
+	// Line is the line of the request in the log, starting at 1.
+	Line     int     `json:"line"`
+	Record   *Record `json:"record"`
+	Enforced Outcome `json:"enforced"`
+	DryRun   Outcome `json:"dryRun"`
+}
+
+// Outcome is an authz.Decision in report form.
+type Outcome struct {
+	Result authz.Result `json:"result"`
+	// Policy is the namespace/name of the policy that made the decision.
+	Policy string `json:"policy,omitempty"`
+	// Rule is the index of the matching rule, or -1.
+	Rule   int    `json:"rule"`
+	Reason string `json:"reason"`
+}
+
+func outcome(d authz.Decision) Outcome {
+	o := Outcome{Result: d.Result, Rule: d.Rule, Reason: d.Reason}
+	if d.Policy.Name != "" {
+		o.Policy = d.Policy.String()
+	}
+	return o
+}
+
+// LineError is a line of the log that could not be replayed.
+type LineError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// Report is the result of replaying a request log.
+type Report struct {
+	// Requests is the number of replayed requests.
+	Requests int `json:"requests"`
+	// Enforced counts the decisions of the enforced policies by result.
+	Enforced map[authz.Result]int `json:"enforced"`
+	// DryRun counts the decisions that would be made if the policies in
+	// DryRun mode were enforced, by result.
+	DryRun map[authz.Result]int `json:"dryRun"`
+	// WouldDeny are the requests that are not denied today, but would be
+	// denied if the policies in DryRun mode were enforced.
+	WouldDeny []Finding `json:"wouldDeny,omitempty"`
+	// WouldAllow are the requests that are denied today, but would be
+	// allowed if the policies in DryRun mode were enforced.
+	WouldAllow []Finding `json:"wouldAllow,omitempty"`
+	// DeniedBy counts the requests of WouldDeny by the policy that would
+	// deny them.
+	DeniedBy map[string]int `json:"deniedBy,omitempty"`
+	// Errors are the lines that could not be replayed.
+	Errors []LineError `json:"errors,omitempty"`
+}
+
+// Replay replays the JSON lines request log read from r through the given
+// evaluator. Lines that cannot be parsed or evaluated are reported as Errors
+// rather than failing the replay, as are lines longer than 1MiB; the returned
+// error is only set if r cannot be read.
+func Replay(r io.Reader, e *authz.Evaluator) (*Report, error) {
+	report := &Report{Enforced: map[authz.Result]int{}, DryRun: map[authz.Result]int{}, DeniedBy: map[string]int{}}
+	br := bufio.NewReaderSize(r, 64*1024)
+	for line := 1; ; line++ {
+		b, err := readLine(br)
+		if err == errLineTooLong {
+			report.Errors = append(report.Errors, LineError{Line: line, Error: err.Error()})
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		b = bytes.TrimSpace(b)
+		if len(b) == 0 {
+			continue
+		}
+		rec := &Record{}
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		if err := d.Decode(rec); err != nil {
+			report.Errors = append(report.Errors, LineError{Line: line, Error: err.Error()})
+			continue
+		}
+		req, err := rec.Request()
+		if err != nil {
+			report.Errors = append(report.Errors, LineError{Line: line, Error: err.Error()})
+			continue
+		}
+		enforced, err := e.Evaluate(req)
+		if err != nil {
+			report.Errors = append(report.Errors, LineError{Line: line, Error: err.Error()})
+			continue
+		}
+		dryRun := enforced
+		if enforced.DryRun != nil {
+			dryRun = *enforced.DryRun
+			enforced.DryRun = nil
+		}
+		report.Requests++
+		report.Enforced[enforced.Result]++
+		report.DryRun[dryRun.Result]++
+
+		f := Finding{Line: line, Record: rec, Enforced: outcome(enforced), DryRun: outcome(dryRun)}
+		switch {
+		case dryRun.Result == authz.Denied && enforced.Result != authz.Denied:
+			report.WouldDeny = append(report.WouldDeny, f)
+			report.DeniedBy[dryRun.Policy.String()]++
+		case dryRun.Result != authz.Denied && enforced.Result == authz.Denied:
+			report.WouldAllow = append(report.WouldAllow, f)
+		}
+	}
+	return report, nil
+}
+
+var errLineTooLong = fmt.Errorf("line is longer than %d bytes", maxLineBytes)
+
+// readLine returns the next line read from r, without its line terminator.
+// It returns io.EOF once all the lines have been read, and errLineTooLong,
+// after skipping the line, if it is longer than maxLineBytes.
+func readLine(r *bufio.Reader) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		if err == nil {
+			chunk = chunk[:len(chunk)-1]
+		}
+		if len(line)+len(chunk) > maxLineBytes {
+			for err == bufio.ErrBufferFull {
+				_, err = r.ReadSlice('\n')
+			}
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			return nil, errLineTooLong
+		}
+		line = append(line, chunk...)
+		switch {
+		case err == bufio.ErrBufferFull:
+			continue
+		case err == io.EOF && len(line) > 0:
+			return line, nil
+		}
+		return line, err
+	}
+}
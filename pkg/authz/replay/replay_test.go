@@ -0,0 +1,130 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package replay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz"
+)
+
+const policies = `
+apiVersion: networking.gke.io/v1
+kind: GCPAuthzPolicy
+metadata:
+  namespace: app
+  name: deny-admin
+spec:
+  enforcementLevel: L7
+  action: DENY
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: external
+  rules:
+  - to:
+      operations:
+      - paths:
+        - type: Prefix
+          value: /admin
+---
+apiVersion: networking.gke.io/v1
+kind: GCPAuthzPolicy
+metadata:
+  namespace: app
+  name: partners-only
+spec:
+  enforcementLevel: L7
+  action: ALLOW
+  enforcementMode: DryRun
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: external
+  rules:
+  - from:
+      sources:
+      - ipBlocks: ["203.0.113.0/24"]
+`
+
+const requests = `{"method":"GET","host":"api.example.com","path":"/v1/orders","sourceIp":"203.0.113.7"}
+{"method":"GET","host":"api.example.com","path":"/v1/orders","sourceIp":"198.51.100.1","headers":{"x-tenant":["acme"]}}
+
+{"timestamp":"2026-01-01T00:00:00Z","httpRequest":{"requestMethod":"POST","requestUrl":"https://api.example.com/admin/users?x=1","remoteIp":"198.51.100.1"}}
+{"httpRequest":{"requestMethod":"GET","requestUrl":"https://api.example.com/v1/orders","remoteIp":"not-an-ip"}}
+not json
+`
+
+func TestReplay(t *testing.T) {
+	decoded, err := authz.DecodePolicies(strings.NewReader(policies))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := &authz.Evaluator{}
+	for _, p := range decoded {
+		cp, err := authz.Compile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e.Policies = append(e.Policies, cp)
+	}
+
+	r, err := Replay(strings.NewReader(requests), e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Requests != 3 {
+		t.Errorf("Requests = %d, want 3", r.Requests)
+	}
+	if r.Enforced[authz.Allowed] != 2 || r.Enforced[authz.Denied] != 1 {
+		t.Errorf("Enforced = %v, want 2 allowed and 1 denied", r.Enforced)
+	}
+	if len(r.WouldDeny) != 1 || r.WouldDeny[0].Line != 2 || r.WouldDeny[0].DryRun.Policy != "app/partners-only" {
+		t.Errorf("WouldDeny = %+v, want line 2 denied by app/partners-only", r.WouldDeny)
+	}
+	if r.DeniedBy["app/partners-only"] != 1 {
+		t.Errorf("DeniedBy = %v, want app/partners-only: 1", r.DeniedBy)
+	}
+	if len(r.WouldAllow) != 0 {
+		t.Errorf("WouldAllow = %+v, want none", r.WouldAllow)
+	}
+	if len(r.Errors) != 2 || r.Errors[0].Line != 5 || r.Errors[1].Line != 6 {
+		t.Errorf("Errors = %+v, want lines 5 and 6", r.Errors)
+	}
+}
+
+func TestReplayLongLines(t *testing.T) {
+	valid := `{"method":"GET","host":"api.example.com","path":"/v1/orders","sourceIp":"203.0.113.7"}`
+	log := strings.Join([]string{
+		valid,
+		strings.Repeat("x", maxLineBytes+1),
+		strings.Repeat(" ", maxLineBytes),
+		valid,
+		strings.Repeat("x", 2*maxLineBytes),
+	}, "\n")
+	r, err := Replay(strings.NewReader(log), &authz.Evaluator{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Requests != 2 {
+		t.Errorf("Requests = %d, want 2", r.Requests)
+	}
+	if len(r.Errors) != 2 || r.Errors[0].Line != 2 || r.Errors[1].Line != 5 {
+		t.Errorf("Errors = %+v, want lines 2 and 5", r.Errors)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package authz
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/policyref"
+)
+
+// EnforcedCondition returns the Enforced condition of the given policy,
+// which reports whether the policy is in DryRun mode.
+func EnforcedCondition(p *networkingv1.GCPAuthzPolicy, now metav1.Time) metav1.Condition {
+	c := metav1.Condition{
+		Type:               string(networkingv1.PolicyConditionEnforced),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(networkingv1.PolicyReasonEnforced),
+		Message:            "Decisions of the policy are enforced",
+		ObservedGeneration: p.Generation,
+		LastTransitionTime: now,
+	}
+	if p.Spec.EnforcementMode != nil && *p.Spec.EnforcementMode == networkingv1.DryRun {
+		c.Status = metav1.ConditionFalse
+		c.Reason = string(networkingv1.PolicyReasonDryRun)
+		c.Message = "Policy is in DryRun mode, its decisions are logged but not enforced"
+	}
+	return c
+}
+
+// SetEnforcedCondition sets the Enforced condition in the status of every
+// ancestor of the given policy. The transition time is only updated if the
+// status of the condition changes.
+func SetEnforcedCondition(p *networkingv1.GCPAuthzPolicy, now metav1.Time) {
+	c := EnforcedCondition(p, now)
+	for i := range p.Status.Ancestors {
+		meta.SetStatusCondition(&p.Status.Ancestors[i].Conditions, c)
+	}
+}
+
+// ResolvedRefsCondition returns the ResolvedRefs condition of the given
+// policy for the error returned by ResolveExtensions.
+func ResolvedRefsCondition(p *networkingv1.GCPAuthzPolicy, err error, now metav1.Time) metav1.Condition {
+	c := metav1.Condition{
+		Type:               string(networkingv1.PolicyConditionResolvedRefs),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(networkingv1.PolicyReasonResolvedRefs),
+		Message:            "All extension references are resolved",
+		ObservedGeneration: p.Generation,
+		LastTransitionTime: now,
+	}
+	if err != nil {
+		c.Status = metav1.ConditionFalse
+		c.Reason = string(networkingv1.PolicyReasonInvalid)
+		c.Message = err.Error()
+		var refErr *policyref.RefError
+		if errors.As(err, &refErr) {
+			c.Reason = string(refErr.Reason)
+		}
+	}
+	return c
+}
+
+// SetResolvedRefsCondition sets the ResolvedRefs condition in the status of
+// every ancestor of the given policy.
+func SetResolvedRefsCondition(p *networkingv1.GCPAuthzPolicy, err error, now metav1.Time) {
+	c := ResolvedRefsCondition(p, err, now)
+	for i := range p.Status.Ancestors {
+		meta.SetStatusCondition(&p.Status.Ancestors[i].Conditions, c)
+	}
+}
+
+// SetAuthzExtensionConditions sets the Accepted and ResolvedRefs conditions
+// of the given GCPAuthzExtension, from the errors returned by
+// ValidateAuthzExtensionSpec and ResolveAuthzExtensionBackend.
+func SetAuthzExtensionConditions(ext *networkingv1.GCPAuthzExtension, errs field.ErrorList, resolveErr error, now metav1.Time) {
+	accepted := metav1.Condition{
+		Type:               string(networkingv1.ExtensionConditionAccepted),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(networkingv1.ExtensionReasonAccepted),
+		Message:            "Extension is accepted",
+		ObservedGeneration: ext.Generation,
+		LastTransitionTime: now,
+	}
+	resolved := metav1.Condition{
+		Type:               string(networkingv1.ExtensionConditionResolvedRefs),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(networkingv1.ExtensionReasonResolvedRefs),
+		Message:            "Extension service is resolved",
+		ObservedGeneration: ext.Generation,
+		LastTransitionTime: now,
+	}
+
+	var refErr *policyref.RefError
+	switch {
+	case len(errs) > 0:
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = string(networkingv1.ExtensionReasonInvalid)
+		accepted.Message = errs.ToAggregate().Error()
+	case errors.As(resolveErr, &refErr) && refErr.Reason == networkingv1.PolicyReasonUnsupportedWireFormat:
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = string(networkingv1.ExtensionReasonInvalidExtensionService)
+		accepted.Message = resolveErr.Error()
+	case resolveErr != nil:
+		resolved.Status = metav1.ConditionFalse
+		resolved.Reason = string(networkingv1.ExtensionReasonExtensionServiceNotFound)
+		resolved.Message = resolveErr.Error()
+	}
+	meta.SetStatusCondition(&ext.Status.Conditions, accepted)
+	meta.SetStatusCondition(&ext.Status.Conditions, resolved)
+}
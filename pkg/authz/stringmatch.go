@@ -0,0 +1,127 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package authz evaluates and validates GCPAuthzPolicies offline, without the
+// load balancer that enforces them.
+package authz
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// MaxRegexLength is the maximum length of a SafeRegex.
+const MaxRegexLength = 1024
+
+// StringMatcher is a compiled StringMatchCriteria or HTTPHeaderMatch.
+type StringMatcher struct {
+	typ        networkingv1.StringMatchCriteriaType
+	value      string
+	ignoreCase bool
+	re         *regexp.Regexp
+}
+
+// CompileStringMatch compiles the given match criteria. SafeRegex values are
+// compiled as RE2 expressions and PathTemplate values as path templates.
+func CompileStringMatch(m networkingv1.StringMatchCriteria) (*StringMatcher, error) {
+	return compileStringMatch(m.Type, m.Value, m.IgnoreCase)
+}
+
+// CompileHeaderMatch compiles the value criteria of the given header match.
+func CompileHeaderMatch(m networkingv1.HTTPHeaderMatch) (*StringMatcher, error) {
+	if m.Type == networkingv1.StringPathTemplate {
+		return nil, fmt.Errorf("%s is only supported for paths", m.Type)
+	}
+	return compileStringMatch(m.Type, m.Value, m.IgnoreCase)
+}
+
+func compileStringMatch(typ networkingv1.StringMatchCriteriaType, value string, ignoreCase bool) (*StringMatcher, error) {
+	m := &StringMatcher{typ: typ, value: value, ignoreCase: ignoreCase}
+	switch typ {
+	case networkingv1.StringExact, "":
+		m.typ = networkingv1.StringExact
+	case networkingv1.StringPrefix, networkingv1.StringSuffix, networkingv1.StringContains:
+		if ignoreCase {
+			m.value = strings.ToLower(value)
+		}
+	case networkingv1.StringSafeRegex:
+		if ignoreCase {
+			return nil, fmt.Errorf("ignoreCase is not supported for %s, use the (?i) flag instead", typ)
+		}
+		if len(value) > MaxRegexLength {
+			return nil, fmt.Errorf("regular expression is %d characters long, the maximum is %d", len(value), MaxRegexLength)
+		}
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		m.re = re
+	case networkingv1.StringPathTemplate:
+		if ignoreCase {
+			return nil, fmt.Errorf("ignoreCase is not supported for %s", typ)
+		}
+		t, err := CompilePathTemplate(value)
+		if err != nil {
+			return nil, err
+		}
+		m.re = t.re
+	default:
+		return nil, fmt.Errorf("unsupported match type %q", typ)
+	}
+	return m, nil
+}
+
+// Match reports whether the given string matches.
+func (m *StringMatcher) Match(s string) bool {
+	if m.re != nil {
+		return m.re.MatchString(s)
+	}
+	if m.ignoreCase {
+		if m.typ == networkingv1.StringExact {
+			return strings.EqualFold(s, m.value)
+		}
+		s = strings.ToLower(s)
+	}
+	switch m.typ {
+	case networkingv1.StringExact:
+		return s == m.value
+	case networkingv1.StringPrefix:
+		return strings.HasPrefix(s, m.value)
+	case networkingv1.StringSuffix:
+		return strings.HasSuffix(s, m.value)
+	case networkingv1.StringContains:
+		return strings.Contains(s, m.value)
+	}
+	return false
+}
+
+// MatchAny reports whether any of the given strings matches.
+func (m *StringMatcher) MatchAny(values []string) bool {
+	for _, v := range values {
+		if m.Match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the criteria in type:value form.
+func (m *StringMatcher) String() string {
+	return string(m.typ) + ":" + m.value
+}
@@ -0,0 +1,212 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package authz
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// ValidateSpec validates the given policy beyond what the CRD schema
+// enforces. Match criteria are compiled, so that invalid regular expressions
+// and path templates are rejected before admission rather than by the load
+// balancer.
+func ValidateSpec(spec *networkingv1.GCPAuthzPolicySpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.EnforcementMode != nil && *spec.EnforcementMode == networkingv1.DryRun && spec.Action != nil && *spec.Action == networkingv1.Custom {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("enforcementMode"), *spec.EnforcementMode, "DryRun is not supported when action is CUSTOM"))
+	}
+	if spec.Action != nil && *spec.Action == networkingv1.Custom {
+		if spec.EnforcementLevel != networkingv1.L7 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("enforcementLevel"), spec.EnforcementLevel, "must be L7 when action is CUSTOM"))
+		}
+		if spec.CustomProviders == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("customProviders"), "required when action is CUSTOM"))
+		}
+	}
+	if spec.CustomProviders != nil {
+		for i, ref := range spec.CustomProviders.ExtensionRefs {
+			refPath := fldPath.Child("customProviders", "extensionRefs").Index(i)
+			if !(ref.Group == networkingv1.GroupName && (ref.Kind == AuthzExtensionKind || ref.Kind == TrafficExtensionKind)) && !(ref.Group == "" && ref.Kind == ServiceKind) {
+				allErrs = append(allErrs, field.NotSupported(refPath.Child("kind"), ref.Kind, []string{networkingv1.GroupName + "/" + AuthzExtensionKind, networkingv1.GroupName + "/" + TrafficExtensionKind, ServiceKind}))
+			}
+		}
+	}
+	for i, r := range spec.Rules {
+		rulePath := fldPath.Child("rules").Index(i)
+		if r.From != nil {
+			allErrs = append(allErrs, validateSources(r.From.Sources, spec.EnforcementLevel, rulePath.Child("from", "sources"))...)
+			allErrs = append(allErrs, validateSources(r.From.NotSources, spec.EnforcementLevel, rulePath.Child("from", "notSources"))...)
+		}
+		if r.To != nil {
+			allErrs = append(allErrs, validateOperations(r.To.Operations, rulePath.Child("to", "operations"))...)
+			allErrs = append(allErrs, validateOperations(r.To.NotOperations, rulePath.Child("to", "notOperations"))...)
+		}
+	}
+	return allErrs
+}
+
+func validateSources(sources []networkingv1.GCPAuthzPolicySource, level networkingv1.EnforcementLevel, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, s := range sources {
+		sPath := fldPath.Index(i)
+		for j, c := range s.IPBlocks {
+			if _, err := ParseCIDR(c); err != nil {
+				allErrs = append(allErrs, field.Invalid(sPath.Child("ipBlocks").Index(j), c, err.Error()))
+			}
+		}
+		for j, c := range s.NotIPBlocks {
+			if _, err := ParseCIDR(c); err != nil {
+				allErrs = append(allErrs, field.Invalid(sPath.Child("notIpBlocks").Index(j), c, err.Error()))
+			}
+		}
+		if s.RequestAuth != nil {
+			if level == networkingv1.L4 {
+				allErrs = append(allErrs, field.Forbidden(sPath.Child("requestAuth"), "only supported when enforcementLevel is L7"))
+			} else {
+				allErrs = append(allErrs, validateRequestAuth(s.RequestAuth, sPath.Child("requestAuth"))...)
+			}
+		}
+		for j, p := range s.Principals {
+			pPath := fldPath.Index(i).Child("principals").Index(j).Child("principal")
+			if p.Principal.Type != networkingv1.StringExact && p.Principal.Type != "" {
+				allErrs = append(allErrs, field.NotSupported(pPath.Child("type"), p.Principal.Type, []string{string(networkingv1.StringExact)}))
+				continue
+			}
+			allErrs = append(allErrs, validateStringMatch(p.Principal, false, pPath)...)
+		}
+		for j, r := range s.Resources {
+			if r.IAMServiceAccount == nil {
+				continue
+			}
+			saPath := fldPath.Index(i).Child("resources").Index(j).Child("iamServiceAccount")
+			allErrs = append(allErrs, validateStringMatch(*r.IAMServiceAccount, false, saPath)...)
+		}
+	}
+	return allErrs
+}
+
+func validateRequestAuth(a *networkingv1.GCPAuthzPolicyRequestAuth, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(a.Issuers) == 0 && len(a.Audiences) == 0 && len(a.RequestPrincipals) == 0 && len(a.Claims) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one of issuers, audiences, requestPrincipals and claims must be specified"))
+	}
+	for j, m := range a.Issuers {
+		allErrs = append(allErrs, validateStringMatch(m, false, fldPath.Child("issuers").Index(j))...)
+	}
+	for j, m := range a.Audiences {
+		allErrs = append(allErrs, validateStringMatch(m, false, fldPath.Child("audiences").Index(j))...)
+	}
+	for j, m := range a.RequestPrincipals {
+		allErrs = append(allErrs, validateStringMatch(m, false, fldPath.Child("requestPrincipals").Index(j))...)
+	}
+	names := map[string]bool{}
+	for j, c := range a.Claims {
+		cPath := fldPath.Child("claims").Index(j)
+		if c.Name == "" || slices.Contains(strings.Split(c.Name, "."), "") {
+			allErrs = append(allErrs, field.Invalid(cPath.Child("name"), c.Name, "must be a claim name, with nested claims separated by single dots"))
+		} else if names[c.Name] {
+			allErrs = append(allErrs, field.Duplicate(cPath.Child("name"), c.Name))
+		}
+		names[c.Name] = true
+		if len(c.Values) == 0 {
+			allErrs = append(allErrs, field.Required(cPath.Child("values"), ""))
+		}
+		for k, m := range c.Values {
+			allErrs = append(allErrs, validateStringMatch(m, false, cPath.Child("values").Index(k))...)
+		}
+	}
+	return allErrs
+}
+
+func validateOperations(operations []networkingv1.GCPAuthzPolicyOperation, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, o := range operations {
+		opPath := fldPath.Index(i)
+		for j, h := range o.Headers {
+			hPath := opPath.Child("headers").Index(j)
+			if h.Type == networkingv1.StringPathTemplate {
+				allErrs = append(allErrs, field.Invalid(hPath.Child("type"), h.Type, fmt.Sprintf("%s is only supported for paths", h.Type)))
+			} else if _, err := CompileHeaderMatch(h); err != nil {
+				allErrs = append(allErrs, field.Invalid(hPath.Child("value"), h.Value, err.Error()))
+			}
+		}
+		for j, h := range o.Hosts {
+			allErrs = append(allErrs, validateStringMatch(h, false, opPath.Child("hosts").Index(j))...)
+		}
+		for j, p := range o.Paths {
+			allErrs = append(allErrs, validateStringMatch(p, true, opPath.Child("paths").Index(j))...)
+		}
+	}
+	return allErrs
+}
+
+// validateStringMatch compiles the given criteria. PathTemplate is only
+// allowed if isPath is set.
+func validateStringMatch(m networkingv1.StringMatchCriteria, isPath bool, fldPath *field.Path) field.ErrorList {
+	if m.Type == networkingv1.StringPathTemplate && !isPath {
+		return field.ErrorList{field.Invalid(fldPath.Child("type"), m.Type, fmt.Sprintf("%s is only supported for paths", m.Type))}
+	}
+	if _, err := CompileStringMatch(m); err != nil {
+		return field.ErrorList{field.Invalid(fldPath.Child("value"), m.Value, err.Error())}
+	}
+	return nil
+}
+
+// ValidateAuthzExtensionSpec validates the given GCPAuthzExtension beyond
+// what the CRD schema enforces.
+func ValidateAuthzExtensionSpec(spec *networkingv1.GCPAuthzExtensionSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if kind := spec.BackendRef.Kind; kind != ServiceKind && kind != "ServiceImport" {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("backendRef", "kind"), kind, []string{ServiceKind, "ServiceImport"}))
+	}
+	if t := spec.Timeout; t != nil {
+		if d, err := time.ParseDuration(string(*t)); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), *t, err.Error()))
+		} else if d < 10*time.Millisecond || d > 10*time.Second {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), *t, "must be between 10-10000 milliseconds"))
+		}
+	}
+	if spec.FailOpen && spec.StatusOnError != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("statusOnError"), "cannot be set when failOpen is true"))
+	}
+	allErrs = append(allErrs, validateHeaderNames(spec.ForwardHeaders, fldPath.Child("forwardHeaders"))...)
+	allErrs = append(allErrs, validateHeaderNames(spec.HeadersToUpstreamOnAllow, fldPath.Child("headersToUpstreamOnAllow"))...)
+	allErrs = append(allErrs, validateHeaderNames(spec.HeadersToDownstreamOnDeny, fldPath.Child("headersToDownstreamOnDeny"))...)
+	return allErrs
+}
+
+// validateHeaderNames rejects header names that are listed more than once,
+// ignoring case.
+func validateHeaderNames(names []networkingv1.HTTPHeaderName, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := map[string]bool{}
+	for i, name := range names {
+		key := strings.ToLower(string(name))
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), name))
+		}
+		seen[key] = true
+	}
+	return allErrs
+}
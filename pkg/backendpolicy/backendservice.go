@@ -0,0 +1,124 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"fmt"
+	"time"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/logging"
+)
+
+// BackendService contains the BackendService fields that are configured by
+// GCPBackendPolicy. Field names and their JSON encoding match the compute API.
+// See https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
+//
+// A nil field is not managed by the policy and must be left unchanged.
+type BackendService struct {
+	// SecurityPolicy is the URL of the backend SecurityPolicy. An empty string
+	// detaches the SecurityPolicy. It is applied with the setSecurityPolicy
+	// method.
+	SecurityPolicy *string `json:"securityPolicy,omitempty"`
+	// EdgeSecurityPolicy is the URL of the edge SecurityPolicy. An empty string
+	// detaches the SecurityPolicy. It is applied with the setEdgeSecurityPolicy
+	// method.
+	EdgeSecurityPolicy *string `json:"edgeSecurityPolicy,omitempty"`
+	// LogConfig is the logging configuration of the BackendService.
+	LogConfig *logging.LogConfig `json:"logConfig,omitempty"`
+	// CircuitBreakers are the circuit breaking thresholds of the BackendService.
+	CircuitBreakers *CircuitBreakers `json:"circuitBreakers,omitempty"`
+	// OutlierDetection is the outlier detection configuration of the BackendService.
+	OutlierDetection *OutlierDetection `json:"outlierDetection,omitempty"`
+	// ConsistentHash is the consistent hashing configuration of the BackendService.
+	ConsistentHash *ConsistentHash `json:"consistentHash,omitempty"`
+	// CustomRequestHeaders are the headers added to requests, in the
+	// "Name: value" form.
+	CustomRequestHeaders []string `json:"customRequestHeaders,omitempty"`
+	// CustomResponseHeaders are the headers added to responses, in the
+	// "Name: value" form.
+	CustomResponseHeaders []string `json:"customResponseHeaders,omitempty"`
+	// EnableCDN denotes whether Cloud CDN is enabled for the BackendService.
+	EnableCDN *bool `json:"enableCDN,omitempty"`
+	// CDNPolicy is the Cloud CDN configuration of the BackendService. Signed
+	// URL keys are applied separately, see ResolveSignedURLKeys.
+	CDNPolicy *CDNPolicy `json:"cdnPolicy,omitempty"`
+	// Protocol is the protocol used to communicate with the backends. Empty
+	// if it is not managed by the policy.
+	Protocol string `json:"protocol,omitempty"`
+	// TLSSettings is the TLS configuration of the connections to the backends.
+	TLSSettings *TLSSettings `json:"tlsSettings,omitempty"`
+}
+
+// Duration is a span of time in the compute API.
+type Duration struct {
+	Seconds int64 `json:"seconds,string"`
+	Nanos   int32 `json:"nanos,omitempty"`
+}
+
+// toDuration converts a Gateway API duration into a compute API Duration.
+func toDuration(d *gatewayv1.Duration) (*Duration, error) {
+	if d == nil {
+		return nil, nil
+	}
+	parsed, err := time.ParseDuration(string(*d))
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration %q: %w", *d, err)
+	}
+	return &Duration{
+		Seconds: int64(parsed / time.Second),
+		Nanos:   int32(parsed % time.Second),
+	}, nil
+}
+
+// Translate returns the BackendService fields configured by the given policy
+// configuration. Raw resource names are resolved within the given project, and
+// within the given region for regional load balancers. region must be empty
+// for global load balancers.
+func Translate(cfg *networkingv1.GCPBackendPolicyConfig, project, region string) (*BackendService, error) {
+	bs := &BackendService{}
+	if cfg == nil {
+		return bs, nil
+	}
+	if err := ApplySecurityPolicies(cfg, bs, project, region); err != nil {
+		return nil, err
+	}
+	bs.LogConfig = logging.Render(cfg.Logging)
+	bs.CircuitBreakers = renderCircuitBreakers(cfg.CircuitBreakers)
+	od, err := renderOutlierDetection(cfg.OutlierDetection)
+	if err != nil {
+		return nil, err
+	}
+	bs.OutlierDetection = od
+	ch, err := RenderConsistentHash(cfg.ConsistentHash)
+	if err != nil {
+		return nil, err
+	}
+	bs.ConsistentHash = ch
+	bs.CustomRequestHeaders = renderCustomHeaders(cfg.CustomRequestHeaders)
+	bs.CustomResponseHeaders = renderCustomHeaders(cfg.CustomResponseHeaders)
+	if bs.EnableCDN, bs.CDNPolicy, err = renderCDN(cfg.CDN); err != nil {
+		return nil, err
+	}
+	if cfg.Protocol != nil {
+		bs.Protocol = string(*cfg.Protocol)
+	}
+	bs.TLSSettings = renderTLSSettings(cfg.TLS)
+	return bs, nil
+}
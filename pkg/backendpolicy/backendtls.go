@@ -0,0 +1,207 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/policyref"
+)
+
+// CACertificateKey is the key of the CA certificate bundle in the ConfigMaps
+// referenced by BackendTLSConfig.CACertificateRefs.
+const CACertificateKey = "ca.crt"
+
+// TLSSettings is the tlsSettings of a BackendService in the compute API.
+type TLSSettings struct {
+	// SNI is the server name indication sent to the backends.
+	SNI string `json:"sni,omitempty"`
+	// SubjectAltNames are the Subject Alternative Names that the certificate
+	// of the backends must match.
+	SubjectAltNames []TLSSubjectAltName `json:"subjectAltNames,omitempty"`
+	// AuthenticationConfig is the URL of the BackendAuthenticationConfig used
+	// to validate the certificate of the backends. It is not set by Translate
+	// because the BackendAuthenticationConfig is managed separately, see
+	// RenderBackendAuthentication.
+	AuthenticationConfig string `json:"authenticationConfig,omitempty"`
+}
+
+// TLSSubjectAltName is a tlsSettings.subjectAltNames entry in the compute API.
+type TLSSubjectAltName struct {
+	DNSName                   string `json:"dnsName,omitempty"`
+	UniformResourceIdentifier string `json:"uniformResourceIdentifier,omitempty"`
+}
+
+// BackendAuthenticationConfig is the network security
+// BackendAuthenticationConfig resource that validates the certificate of the
+// backends.
+// See https://cloud.google.com/load-balancing/docs/backend-authenticated-tls-backend-mtls
+type BackendAuthenticationConfig struct {
+	// WellKnownRoots is PUBLIC_ROOTS to trust the public root CAs, or NONE.
+	WellKnownRoots string `json:"wellKnownRoots,omitempty"`
+	// TrustConfig is the URL of the certificate manager TrustConfig that
+	// contains the CA certificates. It is set by the caller once the
+	// TrustConfig returned by RenderBackendAuthentication has been created.
+	TrustConfig string `json:"trustConfig,omitempty"`
+}
+
+// TrustConfig is the certificate manager TrustConfig resource that contains
+// the CA certificates referenced by BackendTLSConfig.CACertificateRefs.
+type TrustConfig struct {
+	TrustStores []TrustStore `json:"trustStores"`
+}
+
+// TrustStore is a trustStores entry of a TrustConfig.
+type TrustStore struct {
+	TrustAnchors []TrustAnchor `json:"trustAnchors"`
+}
+
+// TrustAnchor is a trustAnchors entry of a TrustStore.
+type TrustAnchor struct {
+	PemCertificate string `json:"pemCertificate"`
+}
+
+// renderTLSSettings converts the given BackendTLSConfig into compute API
+// TLSSettings. It returns nil if tls is nil.
+func renderTLSSettings(tls *networkingv1.BackendTLSConfig) *TLSSettings {
+	if tls == nil {
+		return nil
+	}
+	ret := &TLSSettings{}
+	if tls.Hostname != nil {
+		ret.SNI = string(*tls.Hostname)
+	}
+	for _, san := range tls.SubjectAltNames {
+		switch san.Type {
+		case gatewayv1.HostnameSubjectAltNameType:
+			ret.SubjectAltNames = append(ret.SubjectAltNames, TLSSubjectAltName{DNSName: string(san.Hostname)})
+		case gatewayv1.URISubjectAltNameType:
+			ret.SubjectAltNames = append(ret.SubjectAltNames, TLSSubjectAltName{UniformResourceIdentifier: string(san.URI)})
+		}
+	}
+	// As with BackendTLSPolicy, the hostname is used to authenticate the
+	// backends unless SubjectAltNames are specified.
+	if len(ret.SubjectAltNames) == 0 && ret.SNI != "" && validatesCertificate(tls) {
+		ret.SubjectAltNames = []TLSSubjectAltName{{DNSName: ret.SNI}}
+	}
+	return ret
+}
+
+func validatesCertificate(tls *networkingv1.BackendTLSConfig) bool {
+	return len(tls.CACertificateRefs) > 0 || tls.WellKnownCACertificates != nil
+}
+
+// RenderBackendAuthentication returns the BackendAuthenticationConfig and,
+// if CA certificates are used, the TrustConfig that validate the certificate
+// of the backends. caCertificates are the certificates returned by
+// ResolveCACertificates. It returns nil if the certificate of the backends is
+// not validated.
+func RenderBackendAuthentication(tls *networkingv1.BackendTLSConfig, caCertificates []string) (*BackendAuthenticationConfig, *TrustConfig) {
+	if tls == nil || !validatesCertificate(tls) {
+		return nil, nil
+	}
+	if tls.WellKnownCACertificates != nil {
+		return &BackendAuthenticationConfig{WellKnownRoots: "PUBLIC_ROOTS"}, nil
+	}
+	store := TrustStore{}
+	for _, cert := range caCertificates {
+		store.TrustAnchors = append(store.TrustAnchors, TrustAnchor{PemCertificate: cert})
+	}
+	return &BackendAuthenticationConfig{WellKnownRoots: "NONE"}, &TrustConfig{TrustStores: []TrustStore{store}}
+}
+
+// ResolveCACertificates reads the CA certificates from the ConfigMaps
+// referenced by the TLS configuration of the given policy. Each returned
+// element is a single PEM-encoded certificate. Any failure is returned as a
+// *policyref.RefError.
+func ResolveCACertificates(policy *networkingv1.GCPBackendPolicy, configMaps corev1listers.ConfigMapLister) ([]string, error) {
+	if policy.Spec.Default == nil || policy.Spec.Default.TLS == nil {
+		return nil, nil
+	}
+	var certs []string
+	for _, r := range policy.Spec.Default.TLS.CACertificateRefs {
+		ref := types.NamespacedName{Namespace: policy.Namespace, Name: string(r.Name)}
+		if r.Group != "" || r.Kind != "ConfigMap" {
+			return nil, &policyref.RefError{Reason: networkingv1.PolicyReasonInvalidRef, Ref: ref,
+				Message: fmt.Sprintf("unsupported CA certificate reference kind %s/%s", r.Group, r.Kind)}
+		}
+		cm, err := configMaps.ConfigMaps(ref.Namespace).Get(ref.Name)
+		if apierrors.IsNotFound(err) {
+			return nil, &policyref.RefError{Reason: networkingv1.PolicyReasonInvalidRef, Ref: ref, Message: fmt.Sprintf("ConfigMap %s not found", ref)}
+		}
+		if err != nil {
+			return nil, &policyref.RefError{Reason: networkingv1.PolicyReasonInvalidRef, Ref: ref, Message: fmt.Sprintf("failed to get ConfigMap %s", ref), Err: err}
+		}
+		parsed, err := splitCertificates([]byte(cm.Data[CACertificateKey]))
+		if err != nil {
+			return nil, &policyref.RefError{Reason: networkingv1.PolicyReasonInvalidRef, Ref: ref,
+				Message: fmt.Sprintf("ConfigMap %s does not contain a valid %q key", ref, CACertificateKey), Err: err}
+		}
+		certs = append(certs, parsed...)
+	}
+	return certs, nil
+}
+
+// splitCertificates splits a PEM bundle into its certificates and checks that
+// each of them can be parsed.
+func splitCertificates(bundle []byte) ([]string, error) {
+	var certs []string
+	for {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, err
+		}
+		certs = append(certs, string(pem.EncodeToMemory(block)))
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM-encoded certificate found")
+	}
+	return certs, nil
+}
+
+// BackendTLSFromPolicy converts the validation of a Gateway API
+// BackendTLSPolicy into the equivalent BackendTLSConfig.
+func BackendTLSFromPolicy(v *gatewayv1.BackendTLSPolicyValidation) *networkingv1.BackendTLSConfig {
+	if v == nil {
+		return nil
+	}
+	hostname := v.Hostname
+	ret := &networkingv1.BackendTLSConfig{
+		Hostname:          &hostname,
+		SubjectAltNames:   v.SubjectAltNames,
+		CACertificateRefs: v.CACertificateRefs,
+	}
+	if v.WellKnownCACertificates != nil && *v.WellKnownCACertificates != "" {
+		ret.WellKnownCACertificates = v.WellKnownCACertificates
+	}
+	return ret
+}
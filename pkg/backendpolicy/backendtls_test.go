@@ -0,0 +1,302 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/policyref"
+)
+
+func TestRenderTLSSettings(t *testing.T) {
+	system := gatewayv1.WellKnownCACertificatesSystem
+	for _, tc := range []struct {
+		desc string
+		tls  *networkingv1.BackendTLSConfig
+		want *TLSSettings
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc: "sni without validation",
+			tls:  &networkingv1.BackendTLSConfig{Hostname: ptr.To(gatewayv1.PreciseHostname("backend.example.com"))},
+			want: &TLSSettings{SNI: "backend.example.com"},
+		},
+		{
+			desc: "hostname is used for validation",
+			tls: &networkingv1.BackendTLSConfig{
+				Hostname:                ptr.To(gatewayv1.PreciseHostname("backend.example.com")),
+				WellKnownCACertificates: &system,
+			},
+			want: &TLSSettings{SNI: "backend.example.com", SubjectAltNames: []TLSSubjectAltName{{DNSName: "backend.example.com"}}},
+		},
+		{
+			desc: "subject alt names",
+			tls: &networkingv1.BackendTLSConfig{
+				Hostname: ptr.To(gatewayv1.PreciseHostname("backend.example.com")),
+				SubjectAltNames: []gatewayv1.SubjectAltName{
+					{Type: gatewayv1.HostnameSubjectAltNameType, Hostname: "*.example.com"},
+					{Type: gatewayv1.URISubjectAltNameType, URI: "spiffe://example.com/ns/app/sa/backend"},
+				},
+				CACertificateRefs: []gatewayv1.LocalObjectReference{{Kind: "ConfigMap", Name: "ca"}},
+			},
+			want: &TLSSettings{SNI: "backend.example.com", SubjectAltNames: []TLSSubjectAltName{
+				{DNSName: "*.example.com"},
+				{UniformResourceIdentifier: "spiffe://example.com/ns/app/sa/backend"},
+			}},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := renderTLSSettings(tc.tls); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("renderTLSSettings() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderBackendAuthentication(t *testing.T) {
+	system := gatewayv1.WellKnownCACertificatesSystem
+	for _, tc := range []struct {
+		desc      string
+		tls       *networkingv1.BackendTLSConfig
+		certs     []string
+		want      *BackendAuthenticationConfig
+		wantTrust *TrustConfig
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc: "no validation",
+			tls:  &networkingv1.BackendTLSConfig{Hostname: ptr.To(gatewayv1.PreciseHostname("backend.example.com"))},
+		},
+		{
+			desc: "well known CA certificates",
+			tls:  &networkingv1.BackendTLSConfig{WellKnownCACertificates: &system},
+			want: &BackendAuthenticationConfig{WellKnownRoots: "PUBLIC_ROOTS"},
+		},
+		{
+			desc:  "CA certificates",
+			tls:   &networkingv1.BackendTLSConfig{CACertificateRefs: []gatewayv1.LocalObjectReference{{Kind: "ConfigMap", Name: "ca"}}},
+			certs: []string{"cert1", "cert2"},
+			want:  &BackendAuthenticationConfig{WellKnownRoots: "NONE"},
+			wantTrust: &TrustConfig{TrustStores: []TrustStore{{TrustAnchors: []TrustAnchor{
+				{PemCertificate: "cert1"},
+				{PemCertificate: "cert2"},
+			}}}},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, gotTrust := RenderBackendAuthentication(tc.tls, tc.certs)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("RenderBackendAuthentication() config = %+v, want %+v", got, tc.want)
+			}
+			if !reflect.DeepEqual(gotTrust, tc.wantTrust) {
+				t.Errorf("RenderBackendAuthentication() trust config = %+v, want %+v", gotTrust, tc.wantTrust)
+			}
+		})
+	}
+}
+
+func TestResolveCACertificates(t *testing.T) {
+	cert1, cert2 := testCertificate(t, "ca1"), testCertificate(t, "ca2")
+	key := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")}))
+	invalid := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("invalid")}))
+	configMaps := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, cm := range []*corev1.ConfigMap{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "ca"}, Data: map[string]string{"ca.crt": cert1}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "bundle"}, Data: map[string]string{"ca.crt": cert1 + key + cert2}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "invalid"}, Data: map[string]string{"ca.crt": cert1 + invalid}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "nocert"}, Data: map[string]string{"ca.crt": key}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "nokey"}, Data: map[string]string{"tls.crt": cert1}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "other"}, Data: map[string]string{"ca.crt": cert2}},
+	} {
+		configMaps.Add(cm)
+	}
+
+	for _, tc := range []struct {
+		desc       string
+		tls        *networkingv1.BackendTLSConfig
+		want       []string
+		wantReason networkingv1.PolicyConditionReason
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc: "no CA certificate refs",
+			tls:  &networkingv1.BackendTLSConfig{Hostname: ptr.To(gatewayv1.PreciseHostname("backend.example.com"))},
+		},
+		{
+			desc: "single certificate",
+			tls:  caCertificateRefs("ca"),
+			want: []string{cert1},
+		},
+		{
+			desc: "bundle is split and other blocks are skipped",
+			tls:  caCertificateRefs("bundle"),
+			want: []string{cert1, cert2},
+		},
+		{
+			desc: "multiple refs",
+			tls:  caCertificateRefs("ca", "bundle"),
+			want: []string{cert1, cert1, cert2},
+		},
+		{
+			desc:       "missing ConfigMap",
+			tls:        caCertificateRefs("missing"),
+			wantReason: networkingv1.PolicyReasonInvalidRef,
+		},
+		{
+			desc:       "ConfigMap in another namespace",
+			tls:        caCertificateRefs("other"),
+			wantReason: networkingv1.PolicyReasonInvalidRef,
+		},
+		{
+			desc: "unsupported kind",
+			tls: &networkingv1.BackendTLSConfig{
+				CACertificateRefs: []gatewayv1.LocalObjectReference{{Kind: "Secret", Name: "ca"}},
+			},
+			wantReason: networkingv1.PolicyReasonInvalidRef,
+		},
+		{
+			desc:       "unparsable certificate",
+			tls:        caCertificateRefs("invalid"),
+			wantReason: networkingv1.PolicyReasonInvalidRef,
+		},
+		{
+			desc:       "no certificate",
+			tls:        caCertificateRefs("nocert"),
+			wantReason: networkingv1.PolicyReasonInvalidRef,
+		},
+		{
+			desc:       "missing key",
+			tls:        caCertificateRefs("nokey"),
+			wantReason: networkingv1.PolicyReasonInvalidRef,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			policy := &networkingv1.GCPBackendPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "policy"},
+				Spec:       networkingv1.GCPBackendPolicySpec{Default: &networkingv1.GCPBackendPolicyConfig{TLS: tc.tls}},
+			}
+			got, err := ResolveCACertificates(policy, corev1listers.NewConfigMapLister(configMaps))
+			if tc.wantReason != "" {
+				var refErr *policyref.RefError
+				if !errors.As(err, &refErr) || refErr.Reason != tc.wantReason {
+					t.Fatalf("ResolveCACertificates() = %v, want RefError with reason %s", err, tc.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveCACertificates() = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ResolveCACertificates() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackendTLSFromPolicy(t *testing.T) {
+	system := gatewayv1.WellKnownCACertificatesSystem
+	empty := gatewayv1.WellKnownCACertificatesType("")
+	hostname := gatewayv1.PreciseHostname("backend.example.com")
+	refs := []gatewayv1.LocalObjectReference{{Kind: "ConfigMap", Name: "ca"}}
+	sans := []gatewayv1.SubjectAltName{{Type: gatewayv1.HostnameSubjectAltNameType, Hostname: "*.example.com"}}
+	for _, tc := range []struct {
+		desc string
+		v    *gatewayv1.BackendTLSPolicyValidation
+		want *networkingv1.BackendTLSConfig
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc: "CA certificates",
+			v:    &gatewayv1.BackendTLSPolicyValidation{Hostname: hostname, CACertificateRefs: refs, SubjectAltNames: sans},
+			want: &networkingv1.BackendTLSConfig{Hostname: &hostname, CACertificateRefs: refs, SubjectAltNames: sans},
+		},
+		{
+			desc: "well known CA certificates",
+			v:    &gatewayv1.BackendTLSPolicyValidation{Hostname: hostname, WellKnownCACertificates: &system},
+			want: &networkingv1.BackendTLSConfig{Hostname: &hostname, WellKnownCACertificates: &system},
+		},
+		{
+			desc: "empty well known CA certificates",
+			v:    &gatewayv1.BackendTLSPolicyValidation{Hostname: hostname, CACertificateRefs: refs, WellKnownCACertificates: &empty},
+			want: &networkingv1.BackendTLSConfig{Hostname: &hostname, CACertificateRefs: refs},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := BackendTLSFromPolicy(tc.v); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("BackendTLSFromPolicy() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func caCertificateRefs(names ...string) *networkingv1.BackendTLSConfig {
+	tls := &networkingv1.BackendTLSConfig{}
+	for _, name := range names {
+		tls.CACertificateRefs = append(tls.CACertificateRefs, gatewayv1.LocalObjectReference{Kind: "ConfigMap", Name: gatewayv1.ObjectName(name)})
+	}
+	return tls
+}
+
+// testCertificate returns a PEM-encoded self-signed CA certificate with the
+// given common name.
+func testCertificate(t *testing.T, commonName string) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
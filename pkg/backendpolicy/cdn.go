@@ -0,0 +1,202 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1listers "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/policyref"
+)
+
+// SignedURLKeySecretKey is the key of the signed URL key in the Secret
+// referenced by CDNSignedURLKey.
+const SignedURLKeySecretKey = "key"
+
+// signedURLKeyLength is the length in bytes of a Cloud CDN signed URL key.
+const signedURLKeyLength = 16
+
+// CDNPolicy is the cdnPolicy of a BackendService in the compute API.
+type CDNPolicy struct {
+	CacheMode               string                  `json:"cacheMode,omitempty"`
+	DefaultTTL              *int32                  `json:"defaultTtl,omitempty"`
+	MaxTTL                  *int32                  `json:"maxTtl,omitempty"`
+	ClientTTL               *int32                  `json:"clientTtl,omitempty"`
+	NegativeCaching         *bool                   `json:"negativeCaching,omitempty"`
+	NegativeCachingPolicy   []NegativeCachingPolicy `json:"negativeCachingPolicy,omitempty"`
+	CacheKeyPolicy          *CacheKeyPolicy         `json:"cacheKeyPolicy,omitempty"`
+	SignedURLCacheMaxAgeSec *int64                  `json:"signedUrlCacheMaxAgeSec,omitempty,string"`
+	ServeWhileStale         *int32                  `json:"serveWhileStale,omitempty"`
+	RequestCoalescing       *bool                   `json:"requestCoalescing,omitempty"`
+}
+
+// NegativeCachingPolicy is a cdnPolicy.negativeCachingPolicy entry in the
+// compute API.
+type NegativeCachingPolicy struct {
+	Code int32 `json:"code"`
+	TTL  int32 `json:"ttl"`
+}
+
+// CacheKeyPolicy is the cdnPolicy.cacheKeyPolicy in the compute API.
+type CacheKeyPolicy struct {
+	IncludeProtocol      *bool    `json:"includeProtocol,omitempty"`
+	IncludeHost          *bool    `json:"includeHost,omitempty"`
+	IncludeQueryString   *bool    `json:"includeQueryString,omitempty"`
+	QueryStringWhitelist []string `json:"queryStringWhitelist,omitempty"`
+	QueryStringBlacklist []string `json:"queryStringBlacklist,omitempty"`
+	IncludeHTTPHeaders   []string `json:"includeHttpHeaders,omitempty"`
+	IncludeNamedCookies  []string `json:"includeNamedCookies,omitempty"`
+}
+
+// SignedURLKey is a resolved Cloud CDN signed URL key. It is applied with the
+// addSignedUrlKey method of the BackendService.
+type SignedURLKey struct {
+	KeyName  string `json:"keyName"`
+	KeyValue string `json:"keyValue"`
+}
+
+// renderCDN returns the enableCDN and cdnPolicy fields of the BackendService
+// for the given configuration. Both are nil if cfg is nil. The cdnPolicy is
+// nil if Cloud CDN is disabled.
+func renderCDN(cfg *networkingv1.CDNConfig) (*bool, *CDNPolicy, error) {
+	if cfg == nil {
+		return nil, nil, nil
+	}
+	enabled := cfg.Enabled != nil && *cfg.Enabled
+	if !enabled {
+		return &enabled, nil, nil
+	}
+	p := &CDNPolicy{
+		NegativeCaching:   cfg.NegativeCaching,
+		RequestCoalescing: cfg.RequestCoalescing,
+	}
+	if cfg.CacheMode != nil {
+		p.CacheMode = string(*cfg.CacheMode)
+	}
+	var err error
+	if p.DefaultTTL, err = toSeconds32(cfg.DefaultTTL); err != nil {
+		return nil, nil, fmt.Errorf("cdn.defaultTTL: %w", err)
+	}
+	if p.MaxTTL, err = toSeconds32(cfg.MaxTTL); err != nil {
+		return nil, nil, fmt.Errorf("cdn.maxTTL: %w", err)
+	}
+	if p.ClientTTL, err = toSeconds32(cfg.ClientTTL); err != nil {
+		return nil, nil, fmt.Errorf("cdn.clientTTL: %w", err)
+	}
+	if p.ServeWhileStale, err = toSeconds32(cfg.ServeWhileStale); err != nil {
+		return nil, nil, fmt.Errorf("cdn.serveWhileStale: %w", err)
+	}
+	if cfg.SignedURLCacheMaxAge != nil {
+		secs, err := toSeconds(*cfg.SignedURLCacheMaxAge)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cdn.signedURLCacheMaxAge: %w", err)
+		}
+		p.SignedURLCacheMaxAgeSec = &secs
+	}
+	for i, ncp := range cfg.NegativeCachingPolicy {
+		ttl, err := toSeconds32(&ncp.TTL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cdn.negativeCachingPolicy[%d].ttl: %w", i, err)
+		}
+		p.NegativeCachingPolicy = append(p.NegativeCachingPolicy, NegativeCachingPolicy{Code: ncp.Code, TTL: *ttl})
+	}
+	if ckp := cfg.CacheKeyPolicy; ckp != nil {
+		p.CacheKeyPolicy = &CacheKeyPolicy{
+			IncludeProtocol:      ckp.IncludeProtocol,
+			IncludeHost:          ckp.IncludeHost,
+			IncludeQueryString:   ckp.IncludeQueryString,
+			QueryStringWhitelist: ckp.QueryStringIncludeList,
+			QueryStringBlacklist: ckp.QueryStringExcludeList,
+			IncludeNamedCookies:  ckp.IncludeNamedCookies,
+		}
+		for _, h := range ckp.IncludeHTTPHeaders {
+			p.CacheKeyPolicy.IncludeHTTPHeaders = append(p.CacheKeyPolicy.IncludeHTTPHeaders, string(h))
+		}
+	}
+	return &enabled, p, nil
+}
+
+// ResolveSignedURLKeys reads the signed URL keys referenced by the CDN
+// configuration of the given policy. It returns nil if Cloud CDN is not
+// enabled. Any failure is returned as a *policyref.RefError.
+func ResolveSignedURLKeys(policy *networkingv1.GCPBackendPolicy, secrets corev1listers.SecretLister, grants gatewayv1beta1listers.ReferenceGrantLister) ([]SignedURLKey, error) {
+	if policy.Spec.Default == nil {
+		return nil, nil
+	}
+	cdn := policy.Spec.Default.CDN
+	if cdn == nil || cdn.Enabled == nil || !*cdn.Enabled {
+		return nil, nil
+	}
+	var keys []SignedURLKey
+	for _, k := range cdn.SignedURLKeys {
+		value, ref, err := readSecret(policy, k.SecretRef.Name, k.SecretRef.Namespace, SignedURLKeySecretKey, secrets, grants)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.TrimSpace(string(value))
+		if err := validateSignedURLKey(key); err != nil {
+			return nil, &policyref.RefError{Reason: networkingv1.PolicyReasonInvalidRef, Ref: ref,
+				Message: fmt.Sprintf("Secret %s does not contain a valid signed URL key for %s", ref, k.KeyName), Err: err}
+		}
+		keys = append(keys, SignedURLKey{KeyName: k.KeyName, KeyValue: key})
+	}
+	return keys, nil
+}
+
+// validateSignedURLKey checks that the given key is a base64url encoded
+// 128-bit value, with or without padding.
+func validateSignedURLKey(key string) error {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+	if err != nil {
+		return fmt.Errorf("must be base64url encoded: %w", err)
+	}
+	if len(raw) != signedURLKeyLength {
+		return fmt.Errorf("must be %d bytes, got %d", signedURLKeyLength, len(raw))
+	}
+	return nil
+}
+
+// toSeconds converts a Gateway API duration into whole seconds.
+func toSeconds(d gatewayv1.Duration) (int64, error) {
+	parsed, err := time.ParseDuration(string(d))
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", d, err)
+	}
+	if parsed%time.Second != 0 {
+		return 0, fmt.Errorf("duration %q must be in whole seconds", d)
+	}
+	return int64(parsed / time.Second), nil
+}
+
+func toSeconds32(d *gatewayv1.Duration) (*int32, error) {
+	if d == nil {
+		return nil, nil
+	}
+	secs, err := toSeconds(*d)
+	if err != nil {
+		return nil, err
+	}
+	ret := int32(secs)
+	return &ret, nil
+}
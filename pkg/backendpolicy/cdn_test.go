@@ -0,0 +1,216 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1beta1listers "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/policyref"
+)
+
+func TestRenderCDN(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		cdn     *networkingv1.CDNConfig
+		want    string
+		wantErr bool
+	}{
+		{
+			desc: "unset",
+			want: `{}`,
+		},
+		{
+			desc: "disabled",
+			cdn:  &networkingv1.CDNConfig{Enabled: ptr.To(false)},
+			want: `{"enableCDN":false}`,
+		},
+		{
+			desc: "full",
+			cdn: &networkingv1.CDNConfig{
+				Enabled:         ptr.To(true),
+				CacheMode:       ptr.To(networkingv1.CDNCacheModeCacheAllStatic),
+				DefaultTTL:      ptr.To(gatewayv1.Duration("1h")),
+				MaxTTL:          ptr.To(gatewayv1.Duration("24h")),
+				NegativeCaching: ptr.To(true),
+				NegativeCachingPolicy: []networkingv1.CDNNegativeCachingPolicy{
+					{Code: 404, TTL: "2m"},
+				},
+				CacheKeyPolicy: &networkingv1.CDNCacheKeyPolicy{
+					IncludeQueryString:     ptr.To(true),
+					QueryStringIncludeList: []string{"page"},
+					IncludeHTTPHeaders:     []networkingv1.HTTPHeaderName{"X-Device"},
+				},
+				SignedURLCacheMaxAge: ptr.To(gatewayv1.Duration("10m")),
+				ServeWhileStale:      ptr.To(gatewayv1.Duration("0s")),
+			},
+			want: `{"enableCDN":true,"cdnPolicy":{"cacheMode":"CACHE_ALL_STATIC","defaultTtl":3600,"maxTtl":86400,` +
+				`"negativeCaching":true,"negativeCachingPolicy":[{"code":404,"ttl":120}],` +
+				`"cacheKeyPolicy":{"includeQueryString":true,"queryStringWhitelist":["page"],"includeHttpHeaders":["X-Device"]},` +
+				`"signedUrlCacheMaxAgeSec":"600","serveWhileStale":0}}`,
+		},
+		{
+			desc:    "fractional seconds",
+			cdn:     &networkingv1.CDNConfig{Enabled: ptr.To(true), DefaultTTL: ptr.To(gatewayv1.Duration("1500ms"))},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			bs, err := Translate(&networkingv1.GCPBackendPolicyConfig{CDN: tc.cdn}, "p", "")
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Translate() = %v, want error %t", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := json.Marshal(bs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("Translate() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateSignedURLKey(t *testing.T) {
+	for key, wantErr := range map[string]bool{
+		"nZtRohdNF9m3cKM24IcK4w==": false,
+		"nZtRohdNF9m3cKM24IcK4w":   false,
+		"nZtRohdNF9m3cKM24IcK":     true,
+		"not base64!":              true,
+	} {
+		if err := validateSignedURLKey(key); (err != nil) != wantErr {
+			t.Errorf("validateSignedURLKey(%q) = %v, want error %t", key, err, wantErr)
+		}
+	}
+}
+
+func TestResolveSignedURLKeys(t *testing.T) {
+	const key = "nZtRohdNF9m3cKM24IcK4w=="
+	secrets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, s := range []*corev1.Secret{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "key"}, Data: map[string][]byte{"key": []byte(key + "\n")}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "key"}, Data: map[string][]byte{"key": []byte(key)}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "key"}, Data: map[string][]byte{"key": []byte(key)}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "short"}, Data: map[string][]byte{"key": []byte("c2hvcnQ=")}},
+	} {
+		secrets.Add(s)
+	}
+	grants := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	grants.Add(&gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "allow-app"},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{{Group: networkingv1.GroupName, Kind: "GCPBackendPolicy", Namespace: "app"}},
+			To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: "Secret"}},
+		},
+	})
+
+	for _, tc := range []struct {
+		desc       string
+		cdn        *networkingv1.CDNConfig
+		want       []SignedURLKey
+		wantReason networkingv1.PolicyConditionReason
+		wantRef    types.NamespacedName
+	}{
+		{
+			desc: "disabled",
+			cdn: &networkingv1.CDNConfig{
+				Enabled:       ptr.To(false),
+				SignedURLKeys: []networkingv1.CDNSignedURLKey{{KeyName: "k", SecretRef: networkingv1.SecretObjectReference{Name: "missing"}}},
+			},
+		},
+		{
+			desc: "local secret",
+			cdn:  cdnSignedURLKeys("key", ""),
+			want: []SignedURLKey{{KeyName: "k", KeyValue: key}},
+		},
+		{
+			desc: "granted cross namespace secret",
+			cdn:  cdnSignedURLKeys("key", "shared"),
+			want: []SignedURLKey{{KeyName: "k", KeyValue: key}},
+		},
+		{
+			desc:       "cross namespace secret without grant",
+			cdn:        cdnSignedURLKeys("key", "other"),
+			wantReason: networkingv1.PolicyReasonRefNotPermitted,
+			wantRef:    types.NamespacedName{Namespace: "other", Name: "key"},
+		},
+		{
+			desc:       "missing secret",
+			cdn:        cdnSignedURLKeys("missing", ""),
+			wantReason: networkingv1.PolicyReasonInvalidRef,
+			wantRef:    types.NamespacedName{Namespace: "app", Name: "missing"},
+		},
+		{
+			desc:       "invalid key",
+			cdn:        cdnSignedURLKeys("short", ""),
+			wantReason: networkingv1.PolicyReasonInvalidRef,
+			wantRef:    types.NamespacedName{Namespace: "app", Name: "short"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			policy := &networkingv1.GCPBackendPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "policy"},
+				Spec:       networkingv1.GCPBackendPolicySpec{Default: &networkingv1.GCPBackendPolicyConfig{CDN: tc.cdn}},
+			}
+			got, err := ResolveSignedURLKeys(policy, corev1listers.NewSecretLister(secrets), gatewayv1beta1listers.NewReferenceGrantLister(grants))
+			if tc.wantReason != "" {
+				var refErr *policyref.RefError
+				if !errors.As(err, &refErr) || refErr.Reason != tc.wantReason {
+					t.Fatalf("ResolveSignedURLKeys() = %v, want RefError with reason %s", err, tc.wantReason)
+				}
+				if refErr.Ref != tc.wantRef {
+					t.Errorf("ResolveSignedURLKeys() ref = %v, want %v", refErr.Ref, tc.wantRef)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveSignedURLKeys() = %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("ResolveSignedURLKeys() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("ResolveSignedURLKeys()[%d] = %v, want %v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func cdnSignedURLKeys(name, namespace string) *networkingv1.CDNConfig {
+	ref := networkingv1.SecretObjectReference{Name: name}
+	if namespace != "" {
+		ref.Namespace = ptr.To(namespace)
+	}
+	return &networkingv1.CDNConfig{Enabled: ptr.To(true), SignedURLKeys: []networkingv1.CDNSignedURLKey{{KeyName: "k", SecretRef: ref}}}
+}
@@ -0,0 +1,73 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"fmt"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// CircuitBreakers is the circuitBreakers of a BackendService in the compute API.
+type CircuitBreakers struct {
+	MaxConnections     *int32 `json:"maxConnections,omitempty"`
+	MaxPendingRequests *int32 `json:"maxPendingRequests,omitempty"`
+	MaxRequests        *int32 `json:"maxRequests,omitempty"`
+	MaxRetries         *int32 `json:"maxRetries,omitempty"`
+}
+
+// OutlierDetection is the outlierDetection of a BackendService in the compute API.
+type OutlierDetection struct {
+	ConsecutiveErrors          *int32    `json:"consecutiveErrors,omitempty"`
+	EnforcingConsecutiveErrors *int32    `json:"enforcingConsecutiveErrors,omitempty"`
+	Interval                   *Duration `json:"interval,omitempty"`
+	BaseEjectionTime           *Duration `json:"baseEjectionTime,omitempty"`
+	MaxEjectionPercent         *int32    `json:"maxEjectionPercent,omitempty"`
+}
+
+func renderCircuitBreakers(cb *networkingv1.CircuitBreakers) *CircuitBreakers {
+	if cb == nil {
+		return nil
+	}
+	return &CircuitBreakers{
+		MaxConnections:     cb.MaxConnections,
+		MaxPendingRequests: cb.MaxPendingRequests,
+		MaxRequests:        cb.MaxRequests,
+		MaxRetries:         cb.MaxRetries,
+	}
+}
+
+func renderOutlierDetection(od *networkingv1.OutlierDetection) (*OutlierDetection, error) {
+	if od == nil {
+		return nil, nil
+	}
+	interval, err := toDuration(od.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("outlierDetection.interval: %w", err)
+	}
+	baseEjectionTime, err := toDuration(od.BaseEjectionTime)
+	if err != nil {
+		return nil, fmt.Errorf("outlierDetection.baseEjectionTime: %w", err)
+	}
+	return &OutlierDetection{
+		ConsecutiveErrors:          od.ConsecutiveErrors,
+		EnforcingConsecutiveErrors: od.EnforcingConsecutiveErrors,
+		Interval:                   interval,
+		BaseEjectionTime:           baseEjectionTime,
+		MaxEjectionPercent:         od.MaxEjectionPercent,
+	}, nil
+}
@@ -0,0 +1,135 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+func TestToDuration(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    Duration
+		wantErr bool
+	}{
+		{in: "0s", want: Duration{}},
+		{in: "1s", want: Duration{Seconds: 1}},
+		{in: "500ms", want: Duration{Nanos: 500000000}},
+		{in: "1m30s", want: Duration{Seconds: 90}},
+		{in: "1h2m3s4ms", want: Duration{Seconds: 3723, Nanos: 4000000}},
+		{in: "", wantErr: true},
+		{in: "10", wantErr: true},
+		{in: "1d", wantErr: true},
+	} {
+		d := gatewayv1.Duration(tc.in)
+		got, err := toDuration(&d)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("toDuration(%q) = %+v, want error", tc.in, got)
+			}
+			continue
+		}
+		if err != nil || *got != tc.want {
+			t.Errorf("toDuration(%q) = %+v, %v, want %+v", tc.in, got, err, tc.want)
+		}
+	}
+	if got, err := toDuration(nil); got != nil || err != nil {
+		t.Errorf("toDuration(nil) = %+v, %v, want nil", got, err)
+	}
+}
+
+func TestRenderCircuitBreaking(t *testing.T) {
+	interval := gatewayv1.Duration("1500ms")
+	baseEjectionTime := gatewayv1.Duration("30s")
+	invalid := gatewayv1.Duration("soon")
+
+	for _, tc := range []struct {
+		desc    string
+		cfg     *networkingv1.GCPBackendPolicyConfig
+		want    string
+		wantErr bool
+	}{
+		{
+			desc: "unset",
+			cfg:  &networkingv1.GCPBackendPolicyConfig{},
+			want: `{}`,
+		},
+		{
+			desc: "circuit breakers",
+			cfg: &networkingv1.GCPBackendPolicyConfig{
+				CircuitBreakers: &networkingv1.CircuitBreakers{MaxConnections: ptr.To[int32](100), MaxRetries: ptr.To[int32](3)},
+			},
+			want: `{"circuitBreakers":{"maxConnections":100,"maxRetries":3}}`,
+		},
+		{
+			desc: "outlier detection",
+			cfg: &networkingv1.GCPBackendPolicyConfig{
+				OutlierDetection: &networkingv1.OutlierDetection{
+					ConsecutiveErrors:          ptr.To[int32](5),
+					EnforcingConsecutiveErrors: ptr.To[int32](0),
+					Interval:                   &interval,
+					BaseEjectionTime:           &baseEjectionTime,
+					MaxEjectionPercent:         ptr.To[int32](100),
+				},
+			},
+			want: `{"outlierDetection":{"consecutiveErrors":5,"enforcingConsecutiveErrors":0,"interval":{"seconds":"1","nanos":500000000},"baseEjectionTime":{"seconds":"30"},"maxEjectionPercent":100}}`,
+		},
+		{
+			desc:    "invalid interval",
+			cfg:     &networkingv1.GCPBackendPolicyConfig{OutlierDetection: &networkingv1.OutlierDetection{Interval: &invalid}},
+			wantErr: true,
+		},
+		{
+			desc:    "invalid baseEjectionTime",
+			cfg:     &networkingv1.GCPBackendPolicyConfig{OutlierDetection: &networkingv1.OutlierDetection{BaseEjectionTime: &invalid}},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			bs, err := Translate(tc.cfg, "project", "")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Translate() = %+v, want error", bs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Translate() = %v", err)
+			}
+			got, err := json.Marshal(struct {
+				CircuitBreakers  *CircuitBreakers  `json:"circuitBreakers,omitempty"`
+				OutlierDetection *OutlierDetection `json:"outlierDetection,omitempty"`
+			}{bs.CircuitBreakers, bs.OutlierDetection})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotJSON, wantJSON any
+			json.Unmarshal(got, &gotJSON)
+			json.Unmarshal([]byte(tc.want), &wantJSON)
+			if !reflect.DeepEqual(gotJSON, wantJSON) {
+				t.Errorf("Translate() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+const (
+	// RingHash is the ring hash locality load balancing algorithm.
+	RingHash = "RING_HASH"
+	// Maglev is the Maglev locality load balancing algorithm.
+	Maglev = "MAGLEV"
+)
+
+// IsHashingAlgorithm returns true if the given locality load balancing
+// algorithm selects endpoints by consistent hashing.
+func IsHashingAlgorithm(algorithm string) bool {
+	return algorithm == RingHash || algorithm == Maglev
+}
+
+// ConsistentHash is the consistentHash of a BackendService in the compute API.
+type ConsistentHash struct {
+	HTTPHeaderName  string      `json:"httpHeaderName,omitempty"`
+	HTTPCookie      *HTTPCookie `json:"httpCookie,omitempty"`
+	MinimumRingSize *int64      `json:"minimumRingSize,omitempty,string"`
+}
+
+// HTTPCookie is the consistentHash.httpCookie of a BackendService in the
+// compute API.
+type HTTPCookie struct {
+	Name string    `json:"name"`
+	Path string    `json:"path,omitempty"`
+	TTL  *Duration `json:"ttl,omitempty"`
+}
+
+// RenderConsistentHash converts the given ConsistentHashConfig into a compute
+// API ConsistentHash. It returns nil if cfg is nil.
+func RenderConsistentHash(cfg *networkingv1.ConsistentHashConfig) (*ConsistentHash, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	ret := &ConsistentHash{MinimumRingSize: cfg.MinimumRingSize}
+	if cfg.HTTPHeaderName != nil {
+		ret.HTTPHeaderName = string(*cfg.HTTPHeaderName)
+	}
+	if c := cfg.HTTPCookie; c != nil {
+		ttl, err := toDuration(c.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("consistentHash.httpCookie.ttl: %w", err)
+		}
+		ret.HTTPCookie = &HTTPCookie{Name: c.Name, TTL: ttl}
+		if c.Path != nil {
+			ret.HTTPCookie.Path = *c.Path
+		}
+	}
+	return ret, nil
+}
+
+// ValidateConsistentHash checks that the consistent hashing configured by a
+// GCPBackendPolicy can be honored by the locality load balancing algorithm of
+// the GCPTrafficDistributionPolicy that targets the same Service. td is nil if
+// no GCPTrafficDistributionPolicy targets the Service, in which case the
+// default ROUND_ROBIN algorithm is used.
+func ValidateConsistentHash(cfg *networkingv1.GCPBackendPolicyConfig, td *networkingv1.GCPTrafficDistributionPolicyConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if cfg == nil {
+		return allErrs
+	}
+	var path *field.Path
+	switch {
+	case cfg.ConsistentHash != nil:
+		path = fldPath.Child("consistentHash")
+	case cfg.SessionAffinity != nil && cfg.SessionAffinity.Type != nil &&
+		(*cfg.SessionAffinity.Type == "HEADER_FIELD" || *cfg.SessionAffinity.Type == "HTTP_COOKIE"):
+		path = fldPath.Child("sessionAffinity", "type")
+	default:
+		return allErrs
+	}
+	algorithm := "ROUND_ROBIN"
+	if td != nil && td.LocalityLbAlgorithm != nil {
+		algorithm = *td.LocalityLbAlgorithm
+	}
+	if !IsHashingAlgorithm(algorithm) {
+		allErrs = append(allErrs, field.Forbidden(path,
+			fmt.Sprintf("consistent hashing requires the %s or %s locality load balancing algorithm, but the Service uses %s", RingHash, Maglev, algorithm)))
+		return allErrs
+	}
+	if cfg.ConsistentHash != nil && cfg.ConsistentHash.MinimumRingSize != nil && algorithm != RingHash {
+		allErrs = append(allErrs, field.Forbidden(path.Child("minimumRingSize"),
+			fmt.Sprintf("minimumRingSize requires the %s locality load balancing algorithm, but the Service uses %s", RingHash, algorithm)))
+	}
+	return allErrs
+}
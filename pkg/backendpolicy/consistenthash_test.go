@@ -0,0 +1,175 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+func TestRenderConsistentHash(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		cfg     *networkingv1.ConsistentHashConfig
+		want    string
+		wantErr bool
+	}{
+		{
+			desc: "unset",
+			want: `null`,
+		},
+		{
+			desc: "header",
+			cfg: &networkingv1.ConsistentHashConfig{
+				HTTPHeaderName:  ptr.To(networkingv1.HTTPHeaderName("X-User")),
+				MinimumRingSize: ptr.To[int64](2048),
+			},
+			want: `{"httpHeaderName":"X-User","minimumRingSize":"2048"}`,
+		},
+		{
+			desc: "cookie",
+			cfg: &networkingv1.ConsistentHashConfig{
+				HTTPCookie: &networkingv1.ConsistentHashHTTPCookie{
+					Name: "session",
+					Path: ptr.To("/cart"),
+					TTL:  ptr.To(gatewayv1.Duration("1h30m")),
+				},
+			},
+			want: `{"httpCookie":{"name":"session","path":"/cart","ttl":{"seconds":"5400"}}}`,
+		},
+		{
+			desc: "session cookie",
+			cfg: &networkingv1.ConsistentHashConfig{
+				HTTPCookie: &networkingv1.ConsistentHashHTTPCookie{Name: "session"},
+			},
+			want: `{"httpCookie":{"name":"session"}}`,
+		},
+		{
+			desc: "invalid cookie ttl",
+			cfg: &networkingv1.ConsistentHashConfig{
+				HTTPCookie: &networkingv1.ConsistentHashHTTPCookie{Name: "session", TTL: ptr.To(gatewayv1.Duration("1d"))},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ch, err := RenderConsistentHash(tc.cfg)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("RenderConsistentHash() = %v, want error %t", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := json.Marshal(ch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("RenderConsistentHash() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateConsistentHash(t *testing.T) {
+	header := &networkingv1.ConsistentHashConfig{HTTPHeaderName: ptr.To(networkingv1.HTTPHeaderName("X-User"))}
+	ring := &networkingv1.ConsistentHashConfig{HTTPHeaderName: ptr.To(networkingv1.HTTPHeaderName("X-User")), MinimumRingSize: ptr.To[int64](2048)}
+	for _, tc := range []struct {
+		desc      string
+		cfg       *networkingv1.GCPBackendPolicyConfig
+		algorithm *string
+		noTD      bool
+		wantErr   string
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc: "no hashing",
+			cfg:  &networkingv1.GCPBackendPolicyConfig{SessionAffinity: &networkingv1.SessionAffinityConfig{Type: ptr.To("CLIENT_IP")}},
+			noTD: true,
+		},
+		{
+			desc:      "ring hash",
+			cfg:       &networkingv1.GCPBackendPolicyConfig{ConsistentHash: ring},
+			algorithm: ptr.To(RingHash),
+		},
+		{
+			desc:      "maglev",
+			cfg:       &networkingv1.GCPBackendPolicyConfig{ConsistentHash: header},
+			algorithm: ptr.To(Maglev),
+		},
+		{
+			desc:    "default round robin",
+			cfg:     &networkingv1.GCPBackendPolicyConfig{ConsistentHash: header},
+			noTD:    true,
+			wantErr: "spec.default.consistentHash: Forbidden: consistent hashing requires the RING_HASH or MAGLEV locality load balancing algorithm, but the Service uses ROUND_ROBIN",
+		},
+		{
+			desc:    "traffic distribution policy without algorithm",
+			cfg:     &networkingv1.GCPBackendPolicyConfig{ConsistentHash: header},
+			wantErr: "but the Service uses ROUND_ROBIN",
+		},
+		{
+			desc:      "least request",
+			cfg:       &networkingv1.GCPBackendPolicyConfig{ConsistentHash: header},
+			algorithm: ptr.To("LEAST_REQUEST"),
+			wantErr:   "but the Service uses LEAST_REQUEST",
+		},
+		{
+			desc:      "header field affinity",
+			cfg:       &networkingv1.GCPBackendPolicyConfig{SessionAffinity: &networkingv1.SessionAffinityConfig{Type: ptr.To("HEADER_FIELD")}},
+			algorithm: ptr.To("RANDOM"),
+			wantErr:   "spec.default.sessionAffinity.type: Forbidden",
+		},
+		{
+			desc:      "http cookie affinity",
+			cfg:       &networkingv1.GCPBackendPolicyConfig{SessionAffinity: &networkingv1.SessionAffinityConfig{Type: ptr.To("HTTP_COOKIE")}},
+			algorithm: ptr.To(Maglev),
+		},
+		{
+			desc:      "minimum ring size with maglev",
+			cfg:       &networkingv1.GCPBackendPolicyConfig{ConsistentHash: ring},
+			algorithm: ptr.To(Maglev),
+			wantErr:   "spec.default.consistentHash.minimumRingSize: Forbidden: minimumRingSize requires the RING_HASH locality load balancing algorithm, but the Service uses MAGLEV",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var td *networkingv1.GCPTrafficDistributionPolicyConfig
+			if !tc.noTD {
+				td = &networkingv1.GCPTrafficDistributionPolicyConfig{LocalityLbAlgorithm: tc.algorithm}
+			}
+			errs := ValidateConsistentHash(tc.cfg, td, field.NewPath("spec", "default"))
+			if tc.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("ValidateConsistentHash() = %v, want no errors", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tc.wantErr) {
+				t.Errorf("ValidateConsistentHash() = %v, want one error containing %q", errs, tc.wantErr)
+			}
+		})
+	}
+}
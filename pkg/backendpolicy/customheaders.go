@@ -0,0 +1,228 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// requestHeaderVariables are the variables that can be used in the values of
+// custom request headers.
+// See https://cloud.google.com/load-balancing/docs/https/custom-headers#variables
+var requestHeaderVariables = map[string]bool{
+	"client_asn":                     true,
+	"client_cert_chain_verified":     true,
+	"client_cert_dnsname_sans":       true,
+	"client_cert_error":              true,
+	"client_cert_issuer_dn":          true,
+	"client_cert_leaf":               true,
+	"client_cert_chain":              true,
+	"client_cert_present":            true,
+	"client_cert_serial_number":      true,
+	"client_cert_sha256_fingerprint": true,
+	"client_cert_spiffe_id":          true,
+	"client_cert_subject_dn":         true,
+	"client_cert_uri_sans":           true,
+	"client_cert_valid_not_after":    true,
+	"client_cert_valid_not_before":   true,
+	"client_city":                    true,
+	"client_city_lat_long":           true,
+	"client_encrypted":               true,
+	"client_ip_address":              true,
+	"client_port":                    true,
+	"client_protocol":                true,
+	"client_region":                  true,
+	"client_region_subdivision":      true,
+	"client_rtt_msec":                true,
+	"server_ip_address":              true,
+	"server_port":                    true,
+	"tls_cipher_suite":               true,
+	"tls_ja3_fingerprint":            true,
+	"tls_ja4_fingerprint":            true,
+	"tls_sni_hostname":               true,
+	"tls_version":                    true,
+}
+
+// responseHeaderVariables are the variables that can be used in the values of
+// custom response headers: all request header variables plus the Cloud CDN and
+// CORS variables that are only available in responses.
+var responseHeaderVariables = func() map[string]bool {
+	ret := map[string]bool{
+		"cdn_cache_id":          true,
+		"cdn_cache_status":      true,
+		"origin_request_header": true,
+	}
+	for v := range requestHeaderVariables {
+		ret[v] = true
+	}
+	return ret
+}()
+
+// reservedHeaderPrefixes are the lower case prefixes of header names that
+// cannot be used for custom headers.
+var reservedHeaderPrefixes = []string{"x-google", "x-gfe", "x-goog-", "x-amz-"}
+
+// reservedHeaders are the lower case header names that cannot be used for
+// custom headers.
+var reservedHeaders = map[string]bool{
+	"host":              true,
+	"authority":         true,
+	"connection":        true,
+	"content-length":    true,
+	"keep-alive":        true,
+	"proxy-connection":  true,
+	"te":                true,
+	"trailer":           true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+	"via":               true,
+	"x-forwarded-for":   true,
+	"x-user-ip":         true,
+}
+
+// RequestHeaderVariables returns the sorted names of the variables that can be
+// used in custom request header values.
+func RequestHeaderVariables() []string {
+	return sortedVariables(requestHeaderVariables)
+}
+
+// ResponseHeaderVariables returns the sorted names of the variables that can
+// be used in custom response header values.
+func ResponseHeaderVariables() []string {
+	return sortedVariables(responseHeaderVariables)
+}
+
+func sortedVariables(variables map[string]bool) []string {
+	ret := make([]string, 0, len(variables))
+	for v := range variables {
+		ret = append(ret, v)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// ParseRequestHeaderValue returns the variables used in the given custom
+// request header value, in order of appearance. It returns an error if the
+// value contains a variable that is not available in request headers or
+// unbalanced braces.
+func ParseRequestHeaderValue(value string) ([]string, error) {
+	return parseHeaderValue(value, requestHeaderVariables)
+}
+
+// ParseResponseHeaderValue returns the variables used in the given custom
+// response header value, in order of appearance. It returns an error if the
+// value contains an unknown variable or unbalanced braces.
+func ParseResponseHeaderValue(value string) ([]string, error) {
+	return parseHeaderValue(value, responseHeaderVariables)
+}
+
+func parseHeaderValue(value string, variables map[string]bool) ([]string, error) {
+	var vars []string
+	for rest := value; rest != ""; {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			break
+		}
+		if rest[open] == '}' {
+			return nil, fmt.Errorf("unexpected '}' in %q", value)
+		}
+		end := strings.IndexAny(rest[open+1:], "{}")
+		if end < 0 || rest[open+1+end] != '}' {
+			return nil, fmt.Errorf("unterminated variable in %q", value)
+		}
+		name := rest[open+1 : open+1+end]
+		if !variables[name] {
+			if responseHeaderVariables[name] {
+				return nil, fmt.Errorf("variable {%s} can only be used in custom response headers", name)
+			}
+			return nil, fmt.Errorf("unknown variable {%s}", name)
+		}
+		vars = append(vars, name)
+		rest = rest[open+1+end+1:]
+	}
+	return vars, nil
+}
+
+// ValidateHeaderName returns an error if the given header name is reserved by
+// the load balancer.
+func ValidateHeaderName(name string) error {
+	lower := strings.ToLower(name)
+	if reservedHeaders[lower] {
+		return fmt.Errorf("header %s is reserved", name)
+	}
+	for _, prefix := range reservedHeaderPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return fmt.Errorf("headers with the prefix %q are reserved", prefix)
+		}
+	}
+	return nil
+}
+
+// ValidateCustomHeaders validates the custom request and response headers of
+// the given configuration. Header names must not be reserved or repeated, and
+// header values must only use the variables supported by their direction.
+func ValidateCustomHeaders(cfg *networkingv1.GCPBackendPolicyConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if cfg == nil {
+		return allErrs
+	}
+	allErrs = append(allErrs, validateCustomHeaderList(cfg.CustomRequestHeaders, requestHeaderVariables, fldPath.Child("customRequestHeaders"))...)
+	allErrs = append(allErrs, validateCustomHeaderList(cfg.CustomResponseHeaders, responseHeaderVariables, fldPath.Child("customResponseHeaders"))...)
+	return allErrs
+}
+
+func validateCustomHeaderList(headers []networkingv1.CustomHeader, variables map[string]bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := map[string]bool{}
+	for i, h := range headers {
+		idxPath := fldPath.Index(i)
+		name := string(h.Name)
+		if err := ValidateHeaderName(name); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), name, err.Error()))
+		}
+		// Header names are case-insensitive, which the listMapKey of the CRD
+		// does not account for.
+		if lower := strings.ToLower(name); seen[lower] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), name))
+		} else {
+			seen[lower] = true
+		}
+		if _, err := parseHeaderValue(h.Value, variables); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("value"), h.Value, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// renderCustomHeaders converts the given headers into the "Name: value" form
+// of the compute API. It returns nil if headers is nil.
+func renderCustomHeaders(headers []networkingv1.CustomHeader) []string {
+	if headers == nil {
+		return nil
+	}
+	ret := make([]string, 0, len(headers))
+	for _, h := range headers {
+		ret = append(ret, fmt.Sprintf("%s: %s", h.Name, h.Value))
+	}
+	return ret
+}
@@ -0,0 +1,147 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+func TestParseHeaderValue(t *testing.T) {
+	for _, tc := range []struct {
+		value           string
+		wantVars        []string
+		wantRequestErr  bool
+		wantResponseErr bool
+	}{
+		{value: "static"},
+		{value: "{client_region}", wantVars: []string{"client_region"}},
+		{value: "{client_region},{client_city}", wantVars: []string{"client_region", "client_city"}},
+		{value: "sni={tls_sni_hostname};v={tls_version}", wantVars: []string{"tls_sni_hostname", "tls_version"}},
+		{value: "{cdn_cache_status}", wantVars: []string{"cdn_cache_status"}, wantRequestErr: true},
+		{value: "{cdn_cache_id}", wantVars: []string{"cdn_cache_id"}, wantRequestErr: true},
+		{value: "{origin_request_header}", wantVars: []string{"origin_request_header"}, wantRequestErr: true},
+		{value: "{unknown}", wantRequestErr: true, wantResponseErr: true},
+		{value: "{client_region", wantRequestErr: true, wantResponseErr: true},
+		{value: "client_region}", wantRequestErr: true, wantResponseErr: true},
+		{value: "{{client_region}}", wantRequestErr: true, wantResponseErr: true},
+		{value: "{}", wantRequestErr: true, wantResponseErr: true},
+	} {
+		for _, p := range []struct {
+			name    string
+			parse   func(string) ([]string, error)
+			wantErr bool
+		}{
+			{"ParseRequestHeaderValue", ParseRequestHeaderValue, tc.wantRequestErr},
+			{"ParseResponseHeaderValue", ParseResponseHeaderValue, tc.wantResponseErr},
+		} {
+			vars, err := p.parse(tc.value)
+			if gotErr := err != nil; gotErr != p.wantErr {
+				t.Errorf("%s(%q) = %v, want error %t", p.name, tc.value, err, p.wantErr)
+				continue
+			}
+			if err != nil {
+				continue
+			}
+			if !reflect.DeepEqual(vars, tc.wantVars) {
+				t.Errorf("%s(%q) = %v, want %v", p.name, tc.value, vars, tc.wantVars)
+			}
+		}
+	}
+}
+
+func TestHeaderVariables(t *testing.T) {
+	request := map[string]bool{}
+	for _, v := range RequestHeaderVariables() {
+		request[v] = true
+	}
+	for _, v := range []string{"cdn_cache_id", "cdn_cache_status", "origin_request_header"} {
+		if request[v] {
+			t.Errorf("RequestHeaderVariables() contains response-only variable %s", v)
+		}
+	}
+	response := map[string]bool{}
+	for _, v := range ResponseHeaderVariables() {
+		response[v] = true
+	}
+	for v := range request {
+		if !response[v] {
+			t.Errorf("ResponseHeaderVariables() does not contain request variable %s", v)
+		}
+	}
+	if got, want := len(response), len(request)+3; got != want {
+		t.Errorf("len(ResponseHeaderVariables()) = %d, want %d", got, want)
+	}
+}
+
+func TestValidateCustomHeaders(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		request  []networkingv1.CustomHeader
+		response []networkingv1.CustomHeader
+		want     []string
+	}{
+		{
+			desc: "valid",
+			request: []networkingv1.CustomHeader{
+				{Name: "X-Client-Geo", Value: "{client_region},{client_city}"},
+				{Name: "X-SNI", Value: "{tls_sni_hostname}"},
+			},
+			response: []networkingv1.CustomHeader{{Name: "X-Cache-Status", Value: "{cdn_cache_status}"}},
+		},
+		{
+			desc:    "reserved",
+			request: []networkingv1.CustomHeader{{Name: "Host", Value: "x"}, {Name: "X-Goog-Foo", Value: "x"}},
+			want:    []string{"spec.default.customRequestHeaders[0].name", "spec.default.customRequestHeaders[1].name"},
+		},
+		{
+			desc:     "duplicate",
+			response: []networkingv1.CustomHeader{{Name: "X-Geo", Value: "a"}, {Name: "x-geo", Value: "b"}},
+			want:     []string{"spec.default.customResponseHeaders[1].name"},
+		},
+		{
+			desc:    "unknown variable",
+			request: []networkingv1.CustomHeader{{Name: "X-Geo", Value: "{client_country}"}},
+			want:    []string{"spec.default.customRequestHeaders[0].value"},
+		},
+		{
+			desc:    "response-only variable in request",
+			request: []networkingv1.CustomHeader{{Name: "X-Cache-Status", Value: "{cdn_cache_status}"}, {Name: "X-Origin", Value: "{origin_request_header}"}},
+			want:    []string{"spec.default.customRequestHeaders[0].value", "spec.default.customRequestHeaders[1].value"},
+		},
+		{
+			desc:     "response-only variables in response",
+			response: []networkingv1.CustomHeader{{Name: "X-Cache", Value: "{cdn_cache_id}/{cdn_cache_status}"}, {Name: "Access-Control-Allow-Origin", Value: "{origin_request_header}"}},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := &networkingv1.GCPBackendPolicyConfig{CustomRequestHeaders: tc.request, CustomResponseHeaders: tc.response}
+			errs := ValidateCustomHeaders(cfg, field.NewPath("spec", "default"))
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Field)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ValidateCustomHeaders() = %v, want errors for %v", errs, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package backendpolicy contains helpers to resolve, validate and translate
+// GCPBackendPolicy configuration into BackendService settings.
+package backendpolicy
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	gatewayv1beta1listers "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/policyref"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/refgrant"
+)
+
+// IAPSecretKey is the key of the OAuth2 client secret in the Secret referenced
+// by Oauth2ClientSecret.
+const IAPSecretKey = "key"
+
+// IAPCredentials are the resolved OAuth2 credentials for Identity-Aware Proxy.
+type IAPCredentials struct {
+	// OAuth2ClientID is the OAuth2 client ID.
+	OAuth2ClientID string
+	// OAuth2ClientSecret is the OAuth2 client secret read from the referenced Secret.
+	OAuth2ClientSecret string
+}
+
+// ResolveIAP validates the IAP configuration of the given policy and reads the
+// referenced OAuth2 client secret. It returns nil credentials if IAP is not
+// enabled. Any failure is returned as a *policyref.RefError.
+func ResolveIAP(policy *networkingv1.GCPBackendPolicy, secrets corev1listers.SecretLister, grants gatewayv1beta1listers.ReferenceGrantLister) (*IAPCredentials, error) {
+	if policy.Spec.Default == nil {
+		return nil, nil
+	}
+	iap := policy.Spec.Default.IAP
+	if iap == nil || iap.Enabled == nil || !*iap.Enabled {
+		return nil, nil
+	}
+	if iap.ClientID == nil || *iap.ClientID == "" {
+		return nil, &policyref.RefError{Reason: networkingv1.PolicyReasonInvalid, Message: "iap.clientID must be set when IAP is enabled"}
+	}
+	if iap.Oauth2ClientSecret == nil || iap.Oauth2ClientSecret.Name == nil || *iap.Oauth2ClientSecret.Name == "" {
+		return nil, &policyref.RefError{Reason: networkingv1.PolicyReasonInvalid, Message: "iap.oauth2ClientSecret.name must be set when IAP is enabled"}
+	}
+
+	value, _, err := readSecret(policy, *iap.Oauth2ClientSecret.Name, iap.Oauth2ClientSecret.Namespace, IAPSecretKey, secrets, grants)
+	if err != nil {
+		return nil, err
+	}
+	return &IAPCredentials{OAuth2ClientID: *iap.ClientID, OAuth2ClientSecret: string(value)}, nil
+}
+
+// readSecret reads the given key of the Secret referenced by the given policy,
+// and returns it with the name of the Secret. The Secret is looked up in the
+// namespace of the policy if namespace is nil or empty. Any failure is
+// returned as a *policyref.RefError.
+func readSecret(policy *networkingv1.GCPBackendPolicy, name string, namespace *string, key string, secrets corev1listers.SecretLister, grants gatewayv1beta1listers.ReferenceGrantLister) ([]byte, types.NamespacedName, error) {
+	ref := types.NamespacedName{Namespace: policy.Namespace, Name: name}
+	if namespace != nil && *namespace != "" {
+		ref.Namespace = *namespace
+	}
+	permitted, err := refgrant.Permitted(grants,
+		refgrant.From{Group: networkingv1.GroupName, Kind: "GCPBackendPolicy", Namespace: policy.Namespace},
+		refgrant.To{Kind: "Secret", Namespace: ref.Namespace, Name: ref.Name})
+	if err != nil {
+		return nil, ref, &policyref.RefError{Reason: networkingv1.PolicyReasonInvalidRef, Ref: ref, Message: "failed to list ReferenceGrants", Err: err}
+	}
+	if !permitted {
+		return nil, ref, &policyref.RefError{Reason: networkingv1.PolicyReasonRefNotPermitted, Ref: ref,
+			Message: fmt.Sprintf("reference to Secret %s is not permitted by any ReferenceGrant", ref)}
+	}
+
+	secret, err := secrets.Secrets(ref.Namespace).Get(ref.Name)
+	if apierrors.IsNotFound(err) {
+		return nil, ref, &policyref.RefError{Reason: networkingv1.PolicyReasonInvalidRef, Ref: ref, Message: fmt.Sprintf("Secret %s not found", ref)}
+	}
+	if err != nil {
+		return nil, ref, &policyref.RefError{Reason: networkingv1.PolicyReasonInvalidRef, Ref: ref, Message: fmt.Sprintf("failed to get Secret %s", ref), Err: err}
+	}
+	value, ok := secret.Data[key]
+	if !ok || len(value) == 0 {
+		return nil, ref, &policyref.RefError{Reason: networkingv1.PolicyReasonInvalidRef, Ref: ref,
+			Message: fmt.Sprintf("Secret %s does not contain a non-empty %q key", ref, key)}
+	}
+	return value, ref, nil
+}
@@ -0,0 +1,129 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1beta1listers "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/policyref"
+)
+
+func TestResolveIAP(t *testing.T) {
+	secrets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, s := range []*corev1.Secret{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "iap"}, Data: map[string][]byte{"key": []byte("local")}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "iap"}, Data: map[string][]byte{"key": []byte("shared")}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "iap"}, Data: map[string][]byte{"key": []byte("other")}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "nokey"}, Data: map[string][]byte{"secret": []byte("x")}},
+	} {
+		secrets.Add(s)
+	}
+	grants := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	grants.Add(&gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "allow-app"},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{{Group: networkingv1.GroupName, Kind: "GCPBackendPolicy", Namespace: "app"}},
+			To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: "Secret"}},
+		},
+	})
+
+	for _, tc := range []struct {
+		desc       string
+		iap        *networkingv1.IdentityAwareProxyConfig
+		wantSecret string
+		wantReason networkingv1.PolicyConditionReason
+	}{
+		{
+			desc: "disabled",
+			iap:  &networkingv1.IdentityAwareProxyConfig{Enabled: ptr.To(false)},
+		},
+		{
+			desc:       "local secret",
+			iap:        iapConfig("iap", ""),
+			wantSecret: "local",
+		},
+		{
+			desc:       "granted cross namespace secret",
+			iap:        iapConfig("iap", "shared"),
+			wantSecret: "shared",
+		},
+		{
+			desc:       "cross namespace secret without grant",
+			iap:        iapConfig("iap", "other"),
+			wantReason: networkingv1.PolicyReasonRefNotPermitted,
+		},
+		{
+			desc:       "missing secret",
+			iap:        iapConfig("missing", ""),
+			wantReason: networkingv1.PolicyReasonInvalidRef,
+		},
+		{
+			desc:       "missing key",
+			iap:        iapConfig("nokey", ""),
+			wantReason: networkingv1.PolicyReasonInvalidRef,
+		},
+		{
+			desc:       "missing client ID",
+			iap:        &networkingv1.IdentityAwareProxyConfig{Enabled: ptr.To(true), Oauth2ClientSecret: &networkingv1.Oauth2ClientSecret{Name: ptr.To("iap")}},
+			wantReason: networkingv1.PolicyReasonInvalid,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			policy := &networkingv1.GCPBackendPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "policy"},
+				Spec:       networkingv1.GCPBackendPolicySpec{Default: &networkingv1.GCPBackendPolicyConfig{IAP: tc.iap}},
+			}
+			got, err := ResolveIAP(policy, corev1listers.NewSecretLister(secrets), gatewayv1beta1listers.NewReferenceGrantLister(grants))
+			if tc.wantReason != "" {
+				var refErr *policyref.RefError
+				if !errors.As(err, &refErr) || refErr.Reason != tc.wantReason {
+					t.Fatalf("ResolveIAP() = %v, want RefError with reason %s", err, tc.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveIAP() = %v", err)
+			}
+			if gotSecret := ""; got != nil {
+				gotSecret = got.OAuth2ClientSecret
+				if gotSecret != tc.wantSecret {
+					t.Errorf("ResolveIAP() secret = %q, want %q", gotSecret, tc.wantSecret)
+				}
+			} else if tc.wantSecret != "" {
+				t.Errorf("ResolveIAP() = nil, want secret %q", tc.wantSecret)
+			}
+		})
+	}
+}
+
+func iapConfig(name, namespace string) *networkingv1.IdentityAwareProxyConfig {
+	ref := &networkingv1.Oauth2ClientSecret{Name: ptr.To(name)}
+	if namespace != "" {
+		ref.Namespace = ptr.To(namespace)
+	}
+	return &networkingv1.IdentityAwareProxyConfig{Enabled: ptr.To(true), ClientID: ptr.To("client"), Oauth2ClientSecret: ref}
+}
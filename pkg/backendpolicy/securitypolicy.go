@@ -0,0 +1,156 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/computeref"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/gatewayclass"
+)
+
+// SecurityPolicyCollection is the collection of SecurityPolicy resources in the
+// compute API.
+const SecurityPolicyCollection = "securityPolicies"
+
+// SecurityPolicyType is the type of a Cloud Armor SecurityPolicy.
+type SecurityPolicyType string
+
+const (
+	// BackendSecurityPolicy filters requests before they are sent to the backends.
+	BackendSecurityPolicy SecurityPolicyType = "backend"
+	// EdgeSecurityPolicy filters requests before they are served from cache.
+	EdgeSecurityPolicy SecurityPolicyType = "edge"
+)
+
+// SecurityPolicyReference is a parsed GCPBackendPolicyConfig.SecurityPolicy or
+// GCPBackendPolicyConfig.EdgeSecurityPolicy.
+type SecurityPolicyReference struct {
+	// Type is the type of the SecurityPolicy.
+	Type SecurityPolicyType
+	// Detach is true if the field was set to the empty string, which detaches
+	// the SecurityPolicy from the BackendService.
+	Detach bool
+	// Ref is the referenced SecurityPolicy. Nil if Detach is true.
+	Ref *computeref.Reference
+}
+
+// ParseSecurityPolicy parses a SecurityPolicy reference of the given type.
+// The empty string is parsed as a reference that detaches the SecurityPolicy.
+func ParseSecurityPolicy(s string, typ SecurityPolicyType) (*SecurityPolicyReference, error) {
+	if s == "" {
+		return &SecurityPolicyReference{Type: typ, Detach: true}, nil
+	}
+	ref, err := computeref.Parse(s, SecurityPolicyCollection)
+	if err != nil {
+		return nil, fmt.Errorf("%s security policy: %w", typ, err)
+	}
+	if typ == EdgeSecurityPolicy && ref.Scope == computeref.ScopeRegional {
+		return nil, fmt.Errorf("edge security policy %q must be global", s)
+	}
+	return &SecurityPolicyReference{Type: typ, Ref: ref}, nil
+}
+
+// SecurityPolicies parses the backend and edge SecurityPolicy references of
+// the given configuration. A nil reference means that the field is unset.
+func SecurityPolicies(cfg *networkingv1.GCPBackendPolicyConfig) (backend, edge *SecurityPolicyReference, err error) {
+	if cfg == nil {
+		return nil, nil, nil
+	}
+	if cfg.SecurityPolicy != nil {
+		if backend, err = ParseSecurityPolicy(*cfg.SecurityPolicy, BackendSecurityPolicy); err != nil {
+			return nil, nil, err
+		}
+	}
+	if cfg.EdgeSecurityPolicy != nil {
+		if edge, err = ParseSecurityPolicy(*cfg.EdgeSecurityPolicy, EdgeSecurityPolicy); err != nil {
+			return nil, nil, err
+		}
+	}
+	return backend, edge, nil
+}
+
+// ValidateSecurityPolicies validates the SecurityPolicy references of the
+// given configuration against the GatewayClass of the Gateways that use the
+// targeted backend. A regional load balancer requires a regional backend
+// SecurityPolicy and a global load balancer a global one.
+//
+// If gatewayClassName is not a GatewayClass managed by GKE, only the syntax of
+// the references is validated.
+func ValidateSecurityPolicies(cfg *networkingv1.GCPBackendPolicyConfig, gatewayClassName string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if cfg == nil {
+		return allErrs
+	}
+	var backend *SecurityPolicyReference
+	if cfg.SecurityPolicy != nil {
+		ref, err := ParseSecurityPolicy(*cfg.SecurityPolicy, BackendSecurityPolicy)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("securityPolicy"), *cfg.SecurityPolicy, err.Error()))
+		}
+		backend = ref
+	}
+	if cfg.EdgeSecurityPolicy != nil {
+		if _, err := ParseSecurityPolicy(*cfg.EdgeSecurityPolicy, EdgeSecurityPolicy); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("edgeSecurityPolicy"), *cfg.EdgeSecurityPolicy, err.Error()))
+		}
+	}
+
+	class, ok := gatewayclass.Lookup(gatewayClassName)
+	if !ok || backend == nil || backend.Detach || backend.Ref.IsRawName() {
+		return allErrs
+	}
+	want := computeref.ScopeGlobal
+	if class.IsRegional() {
+		want = computeref.ScopeRegional
+	}
+	if backend.Ref.Scope != want {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("securityPolicy"), *cfg.SecurityPolicy,
+			fmt.Sprintf("a %s security policy cannot be used with GatewayClass %s, which requires a %s security policy", backend.Ref.Scope, class.Name, want)))
+	}
+	return allErrs
+}
+
+// ApplySecurityPolicies sets the SecurityPolicy fields of the BackendService
+// from the given configuration. Raw names are resolved within the given
+// project, and within the given region for regional load balancers. region
+// must be empty for global load balancers.
+func ApplySecurityPolicies(cfg *networkingv1.GCPBackendPolicyConfig, bs *BackendService, project, region string) error {
+	backend, edge, err := SecurityPolicies(cfg)
+	if err != nil {
+		return err
+	}
+	if backend != nil {
+		bs.SecurityPolicy = backend.url(project, region)
+	}
+	if edge != nil {
+		// Edge security policies are always global.
+		bs.EdgeSecurityPolicy = edge.url(project, "")
+	}
+	return nil
+}
+
+func (r *SecurityPolicyReference) url(project, region string) *string {
+	var url string
+	if !r.Detach {
+		url = r.Ref.URL(project, region)
+	}
+	return &url
+}
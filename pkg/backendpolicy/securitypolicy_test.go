@@ -0,0 +1,147 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package backendpolicy
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/computeref"
+)
+
+func TestParseSecurityPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		in         string
+		typ        SecurityPolicyType
+		wantDetach bool
+		wantScope  computeref.Scope
+		wantErr    string
+	}{
+		{desc: "detach", in: "", typ: BackendSecurityPolicy, wantDetach: true},
+		{desc: "raw name", in: "armor", typ: BackendSecurityPolicy},
+		{desc: "global backend", in: "projects/my-project/global/securityPolicies/armor", typ: BackendSecurityPolicy, wantScope: computeref.ScopeGlobal},
+		{desc: "regional backend", in: "projects/my-project/regions/us-central1/securityPolicies/armor", typ: BackendSecurityPolicy, wantScope: computeref.ScopeRegional},
+		{desc: "global edge", in: "projects/my-project/global/securityPolicies/edge", typ: EdgeSecurityPolicy, wantScope: computeref.ScopeGlobal},
+		{desc: "regional edge", in: "projects/my-project/regions/us-central1/securityPolicies/edge", typ: EdgeSecurityPolicy, wantErr: "must be global"},
+		{desc: "invalid name", in: "Armor", typ: BackendSecurityPolicy, wantErr: "backend security policy"},
+		{desc: "SslPolicy path", in: "projects/my-project/global/sslPolicies/armor", typ: EdgeSecurityPolicy, wantErr: "edge security policy"},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseSecurityPolicy(tc.in, tc.typ)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("ParseSecurityPolicy(%q) = %v, want error containing %q", tc.in, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSecurityPolicy(%q) = %v", tc.in, err)
+			}
+			if got.Type != tc.typ || got.Detach != tc.wantDetach {
+				t.Errorf("ParseSecurityPolicy(%q) = %+v, want type %s and detach %t", tc.in, got, tc.typ, tc.wantDetach)
+			}
+			if !tc.wantDetach && got.Ref.Scope != tc.wantScope {
+				t.Errorf("ParseSecurityPolicy(%q) scope = %q, want %q", tc.in, got.Ref.Scope, tc.wantScope)
+			}
+		})
+	}
+}
+
+func TestValidateSecurityPolicies(t *testing.T) {
+	const (
+		global   = "gke-l7-global-external-managed"
+		regional = "gke-l7-regional-external-managed"
+	)
+	for _, tc := range []struct {
+		desc       string
+		cfg        *networkingv1.GCPBackendPolicyConfig
+		class      string
+		wantFields []string
+	}{
+		{desc: "nil", cfg: nil, class: global},
+		{desc: "raw names", cfg: &networkingv1.GCPBackendPolicyConfig{SecurityPolicy: ptr.To("armor"), EdgeSecurityPolicy: ptr.To("edge")}, class: regional},
+		{desc: "detach", cfg: &networkingv1.GCPBackendPolicyConfig{SecurityPolicy: ptr.To("")}, class: regional},
+		{
+			desc:  "global policy with global class",
+			cfg:   &networkingv1.GCPBackendPolicyConfig{SecurityPolicy: ptr.To("projects/my-project/global/securityPolicies/armor")},
+			class: global,
+		},
+		{
+			desc:       "global policy with regional class",
+			cfg:        &networkingv1.GCPBackendPolicyConfig{SecurityPolicy: ptr.To("projects/my-project/global/securityPolicies/armor")},
+			class:      regional,
+			wantFields: []string{"config.securityPolicy"},
+		},
+		{
+			desc:       "regional policy with global class",
+			cfg:        &networkingv1.GCPBackendPolicyConfig{SecurityPolicy: ptr.To("projects/my-project/regions/us-central1/securityPolicies/armor")},
+			class:      global,
+			wantFields: []string{"config.securityPolicy"},
+		},
+		{
+			desc:  "unknown class only checks syntax",
+			cfg:   &networkingv1.GCPBackendPolicyConfig{SecurityPolicy: ptr.To("projects/my-project/regions/us-central1/securityPolicies/armor")},
+			class: "example.com/gateway",
+		},
+		{
+			desc: "invalid references",
+			cfg: &networkingv1.GCPBackendPolicyConfig{
+				SecurityPolicy:     ptr.To("not/a/path"),
+				EdgeSecurityPolicy: ptr.To("projects/my-project/regions/us-central1/securityPolicies/edge"),
+			},
+			class:      global,
+			wantFields: []string{"config.securityPolicy", "config.edgeSecurityPolicy"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			errs := ValidateSecurityPolicies(tc.cfg, tc.class, field.NewPath("config"))
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tc.wantFields, ",") {
+				t.Errorf("ValidateSecurityPolicies() = %v, want errors for %v", errs, tc.wantFields)
+			}
+		})
+	}
+}
+
+func TestApplySecurityPolicies(t *testing.T) {
+	cfg := &networkingv1.GCPBackendPolicyConfig{
+		SecurityPolicy:     ptr.To("armor"),
+		EdgeSecurityPolicy: ptr.To(""),
+	}
+	var bs BackendService
+	if err := ApplySecurityPolicies(cfg, &bs, "lb-project", "us-central1"); err != nil {
+		t.Fatalf("ApplySecurityPolicies() = %v", err)
+	}
+	if want := "https://www.googleapis.com/compute/v1/projects/lb-project/regions/us-central1/securityPolicies/armor"; bs.SecurityPolicy == nil || *bs.SecurityPolicy != want {
+		t.Errorf("SecurityPolicy = %v, want %q", bs.SecurityPolicy, want)
+	}
+	if bs.EdgeSecurityPolicy == nil || *bs.EdgeSecurityPolicy != "" {
+		t.Errorf("EdgeSecurityPolicy = %v, want the empty string", bs.EdgeSecurityPolicy)
+	}
+
+	if err := ApplySecurityPolicies(&networkingv1.GCPBackendPolicyConfig{SecurityPolicy: ptr.To("Armor")}, &BackendService{}, "lb-project", ""); err == nil {
+		t.Error("ApplySecurityPolicies() with an invalid name succeeded")
+	}
+}
@@ -0,0 +1,52 @@
+/*
+* Copyright 2024 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/pkg/client/clientset/versioned/typed/networking/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeGCPAuthzExtensions implements GCPAuthzExtensionInterface
+type fakeGCPAuthzExtensions struct {
+	*gentype.FakeClientWithList[*v1.GCPAuthzExtension, *v1.GCPAuthzExtensionList]
+	Fake *FakeNetworkingV1
+}
+
+func newFakeGCPAuthzExtensions(fake *FakeNetworkingV1, namespace string) networkingv1.GCPAuthzExtensionInterface {
+	return &fakeGCPAuthzExtensions{
+		gentype.NewFakeClientWithList[*v1.GCPAuthzExtension, *v1.GCPAuthzExtensionList](
+			fake.Fake,
+			namespace,
+			v1.SchemeGroupVersion.WithResource("gcpauthzextensions"),
+			v1.SchemeGroupVersion.WithKind("GCPAuthzExtension"),
+			func() *v1.GCPAuthzExtension { return &v1.GCPAuthzExtension{} },
+			func() *v1.GCPAuthzExtensionList { return &v1.GCPAuthzExtensionList{} },
+			func(dst, src *v1.GCPAuthzExtensionList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.GCPAuthzExtensionList) []*v1.GCPAuthzExtension {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1.GCPAuthzExtensionList, items []*v1.GCPAuthzExtension) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
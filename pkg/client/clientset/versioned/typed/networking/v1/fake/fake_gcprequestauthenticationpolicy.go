@@ -0,0 +1,52 @@
+/*
+* Copyright 2024 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/pkg/client/clientset/versioned/typed/networking/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeGCPRequestAuthenticationPolicies implements GCPRequestAuthenticationPolicyInterface
+type fakeGCPRequestAuthenticationPolicies struct {
+	*gentype.FakeClientWithList[*v1.GCPRequestAuthenticationPolicy, *v1.GCPRequestAuthenticationPolicyList]
+	Fake *FakeNetworkingV1
+}
+
+func newFakeGCPRequestAuthenticationPolicies(fake *FakeNetworkingV1, namespace string) networkingv1.GCPRequestAuthenticationPolicyInterface {
+	return &fakeGCPRequestAuthenticationPolicies{
+		gentype.NewFakeClientWithList[*v1.GCPRequestAuthenticationPolicy, *v1.GCPRequestAuthenticationPolicyList](
+			fake.Fake,
+			namespace,
+			v1.SchemeGroupVersion.WithResource("gcprequestauthenticationpolicies"),
+			v1.SchemeGroupVersion.WithKind("GCPRequestAuthenticationPolicy"),
+			func() *v1.GCPRequestAuthenticationPolicy { return &v1.GCPRequestAuthenticationPolicy{} },
+			func() *v1.GCPRequestAuthenticationPolicyList { return &v1.GCPRequestAuthenticationPolicyList{} },
+			func(dst, src *v1.GCPRequestAuthenticationPolicyList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.GCPRequestAuthenticationPolicyList) []*v1.GCPRequestAuthenticationPolicy {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1.GCPRequestAuthenticationPolicyList, items []*v1.GCPRequestAuthenticationPolicy) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
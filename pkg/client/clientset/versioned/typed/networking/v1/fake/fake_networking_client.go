@@ -28,6 +28,10 @@ type FakeNetworkingV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeNetworkingV1) GCPAuthzExtensions(namespace string) v1.GCPAuthzExtensionInterface {
+	return newFakeGCPAuthzExtensions(c, namespace)
+}
+
 func (c *FakeNetworkingV1) GCPAuthzPolicies(namespace string) v1.GCPAuthzPolicyInterface {
 	return newFakeGCPAuthzPolicies(c, namespace)
 }
@@ -44,6 +48,10 @@ func (c *FakeNetworkingV1) GCPGatewayPolicies(namespace string) v1.GCPGatewayPol
 	return newFakeGCPGatewayPolicies(c, namespace)
 }
 
+func (c *FakeNetworkingV1) GCPRequestAuthenticationPolicies(namespace string) v1.GCPRequestAuthenticationPolicyInterface {
+	return newFakeGCPRequestAuthenticationPolicies(c, namespace)
+}
+
 func (c *FakeNetworkingV1) GCPServerTLSPolicies(namespace string) v1.GCPServerTLSPolicyInterface {
 	return newFakeGCPServerTLSPolicies(c, namespace)
 }
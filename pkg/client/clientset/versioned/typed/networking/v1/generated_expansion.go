@@ -18,6 +18,8 @@
 
 package v1
 
+type GCPAuthzExtensionExpansion interface{}
+
 type GCPAuthzPolicyExpansion interface{}
 
 type GCPBackendPolicyExpansion interface{}
@@ -26,6 +28,8 @@ type GCPClientTLSPolicyExpansion interface{}
 
 type GCPGatewayPolicyExpansion interface{}
 
+type GCPRequestAuthenticationPolicyExpansion interface{}
+
 type GCPServerTLSPolicyExpansion interface{}
 
 type GCPSessionAffinityFilterExpansion interface{}
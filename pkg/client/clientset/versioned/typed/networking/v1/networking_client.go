@@ -28,10 +28,12 @@ import (
 
 type NetworkingV1Interface interface {
 	RESTClient() rest.Interface
+	GCPAuthzExtensionsGetter
 	GCPAuthzPoliciesGetter
 	GCPBackendPoliciesGetter
 	GCPClientTLSPoliciesGetter
 	GCPGatewayPoliciesGetter
+	GCPRequestAuthenticationPoliciesGetter
 	GCPServerTLSPoliciesGetter
 	GCPSessionAffinityFiltersGetter
 	GCPSessionAffinityPoliciesGetter
@@ -45,6 +47,10 @@ type NetworkingV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *NetworkingV1Client) GCPAuthzExtensions(namespace string) GCPAuthzExtensionInterface {
+	return newGCPAuthzExtensions(c, namespace)
+}
+
 func (c *NetworkingV1Client) GCPAuthzPolicies(namespace string) GCPAuthzPolicyInterface {
 	return newGCPAuthzPolicies(c, namespace)
 }
@@ -61,6 +67,10 @@ func (c *NetworkingV1Client) GCPGatewayPolicies(namespace string) GCPGatewayPoli
 	return newGCPGatewayPolicies(c, namespace)
 }
 
+func (c *NetworkingV1Client) GCPRequestAuthenticationPolicies(namespace string) GCPRequestAuthenticationPolicyInterface {
+	return newGCPRequestAuthenticationPolicies(c, namespace)
+}
+
 func (c *NetworkingV1Client) GCPServerTLSPolicies(namespace string) GCPServerTLSPolicyInterface {
 	return newGCPServerTLSPolicies(c, namespace)
 }
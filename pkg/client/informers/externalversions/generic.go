@@ -53,6 +53,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=networking.gke.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("gcpauthzextensions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1().GCPAuthzExtensions().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("gcpauthzpolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1().GCPAuthzPolicies().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("gcpbackendpolicies"):
@@ -61,6 +63,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1().GCPClientTLSPolicies().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("gcpgatewaypolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1().GCPGatewayPolicies().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("gcprequestauthenticationpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1().GCPRequestAuthenticationPolicies().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("gcpservertlspolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1().GCPServerTLSPolicies().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("gcpsessionaffinityfilters"):
@@ -24,6 +24,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// GCPAuthzExtensions returns a GCPAuthzExtensionInformer.
+	GCPAuthzExtensions() GCPAuthzExtensionInformer
 	// GCPAuthzPolicies returns a GCPAuthzPolicyInformer.
 	GCPAuthzPolicies() GCPAuthzPolicyInformer
 	// GCPBackendPolicies returns a GCPBackendPolicyInformer.
@@ -32,6 +34,8 @@ type Interface interface {
 	GCPClientTLSPolicies() GCPClientTLSPolicyInformer
 	// GCPGatewayPolicies returns a GCPGatewayPolicyInformer.
 	GCPGatewayPolicies() GCPGatewayPolicyInformer
+	// GCPRequestAuthenticationPolicies returns a GCPRequestAuthenticationPolicyInformer.
+	GCPRequestAuthenticationPolicies() GCPRequestAuthenticationPolicyInformer
 	// GCPServerTLSPolicies returns a GCPServerTLSPolicyInformer.
 	GCPServerTLSPolicies() GCPServerTLSPolicyInformer
 	// GCPSessionAffinityFilters returns a GCPSessionAffinityFilterInformer.
@@ -57,6 +61,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// GCPAuthzExtensions returns a GCPAuthzExtensionInformer.
+func (v *version) GCPAuthzExtensions() GCPAuthzExtensionInformer {
+	return &gCPAuthzExtensionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // GCPAuthzPolicies returns a GCPAuthzPolicyInformer.
 func (v *version) GCPAuthzPolicies() GCPAuthzPolicyInformer {
 	return &gCPAuthzPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -77,6 +86,11 @@ func (v *version) GCPGatewayPolicies() GCPGatewayPolicyInformer {
 	return &gCPGatewayPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// GCPRequestAuthenticationPolicies returns a GCPRequestAuthenticationPolicyInformer.
+func (v *version) GCPRequestAuthenticationPolicies() GCPRequestAuthenticationPolicyInformer {
+	return &gCPRequestAuthenticationPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // GCPServerTLSPolicies returns a GCPServerTLSPolicyInformer.
 func (v *version) GCPServerTLSPolicies() GCPServerTLSPolicyInformer {
 	return &gCPServerTLSPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -18,6 +18,14 @@
 
 package v1
 
+// GCPAuthzExtensionListerExpansion allows custom methods to be added to
+// GCPAuthzExtensionLister.
+type GCPAuthzExtensionListerExpansion interface{}
+
+// GCPAuthzExtensionNamespaceListerExpansion allows custom methods to be added to
+// GCPAuthzExtensionNamespaceLister.
+type GCPAuthzExtensionNamespaceListerExpansion interface{}
+
 // GCPAuthzPolicyListerExpansion allows custom methods to be added to
 // GCPAuthzPolicyLister.
 type GCPAuthzPolicyListerExpansion interface{}
@@ -50,6 +58,14 @@ type GCPGatewayPolicyListerExpansion interface{}
 // GCPGatewayPolicyNamespaceLister.
 type GCPGatewayPolicyNamespaceListerExpansion interface{}
 
+// GCPRequestAuthenticationPolicyListerExpansion allows custom methods to be added to
+// GCPRequestAuthenticationPolicyLister.
+type GCPRequestAuthenticationPolicyListerExpansion interface{}
+
+// GCPRequestAuthenticationPolicyNamespaceListerExpansion allows custom methods to be added to
+// GCPRequestAuthenticationPolicyNamespaceLister.
+type GCPRequestAuthenticationPolicyNamespaceListerExpansion interface{}
+
 // GCPServerTLSPolicyListerExpansion allows custom methods to be added to
 // GCPServerTLSPolicyLister.
 type GCPServerTLSPolicyListerExpansion interface{}
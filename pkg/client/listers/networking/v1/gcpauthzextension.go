@@ -0,0 +1,70 @@
+/*
+* Copyright 2024 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// GCPAuthzExtensionLister helps list GCPAuthzExtensions.
+// All objects returned here must be treated as read-only.
+type GCPAuthzExtensionLister interface {
+	// List lists all GCPAuthzExtensions in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*networkingv1.GCPAuthzExtension, err error)
+	// GCPAuthzExtensions returns an object that can list and get GCPAuthzExtensions.
+	GCPAuthzExtensions(namespace string) GCPAuthzExtensionNamespaceLister
+	GCPAuthzExtensionListerExpansion
+}
+
+// gCPAuthzExtensionLister implements the GCPAuthzExtensionLister interface.
+type gCPAuthzExtensionLister struct {
+	listers.ResourceIndexer[*networkingv1.GCPAuthzExtension]
+}
+
+// NewGCPAuthzExtensionLister returns a new GCPAuthzExtensionLister.
+func NewGCPAuthzExtensionLister(indexer cache.Indexer) GCPAuthzExtensionLister {
+	return &gCPAuthzExtensionLister{listers.New[*networkingv1.GCPAuthzExtension](indexer, networkingv1.Resource("gcpauthzextension"))}
+}
+
+// GCPAuthzExtensions returns an object that can list and get GCPAuthzExtensions.
+func (s *gCPAuthzExtensionLister) GCPAuthzExtensions(namespace string) GCPAuthzExtensionNamespaceLister {
+	return gCPAuthzExtensionNamespaceLister{listers.NewNamespaced[*networkingv1.GCPAuthzExtension](s.ResourceIndexer, namespace)}
+}
+
+// GCPAuthzExtensionNamespaceLister helps list and get GCPAuthzExtensions.
+// All objects returned here must be treated as read-only.
+type GCPAuthzExtensionNamespaceLister interface {
+	// List lists all GCPAuthzExtensions in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*networkingv1.GCPAuthzExtension, err error)
+	// Get retrieves the GCPAuthzExtension from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*networkingv1.GCPAuthzExtension, error)
+	GCPAuthzExtensionNamespaceListerExpansion
+}
+
+// gCPAuthzExtensionNamespaceLister implements the GCPAuthzExtensionNamespaceLister
+// interface.
+type gCPAuthzExtensionNamespaceLister struct {
+	listers.ResourceIndexer[*networkingv1.GCPAuthzExtension]
+}
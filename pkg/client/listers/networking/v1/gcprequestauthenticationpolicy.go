@@ -0,0 +1,70 @@
+/*
+* Copyright 2024 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// GCPRequestAuthenticationPolicyLister helps list GCPRequestAuthenticationPolicies.
+// All objects returned here must be treated as read-only.
+type GCPRequestAuthenticationPolicyLister interface {
+	// List lists all GCPRequestAuthenticationPolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*networkingv1.GCPRequestAuthenticationPolicy, err error)
+	// GCPRequestAuthenticationPolicies returns an object that can list and get GCPRequestAuthenticationPolicies.
+	GCPRequestAuthenticationPolicies(namespace string) GCPRequestAuthenticationPolicyNamespaceLister
+	GCPRequestAuthenticationPolicyListerExpansion
+}
+
+// gCPRequestAuthenticationPolicyLister implements the GCPRequestAuthenticationPolicyLister interface.
+type gCPRequestAuthenticationPolicyLister struct {
+	listers.ResourceIndexer[*networkingv1.GCPRequestAuthenticationPolicy]
+}
+
+// NewGCPRequestAuthenticationPolicyLister returns a new GCPRequestAuthenticationPolicyLister.
+func NewGCPRequestAuthenticationPolicyLister(indexer cache.Indexer) GCPRequestAuthenticationPolicyLister {
+	return &gCPRequestAuthenticationPolicyLister{listers.New[*networkingv1.GCPRequestAuthenticationPolicy](indexer, networkingv1.Resource("gcprequestauthenticationpolicy"))}
+}
+
+// GCPRequestAuthenticationPolicies returns an object that can list and get GCPRequestAuthenticationPolicies.
+func (s *gCPRequestAuthenticationPolicyLister) GCPRequestAuthenticationPolicies(namespace string) GCPRequestAuthenticationPolicyNamespaceLister {
+	return gCPRequestAuthenticationPolicyNamespaceLister{listers.NewNamespaced[*networkingv1.GCPRequestAuthenticationPolicy](s.ResourceIndexer, namespace)}
+}
+
+// GCPRequestAuthenticationPolicyNamespaceLister helps list and get GCPRequestAuthenticationPolicies.
+// All objects returned here must be treated as read-only.
+type GCPRequestAuthenticationPolicyNamespaceLister interface {
+	// List lists all GCPRequestAuthenticationPolicies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*networkingv1.GCPRequestAuthenticationPolicy, err error)
+	// Get retrieves the GCPRequestAuthenticationPolicy from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*networkingv1.GCPRequestAuthenticationPolicy, error)
+	GCPRequestAuthenticationPolicyNamespaceListerExpansion
+}
+
+// gCPRequestAuthenticationPolicyNamespaceLister implements the GCPRequestAuthenticationPolicyNamespaceLister
+// interface.
+type gCPRequestAuthenticationPolicyNamespaceLister struct {
+	listers.ResourceIndexer[*networkingv1.GCPRequestAuthenticationPolicy]
+}
@@ -0,0 +1,153 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package computeref parses references to Compute Engine resources, such as
+// SslPolicies and SecurityPolicies, given as a raw name or a resource path.
+package computeref
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Scope is the scope of a referenced Compute Engine resource.
+type Scope string
+
+const (
+	// ScopeUnspecified is used when a resource is referenced by a raw name.
+	// The scope is derived from the load balancer that uses the resource.
+	ScopeUnspecified Scope = ""
+	// ScopeGlobal is used for resources under projects/[projectID]/global.
+	ScopeGlobal Scope = "global"
+	// ScopeRegional is used for resources under projects/[projectID]/regions/[region].
+	ScopeRegional Scope = "regional"
+)
+
+// urlPrefixes are stripped from fully qualified resource URLs.
+var urlPrefixes = []string{
+	"https://www.googleapis.com/compute/v1/",
+	"https://www.googleapis.com/compute/beta/",
+	"https://compute.googleapis.com/compute/v1/",
+	"https://compute.googleapis.com/compute/beta/",
+}
+
+// urlPrefix is used to build fully qualified resource URLs.
+const urlPrefix = "https://www.googleapis.com/compute/v1/"
+
+var (
+	nameRegexp    = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+	projectRegexp = regexp.MustCompile(`^([a-z0-9.-]+:)?[a-z][-a-z0-9]{4,28}[a-z0-9]$`)
+	regionRegexp  = regexp.MustCompile(`^[a-z]+-[a-z]+[0-9]+$`)
+)
+
+// Reference is a parsed reference to a Compute Engine resource.
+type Reference struct {
+	// Project is the project of the resource. Empty if the resource is
+	// referenced by a raw name.
+	Project string
+	// Scope is the scope of the resource.
+	Scope Scope
+	// Region is the region of a regional resource.
+	Region string
+	// Collection is the collection of the resource, e.g. "sslPolicies".
+	Collection string
+	// Name is the name of the resource.
+	Name string
+}
+
+// IsRawName returns true if the resource was referenced by a raw name rather
+// than a path.
+func (r *Reference) IsRawName() bool {
+	return r.Scope == ScopeUnspecified
+}
+
+// String returns the reference as a raw name or a relative resource path.
+func (r *Reference) String() string {
+	switch r.Scope {
+	case ScopeGlobal:
+		return fmt.Sprintf("projects/%s/global/%s/%s", r.Project, r.Collection, r.Name)
+	case ScopeRegional:
+		return fmt.Sprintf("projects/%s/regions/%s/%s/%s", r.Project, r.Region, r.Collection, r.Name)
+	default:
+		return r.Name
+	}
+}
+
+// URL returns the fully qualified URL of the resource. A raw name is resolved
+// within the given project, and within the given region unless region is
+// empty, in which case the resource is global.
+func (r *Reference) URL(project, region string) string {
+	resolved := *r
+	if resolved.IsRawName() {
+		resolved.Project = project
+		resolved.Scope = ScopeGlobal
+		if region != "" {
+			resolved.Scope = ScopeRegional
+			resolved.Region = region
+		}
+	}
+	return urlPrefix + resolved.String()
+}
+
+// Parse parses a reference to a resource of the given collection.
+//
+// The following forms are accepted:
+//
+//	[name]
+//	projects/[projectID]/global/[collection]/[name]
+//	projects/[projectID]/regions/[region]/[collection]/[name]
+//
+// Paths may also be given as fully qualified compute API URLs.
+func Parse(s, collection string) (*Reference, error) {
+	if s == "" {
+		return nil, fmt.Errorf("reference must not be empty")
+	}
+	path := s
+	for _, prefix := range urlPrefixes {
+		path = strings.TrimPrefix(path, prefix)
+	}
+
+	parts := strings.Split(path, "/")
+	var ref *Reference
+	switch {
+	case len(parts) == 1:
+		ref = &Reference{Collection: collection, Name: parts[0]}
+	case len(parts) == 5 && parts[0] == "projects" && parts[2] == "global" && parts[3] == collection:
+		ref = &Reference{Project: parts[1], Scope: ScopeGlobal, Collection: collection, Name: parts[4]}
+	case len(parts) == 6 && parts[0] == "projects" && parts[2] == "regions" && parts[4] == collection:
+		ref = &Reference{Project: parts[1], Scope: ScopeRegional, Region: parts[3], Collection: collection, Name: parts[5]}
+	default:
+		return nil, fmt.Errorf("%q must be a name, projects/[projectID]/global/%s/[name] or projects/[projectID]/regions/[region]/%s/[name]", s, collection, collection)
+	}
+
+	if !nameRegexp.MatchString(ref.Name) {
+		return nil, fmt.Errorf("%q has invalid name %q: must match %s", s, ref.Name, nameRegexp)
+	}
+	if ref.Scope != ScopeUnspecified && !projectRegexp.MatchString(ref.Project) {
+		return nil, fmt.Errorf("%q has invalid project %q", s, ref.Project)
+	}
+	if ref.Scope == ScopeRegional && !IsValidRegion(ref.Region) {
+		return nil, fmt.Errorf("%q has invalid region %q", s, ref.Region)
+	}
+	return ref, nil
+}
+
+// IsValidRegion returns true if s has the format of a Google Cloud region,
+// e.g. us-central1.
+func IsValidRegion(s string) bool {
+	return regionRegexp.MatchString(s)
+}
@@ -0,0 +1,171 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package computeref
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		in      string
+		want    Reference
+		wantErr bool
+	}{
+		{
+			desc: "raw name",
+			in:   "my-policy",
+			want: Reference{Collection: "securityPolicies", Name: "my-policy"},
+		},
+		{
+			desc: "single letter name",
+			in:   "a",
+			want: Reference{Collection: "securityPolicies", Name: "a"},
+		},
+		{
+			desc: "global path",
+			in:   "projects/my-project/global/securityPolicies/my-policy",
+			want: Reference{Project: "my-project", Scope: ScopeGlobal, Collection: "securityPolicies", Name: "my-policy"},
+		},
+		{
+			desc: "regional path",
+			in:   "projects/my-project/regions/europe-west4/securityPolicies/my-policy",
+			want: Reference{Project: "my-project", Scope: ScopeRegional, Region: "europe-west4", Collection: "securityPolicies", Name: "my-policy"},
+		},
+		{
+			desc: "domain scoped project",
+			in:   "projects/example.com:my-project/global/securityPolicies/my-policy",
+			want: Reference{Project: "example.com:my-project", Scope: ScopeGlobal, Collection: "securityPolicies", Name: "my-policy"},
+		},
+		{
+			desc: "compute v1 URL",
+			in:   "https://www.googleapis.com/compute/v1/projects/my-project/global/securityPolicies/my-policy",
+			want: Reference{Project: "my-project", Scope: ScopeGlobal, Collection: "securityPolicies", Name: "my-policy"},
+		},
+		{
+			desc: "compute beta URL",
+			in:   "https://compute.googleapis.com/compute/beta/projects/my-project/regions/us-central1/securityPolicies/my-policy",
+			want: Reference{Project: "my-project", Scope: ScopeRegional, Region: "us-central1", Collection: "securityPolicies", Name: "my-policy"},
+		},
+		{desc: "empty", in: "", wantErr: true},
+		{desc: "name with uppercase", in: "My-Policy", wantErr: true},
+		{desc: "name ending with a dash", in: "my-policy-", wantErr: true},
+		{desc: "name starting with a digit", in: "1policy", wantErr: true},
+		{desc: "name too long", in: "a123456789012345678901234567890123456789012345678901234567890123", wantErr: true},
+		{desc: "other collection", in: "projects/my-project/global/sslPolicies/my-policy", wantErr: true},
+		{desc: "partial path", in: "global/securityPolicies/my-policy", wantErr: true},
+		{desc: "missing project", in: "projects//global/securityPolicies/my-policy", wantErr: true},
+		{desc: "project too short", in: "projects/abc/global/securityPolicies/my-policy", wantErr: true},
+		{desc: "project with uppercase", in: "projects/My-Project/global/securityPolicies/my-policy", wantErr: true},
+		{desc: "invalid region", in: "projects/my-project/regions/global/securityPolicies/my-policy", wantErr: true},
+		{desc: "zone instead of region", in: "projects/my-project/regions/us-central1-a/securityPolicies/my-policy", wantErr: true},
+		{desc: "trailing slash", in: "projects/my-project/global/securityPolicies/my-policy/", wantErr: true},
+		{desc: "unknown URL prefix", in: "https://example.com/compute/v1/projects/my-project/global/securityPolicies/my-policy", wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := Parse(tc.in, "securityPolicies")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want error", tc.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) = %v", tc.in, err)
+			}
+			if *got != tc.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tc.in, *got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReferenceURL(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		in      string
+		project string
+		region  string
+		want    string
+	}{
+		{
+			desc:    "raw name, global",
+			in:      "my-policy",
+			project: "lb-project",
+			want:    "https://www.googleapis.com/compute/v1/projects/lb-project/global/securityPolicies/my-policy",
+		},
+		{
+			desc:    "raw name, regional",
+			in:      "my-policy",
+			project: "lb-project",
+			region:  "us-central1",
+			want:    "https://www.googleapis.com/compute/v1/projects/lb-project/regions/us-central1/securityPolicies/my-policy",
+		},
+		{
+			desc:    "global path ignores the load balancer project and region",
+			in:      "projects/my-project/global/securityPolicies/my-policy",
+			project: "lb-project",
+			region:  "us-central1",
+			want:    "https://www.googleapis.com/compute/v1/projects/my-project/global/securityPolicies/my-policy",
+		},
+		{
+			desc:    "regional path",
+			in:      "projects/my-project/regions/europe-west4/securityPolicies/my-policy",
+			project: "lb-project",
+			region:  "us-central1",
+			want:    "https://www.googleapis.com/compute/v1/projects/my-project/regions/europe-west4/securityPolicies/my-policy",
+		},
+		{
+			desc:    "beta URL is normalized",
+			in:      "https://compute.googleapis.com/compute/beta/projects/my-project/global/securityPolicies/my-policy",
+			project: "lb-project",
+			want:    "https://www.googleapis.com/compute/v1/projects/my-project/global/securityPolicies/my-policy",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ref, err := Parse(tc.in, "securityPolicies")
+			if err != nil {
+				t.Fatalf("Parse(%q) = %v", tc.in, err)
+			}
+			if got := ref.URL(tc.project, tc.region); got != tc.want {
+				t.Errorf("URL(%q, %q) = %q, want %q", tc.project, tc.region, got, tc.want)
+			}
+			if ref.IsRawName() && ref.String() != tc.in {
+				t.Errorf("String() = %q, want %q", ref.String(), tc.in)
+			}
+		})
+	}
+}
+
+func TestIsValidRegion(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want bool
+	}{
+		{in: "us-central1", want: true},
+		{in: "europe-west4", want: true},
+		{in: "northamerica-northeast2", want: true},
+		{in: "", want: false},
+		{in: "global", want: false},
+		{in: "us-central", want: false},
+		{in: "us-central1-a", want: false},
+		{in: "US-CENTRAL1", want: false},
+	} {
+		if got := IsValidRegion(tc.in); got != tc.want {
+			t.Errorf("IsValidRegion(%q) = %t, want %t", tc.in, got, tc.want)
+		}
+	}
+}
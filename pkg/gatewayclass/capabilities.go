@@ -0,0 +1,164 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package gatewayclass
+
+import "sort"
+
+// PolicyKind is the kind of a networking.gke.io resource that applies to the
+// load balancer of a Gateway.
+type PolicyKind string
+
+const (
+	// GCPGatewayPolicyKind is the kind of GCPGatewayPolicy.
+	GCPGatewayPolicyKind PolicyKind = "GCPGatewayPolicy"
+	// GCPBackendPolicyKind is the kind of GCPBackendPolicy.
+	GCPBackendPolicyKind PolicyKind = "GCPBackendPolicy"
+	// HealthCheckPolicyKind is the kind of HealthCheckPolicy.
+	HealthCheckPolicyKind PolicyKind = "HealthCheckPolicy"
+	// GCPSessionAffinityPolicyKind is the kind of GCPSessionAffinityPolicy.
+	GCPSessionAffinityPolicyKind PolicyKind = "GCPSessionAffinityPolicy"
+	// GCPSessionAffinityFilterKind is the kind of GCPSessionAffinityFilter.
+	GCPSessionAffinityFilterKind PolicyKind = "GCPSessionAffinityFilter"
+	// GCPTrafficDistributionPolicyKind is the kind of GCPTrafficDistributionPolicy.
+	GCPTrafficDistributionPolicyKind PolicyKind = "GCPTrafficDistributionPolicy"
+	// GCPTrafficExtensionKind is the kind of GCPTrafficExtension.
+	GCPTrafficExtensionKind PolicyKind = "GCPTrafficExtension"
+	// GCPRoutingExtensionKind is the kind of GCPRoutingExtension.
+	GCPRoutingExtensionKind PolicyKind = "GCPRoutingExtension"
+	// GCPAuthzPolicyKind is the kind of GCPAuthzPolicy.
+	GCPAuthzPolicyKind PolicyKind = "GCPAuthzPolicy"
+	// GCPRequestAuthenticationPolicyKind is the kind of GCPRequestAuthenticationPolicy.
+	GCPRequestAuthenticationPolicyKind PolicyKind = "GCPRequestAuthenticationPolicy"
+	// GCPAuthzExtensionKind is the kind of GCPAuthzExtension.
+	GCPAuthzExtensionKind PolicyKind = "GCPAuthzExtension"
+)
+
+// Feature is a policy field whose support depends on the load balancer that
+// backs a GatewayClass.
+type Feature string
+
+const (
+	// FeatureAllowGlobalAccess is GCPGatewayPolicy allowGlobalAccess. It is
+	// only supported by regional internal load balancers.
+	FeatureAllowGlobalAccess Feature = "GCPGatewayPolicy.allowGlobalAccess"
+	// FeatureRegion is GCPGatewayPolicy region. It is only supported by
+	// regional multi-cluster Gateways.
+	FeatureRegion Feature = "GCPGatewayPolicy.region"
+	// FeatureSecurityPolicy is GCPBackendPolicy securityPolicy. Cloud Armor is
+	// only supported by external load balancers.
+	FeatureSecurityPolicy Feature = "GCPBackendPolicy.securityPolicy"
+	// FeatureEdgeSecurityPolicy is GCPBackendPolicy edgeSecurityPolicy. Cloud
+	// Armor edge security policies are only supported by global external load
+	// balancers.
+	FeatureEdgeSecurityPolicy Feature = "GCPBackendPolicy.edgeSecurityPolicy"
+	// FeatureIAP is GCPBackendPolicy iap. Identity-Aware Proxy is not supported
+	// by the cross-region internal load balancer.
+	FeatureIAP Feature = "GCPBackendPolicy.iap"
+	// FeatureBackendPreference is GCPBackendPolicy backendPreference. It is only
+	// supported by multi-cluster Gateways.
+	FeatureBackendPreference Feature = "GCPBackendPolicy.backendPreference"
+	// FeatureCircuitBreakers is GCPBackendPolicy circuitBreakers. It is not
+	// supported by the classic Application Load Balancer.
+	FeatureCircuitBreakers Feature = "GCPBackendPolicy.circuitBreakers"
+	// FeatureOutlierDetection is GCPBackendPolicy outlierDetection. It is not
+	// supported by the classic Application Load Balancer.
+	FeatureOutlierDetection Feature = "GCPBackendPolicy.outlierDetection"
+	// FeatureConsistentHash is GCPBackendPolicy consistentHash. It is not
+	// supported by the classic Application Load Balancer.
+	FeatureConsistentHash Feature = "GCPBackendPolicy.consistentHash"
+	// FeatureCDN is GCPBackendPolicy cdn. Cloud CDN is only supported by global
+	// external load balancers.
+	FeatureCDN Feature = "GCPBackendPolicy.cdn"
+	// FeatureBackendTLS is GCPBackendPolicy tls. Backend TLS validation is not
+	// supported by the classic Application Load Balancer.
+	FeatureBackendTLS Feature = "GCPBackendPolicy.tls"
+)
+
+// all is used for capabilities that are supported by every GatewayClass.
+func all(Class) bool { return true }
+
+// managed is used for capabilities that are only supported by the Envoy
+// based load balancers, i.e. not by the classic Application Load Balancer.
+func managed(c Class) bool { return c.Scheme != External }
+
+var kindSupport = map[PolicyKind]func(Class) bool{
+	GCPGatewayPolicyKind:               all,
+	GCPBackendPolicyKind:               all,
+	HealthCheckPolicyKind:              all,
+	GCPSessionAffinityPolicyKind:       managed,
+	GCPSessionAffinityFilterKind:       managed,
+	GCPTrafficDistributionPolicyKind:   managed,
+	GCPTrafficExtensionKind:            managed,
+	GCPRoutingExtensionKind:            func(c Class) bool { return c.IsRegional() },
+	GCPAuthzPolicyKind:                 managed,
+	GCPRequestAuthenticationPolicyKind: managed,
+	GCPAuthzExtensionKind:              managed,
+}
+
+var featureSupport = map[Feature]func(Class) bool{
+	FeatureAllowGlobalAccess:  func(c Class) bool { return c.IsRegional() && c.IsInternal() },
+	FeatureRegion:             func(c Class) bool { return c.IsRegional() && c.MultiCluster },
+	FeatureSecurityPolicy:     func(c Class) bool { return !c.IsInternal() },
+	FeatureEdgeSecurityPolicy: func(c Class) bool { return c.IsGlobal() && !c.IsInternal() },
+	FeatureIAP:                func(c Class) bool { return !(c.IsGlobal() && c.IsInternal()) },
+	FeatureBackendPreference:  func(c Class) bool { return c.MultiCluster },
+	FeatureCircuitBreakers:    managed,
+	FeatureOutlierDetection:   managed,
+	FeatureConsistentHash:     managed,
+	FeatureCDN:                func(c Class) bool { return c.IsGlobal() && !c.IsInternal() },
+	FeatureBackendTLS:         managed,
+}
+
+// SupportsKind returns true if resources of the given kind can be applied to
+// Gateways of this class.
+func (c Class) SupportsKind(kind PolicyKind) bool {
+	supported, ok := kindSupport[kind]
+	return ok && supported(c)
+}
+
+// Supports returns true if the given feature can be used with Gateways of
+// this class.
+func (c Class) Supports(feature Feature) bool {
+	supported, ok := featureSupport[feature]
+	return ok && supported(c)
+}
+
+// Capabilities lists the policy kinds and features supported by a GatewayClass.
+type Capabilities struct {
+	// Kinds is the sorted list of supported policy kinds.
+	Kinds []PolicyKind
+	// Features is the sorted list of supported features.
+	Features []Feature
+}
+
+// Capabilities returns the policy kinds and features supported by this class.
+func (c Class) Capabilities() Capabilities {
+	var caps Capabilities
+	for kind, supported := range kindSupport {
+		if supported(c) {
+			caps.Kinds = append(caps.Kinds, kind)
+		}
+	}
+	for feature, supported := range featureSupport {
+		if supported(c) {
+			caps.Features = append(caps.Features, feature)
+		}
+	}
+	sort.Slice(caps.Kinds, func(i, j int) bool { return caps.Kinds[i] < caps.Kinds[j] })
+	sort.Slice(caps.Features, func(i, j int) bool { return caps.Features[i] < caps.Features[j] })
+	return caps
+}
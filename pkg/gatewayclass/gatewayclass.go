@@ -0,0 +1,129 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package gatewayclass describes the GatewayClasses provided by GKE and the
+// Google Cloud load balancers that back them.
+package gatewayclass
+
+import "sort"
+
+// Name is the name of a GatewayClass managed by GKE.
+type Name string
+
+const (
+	// GlobalExternalManaged is the global external Application Load Balancer.
+	GlobalExternalManaged Name = "gke-l7-global-external-managed"
+	// GlobalExternalManagedMC is the multi-cluster global external Application Load Balancer.
+	GlobalExternalManagedMC Name = "gke-l7-global-external-managed-mc"
+	// RegionalExternalManaged is the regional external Application Load Balancer.
+	RegionalExternalManaged Name = "gke-l7-regional-external-managed"
+	// RegionalExternalManagedMC is the multi-cluster regional external Application Load Balancer.
+	RegionalExternalManagedMC Name = "gke-l7-regional-external-managed-mc"
+	// RegionalInternal is the regional internal Application Load Balancer.
+	RegionalInternal Name = "gke-l7-rilb"
+	// RegionalInternalMC is the multi-cluster regional internal Application Load Balancer.
+	RegionalInternalMC Name = "gke-l7-rilb-mc"
+	// CrossRegionalInternalManagedMC is the multi-cluster cross-region internal
+	// Application Load Balancer.
+	CrossRegionalInternalManagedMC Name = "gke-l7-cross-regional-internal-managed-mc"
+	// ClassicGlobalExternal is the classic Application Load Balancer.
+	ClassicGlobalExternal Name = "gke-l7-gxlb"
+	// ClassicGlobalExternalMC is the multi-cluster classic Application Load Balancer.
+	ClassicGlobalExternalMC Name = "gke-l7-gxlb-mc"
+)
+
+// Scope is the scope of the Google Cloud resources created for a GatewayClass.
+type Scope string
+
+const (
+	// Global means that the forwarding rule, target proxy and backend services
+	// are global resources.
+	Global Scope = "global"
+	// Regional means that the forwarding rule, target proxy and backend services
+	// are regional resources.
+	Regional Scope = "regional"
+)
+
+// LoadBalancingScheme is the load balancing scheme of the backend services
+// created for a GatewayClass.
+// See loadBalancingScheme in https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
+type LoadBalancingScheme string
+
+const (
+	// External is used by the classic Application Load Balancer.
+	External LoadBalancingScheme = "EXTERNAL"
+	// ExternalManaged is used by the Envoy based external Application Load Balancers.
+	ExternalManaged LoadBalancingScheme = "EXTERNAL_MANAGED"
+	// InternalManaged is used by the Envoy based internal Application Load Balancers.
+	InternalManaged LoadBalancingScheme = "INTERNAL_MANAGED"
+)
+
+// Class describes the load balancer that backs a GKE GatewayClass.
+type Class struct {
+	// Name is the name of the GatewayClass.
+	Name Name
+	// Scope is the scope of the load balancer resources.
+	Scope Scope
+	// Scheme is the load balancing scheme of the backend services.
+	Scheme LoadBalancingScheme
+	// MultiCluster is true for GatewayClasses that are used with
+	// multi-cluster Gateways.
+	MultiCluster bool
+}
+
+// IsGlobal returns true if the GatewayClass is backed by a global load balancer.
+func (c Class) IsGlobal() bool {
+	return c.Scope == Global
+}
+
+// IsRegional returns true if the GatewayClass is backed by a regional load balancer.
+func (c Class) IsRegional() bool {
+	return c.Scope == Regional
+}
+
+// IsInternal returns true if the GatewayClass is backed by an internal load balancer.
+func (c Class) IsInternal() bool {
+	return c.Scheme == InternalManaged
+}
+
+var classes = map[Name]Class{
+	GlobalExternalManaged:          {Name: GlobalExternalManaged, Scope: Global, Scheme: ExternalManaged},
+	GlobalExternalManagedMC:        {Name: GlobalExternalManagedMC, Scope: Global, Scheme: ExternalManaged, MultiCluster: true},
+	RegionalExternalManaged:        {Name: RegionalExternalManaged, Scope: Regional, Scheme: ExternalManaged},
+	RegionalExternalManagedMC:      {Name: RegionalExternalManagedMC, Scope: Regional, Scheme: ExternalManaged, MultiCluster: true},
+	RegionalInternal:               {Name: RegionalInternal, Scope: Regional, Scheme: InternalManaged},
+	RegionalInternalMC:             {Name: RegionalInternalMC, Scope: Regional, Scheme: InternalManaged, MultiCluster: true},
+	CrossRegionalInternalManagedMC: {Name: CrossRegionalInternalManagedMC, Scope: Global, Scheme: InternalManaged, MultiCluster: true},
+	ClassicGlobalExternal:          {Name: ClassicGlobalExternal, Scope: Global, Scheme: External},
+	ClassicGlobalExternalMC:        {Name: ClassicGlobalExternalMC, Scope: Global, Scheme: External, MultiCluster: true},
+}
+
+// Lookup returns the Class with the given name. The second return value is
+// false if the name is not a GatewayClass managed by GKE.
+func Lookup(name string) (Class, bool) {
+	c, ok := classes[Name(name)]
+	return c, ok
+}
+
+// All returns all GatewayClasses managed by GKE, sorted by name.
+func All() []Class {
+	ret := make([]Class, 0, len(classes))
+	for _, c := range classes {
+		ret = append(ret, c)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret
+}
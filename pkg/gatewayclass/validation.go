@@ -0,0 +1,150 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package gatewayclass
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// featureUse is a feature used by a policy and the path of the field that
+// uses it.
+type featureUse struct {
+	feature Feature
+	path    *field.Path
+}
+
+// ValidatePolicy checks that the given policy and the fields it sets are
+// supported by the GatewayClass of every Gateway the policy is attached to.
+//
+// The returned map contains the errors for each GatewayClass that does not
+// support the policy. GatewayClasses that are not managed by GKE are ignored.
+func ValidatePolicy(obj runtime.Object, gatewayClassNames []string) (map[Name]field.ErrorList, error) {
+	kind, uses, err := policyFeatures(obj)
+	if err != nil {
+		return nil, err
+	}
+	ret := map[Name]field.ErrorList{}
+	for _, name := range gatewayClassNames {
+		class, ok := Lookup(name)
+		if !ok {
+			continue
+		}
+		if errs := validate(kind, uses, class); len(errs) > 0 {
+			ret[class.Name] = errs
+		}
+	}
+	return ret, nil
+}
+
+// ValidatePolicyForClass checks that the given policy and the fields it sets
+// are supported by a single GatewayClass.
+func ValidatePolicyForClass(obj runtime.Object, class Class) (field.ErrorList, error) {
+	kind, uses, err := policyFeatures(obj)
+	if err != nil {
+		return nil, err
+	}
+	return validate(kind, uses, class), nil
+}
+
+func validate(kind PolicyKind, uses []featureUse, class Class) field.ErrorList {
+	var allErrs field.ErrorList
+	if !class.SupportsKind(kind) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("kind"), fmt.Sprintf("%s is not supported by GatewayClass %s", kind, class.Name)))
+		return allErrs
+	}
+	for _, use := range uses {
+		if !class.Supports(use.feature) {
+			allErrs = append(allErrs, field.Forbidden(use.path, fmt.Sprintf("not supported by GatewayClass %s", class.Name)))
+		}
+	}
+	return allErrs
+}
+
+// policyFeatures returns the kind of the given policy and the class dependent
+// features it uses.
+func policyFeatures(obj runtime.Object) (PolicyKind, []featureUse, error) {
+	switch p := obj.(type) {
+	case *networkingv1.GCPGatewayPolicy:
+		var uses []featureUse
+		if cfg := p.Spec.Default; cfg != nil {
+			fldPath := field.NewPath("spec", "default")
+			if cfg.AllowGlobalAccess {
+				uses = append(uses, featureUse{FeatureAllowGlobalAccess, fldPath.Child("allowGlobalAccess")})
+			}
+			if cfg.Region != "" {
+				uses = append(uses, featureUse{FeatureRegion, fldPath.Child("region")})
+			}
+		}
+		return GCPGatewayPolicyKind, uses, nil
+	case *networkingv1.GCPBackendPolicy:
+		var uses []featureUse
+		if cfg := p.Spec.Default; cfg != nil {
+			fldPath := field.NewPath("spec", "default")
+			if cfg.SecurityPolicy != nil && *cfg.SecurityPolicy != "" {
+				uses = append(uses, featureUse{FeatureSecurityPolicy, fldPath.Child("securityPolicy")})
+			}
+			if cfg.EdgeSecurityPolicy != nil && *cfg.EdgeSecurityPolicy != "" {
+				uses = append(uses, featureUse{FeatureEdgeSecurityPolicy, fldPath.Child("edgeSecurityPolicy")})
+			}
+			if cfg.IAP != nil && cfg.IAP.Enabled != nil && *cfg.IAP.Enabled {
+				uses = append(uses, featureUse{FeatureIAP, fldPath.Child("iap")})
+			}
+			if cfg.BackendPreference != nil && *cfg.BackendPreference != "DEFAULT" {
+				uses = append(uses, featureUse{FeatureBackendPreference, fldPath.Child("backendPreference")})
+			}
+			if cfg.CircuitBreakers != nil {
+				uses = append(uses, featureUse{FeatureCircuitBreakers, fldPath.Child("circuitBreakers")})
+			}
+			if cfg.OutlierDetection != nil {
+				uses = append(uses, featureUse{FeatureOutlierDetection, fldPath.Child("outlierDetection")})
+			}
+			if cfg.ConsistentHash != nil {
+				uses = append(uses, featureUse{FeatureConsistentHash, fldPath.Child("consistentHash")})
+			}
+			if cfg.CDN != nil && cfg.CDN.Enabled != nil && *cfg.CDN.Enabled {
+				uses = append(uses, featureUse{FeatureCDN, fldPath.Child("cdn")})
+			}
+			if cfg.TLS != nil {
+				uses = append(uses, featureUse{FeatureBackendTLS, fldPath.Child("tls")})
+			}
+		}
+		return GCPBackendPolicyKind, uses, nil
+	case *networkingv1.HealthCheckPolicy:
+		return HealthCheckPolicyKind, nil, nil
+	case *networkingv1.GCPSessionAffinityPolicy:
+		return GCPSessionAffinityPolicyKind, nil, nil
+	case *networkingv1.GCPSessionAffinityFilter:
+		return GCPSessionAffinityFilterKind, nil, nil
+	case *networkingv1.GCPTrafficDistributionPolicy:
+		return GCPTrafficDistributionPolicyKind, nil, nil
+	case *networkingv1.GCPTrafficExtension:
+		return GCPTrafficExtensionKind, nil, nil
+	case *networkingv1.GCPAuthzPolicy:
+		return GCPAuthzPolicyKind, nil, nil
+	case *networkingv1.GCPRequestAuthenticationPolicy:
+		return GCPRequestAuthenticationPolicyKind, nil, nil
+	case *networkingv1.GCPAuthzExtension:
+		return GCPAuthzExtensionKind, nil, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported policy type %T", obj)
+	}
+}
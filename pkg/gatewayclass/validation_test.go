@@ -0,0 +1,104 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package gatewayclass
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	enabled := true
+	securityPolicy := "my-policy"
+	backendPolicy := &networkingv1.GCPBackendPolicy{
+		Spec: networkingv1.GCPBackendPolicySpec{
+			Default: &networkingv1.GCPBackendPolicyConfig{
+				SecurityPolicy: &securityPolicy,
+				IAP:            &networkingv1.IdentityAwareProxyConfig{Enabled: &enabled},
+			},
+		},
+	}
+	gatewayPolicy := &networkingv1.GCPGatewayPolicy{
+		Spec: networkingv1.GCPGatewayPolicySpec{
+			Default: &networkingv1.GCPGatewayPolicyConfig{AllowGlobalAccess: true},
+		},
+	}
+
+	for _, tc := range []struct {
+		desc    string
+		policy  runtime.Object
+		classes []string
+		want    map[Name][]string
+	}{
+		{
+			desc:    "Cloud Armor on internal class",
+			policy:  backendPolicy,
+			classes: []string{string(GlobalExternalManaged), string(RegionalInternal)},
+			want:    map[Name][]string{RegionalInternal: {"spec.default.securityPolicy"}},
+		},
+		{
+			desc:    "global access on global class",
+			policy:  gatewayPolicy,
+			classes: []string{string(RegionalInternal), string(GlobalExternalManaged)},
+			want:    map[Name][]string{GlobalExternalManaged: {"spec.default.allowGlobalAccess"}},
+		},
+		{
+			desc:    "traffic extension on classic class",
+			policy:  &networkingv1.GCPTrafficExtension{},
+			classes: []string{string(ClassicGlobalExternal)},
+			want:    map[Name][]string{ClassicGlobalExternal: {"kind"}},
+		},
+		{
+			desc:    "unknown classes are ignored",
+			policy:  gatewayPolicy,
+			classes: []string{"example.com/gateway"},
+			want:    map[Name][]string{},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ValidatePolicy(tc.policy, tc.classes)
+			if err != nil {
+				t.Fatalf("ValidatePolicy() = %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("ValidatePolicy() = %v, want errors for %v", got, tc.want)
+			}
+			for class, paths := range tc.want {
+				errs := got[class]
+				if len(errs) != len(paths) {
+					t.Fatalf("ValidatePolicy()[%s] = %v, want errors for %v", class, errs, paths)
+				}
+				for i, err := range errs {
+					if err.Field != paths[i] {
+						t.Errorf("ValidatePolicy()[%s] = %v, want errors for %v", class, errs, paths)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRoutingExtensionIsRegionalOnly(t *testing.T) {
+	for _, class := range All() {
+		if got := class.SupportsKind(GCPRoutingExtensionKind); got != class.IsRegional() {
+			t.Errorf("%s.SupportsKind(%s) = %t, want %t", class.Name, GCPRoutingExtensionKind, got, class.IsRegional())
+		}
+	}
+}
@@ -0,0 +1,48 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package gatewaypolicy contains helpers to parse and validate GCPGatewayPolicy
+// configuration.
+package gatewaypolicy
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/computeref"
+)
+
+// SSLPolicyCollection is the collection of SslPolicy resources in the compute API.
+const SSLPolicyCollection = "sslPolicies"
+
+// SSLPolicyReference is a parsed GCPGatewayPolicyConfig.SslPolicy.
+type SSLPolicyReference = computeref.Reference
+
+// ParseSSLPolicy parses the value of GCPGatewayPolicyConfig.SslPolicy.
+//
+// The following forms are accepted:
+//
+//	[name]
+//	projects/[projectID]/global/sslPolicies/[name]
+//	projects/[projectID]/regions/[region]/sslPolicies/[name]
+//
+// Paths may also be given as fully qualified compute API URLs.
+func ParseSSLPolicy(s string) (*SSLPolicyReference, error) {
+	ref, err := computeref.Parse(s, SSLPolicyCollection)
+	if err != nil {
+		return nil, fmt.Errorf("sslPolicy: %w", err)
+	}
+	return ref, nil
+}
@@ -0,0 +1,159 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package gatewaypolicy
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/computeref"
+)
+
+func TestParseSSLPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		in      string
+		want    SSLPolicyReference
+		wantErr bool
+	}{
+		{
+			desc: "raw name",
+			in:   "my-policy",
+			want: SSLPolicyReference{Collection: SSLPolicyCollection, Name: "my-policy"},
+		},
+		{
+			desc: "global path",
+			in:   "projects/my-project/global/sslPolicies/my-policy",
+			want: SSLPolicyReference{Project: "my-project", Scope: computeref.ScopeGlobal, Collection: SSLPolicyCollection, Name: "my-policy"},
+		},
+		{
+			desc: "regional path",
+			in:   "projects/my-project/regions/us-central1/sslPolicies/my-policy",
+			want: SSLPolicyReference{Project: "my-project", Scope: computeref.ScopeRegional, Region: "us-central1", Collection: SSLPolicyCollection, Name: "my-policy"},
+		},
+		{
+			desc: "compute URL",
+			in:   "https://www.googleapis.com/compute/v1/projects/my-project/global/sslPolicies/my-policy",
+			want: SSLPolicyReference{Project: "my-project", Scope: computeref.ScopeGlobal, Collection: SSLPolicyCollection, Name: "my-policy"},
+		},
+		{
+			desc:    "empty",
+			in:      "",
+			wantErr: true,
+		},
+		{
+			desc:    "wrong collection",
+			in:      "projects/my-project/global/backendServices/my-policy",
+			wantErr: true,
+		},
+		{
+			desc:    "invalid name",
+			in:      "My_Policy",
+			wantErr: true,
+		},
+		{
+			desc:    "invalid region",
+			in:      "projects/my-project/regions/central/sslPolicies/my-policy",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseSSLPolicy(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ParseSSLPolicy(%q) = %v, want error %t", tc.in, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if *got != tc.want {
+				t.Errorf("ParseSSLPolicy(%q) = %+v, want %+v", tc.in, *got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		cfg       networkingv1.GCPGatewayPolicyConfig
+		class     string
+		wantPaths []string
+	}{
+		{
+			desc:  "raw name on global class",
+			cfg:   networkingv1.GCPGatewayPolicyConfig{SslPolicy: "my-policy"},
+			class: "gke-l7-global-external-managed",
+		},
+		{
+			desc:      "regional policy on global class",
+			cfg:       networkingv1.GCPGatewayPolicyConfig{SslPolicy: "projects/my-project/regions/us-central1/sslPolicies/my-policy"},
+			class:     "gke-l7-global-external-managed",
+			wantPaths: []string{"default.sslPolicy"},
+		},
+		{
+			desc:  "regional policy on regional class",
+			cfg:   networkingv1.GCPGatewayPolicyConfig{SslPolicy: "projects/my-project/regions/us-central1/sslPolicies/my-policy"},
+			class: "gke-l7-rilb",
+		},
+		{
+			desc:      "regional policy in another region",
+			cfg:       networkingv1.GCPGatewayPolicyConfig{SslPolicy: "projects/my-project/regions/us-central1/sslPolicies/my-policy", Region: "europe-west1"},
+			class:     "gke-l7-rilb-mc",
+			wantPaths: []string{"default.sslPolicy"},
+		},
+		{
+			desc:  "global access on internal regional class",
+			cfg:   networkingv1.GCPGatewayPolicyConfig{AllowGlobalAccess: true},
+			class: "gke-l7-rilb",
+		},
+		{
+			desc:      "global access on global class",
+			cfg:       networkingv1.GCPGatewayPolicyConfig{AllowGlobalAccess: true},
+			class:     "gke-l7-global-external-managed",
+			wantPaths: []string{"default.allowGlobalAccess"},
+		},
+		{
+			desc:      "region on single cluster class",
+			cfg:       networkingv1.GCPGatewayPolicyConfig{Region: "us-central1"},
+			class:     "gke-l7-rilb",
+			wantPaths: []string{"default.region"},
+		},
+		{
+			desc:  "unknown class",
+			cfg:   networkingv1.GCPGatewayPolicyConfig{AllowGlobalAccess: true},
+			class: "example.com/other",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			errs := ValidateConfig(&tc.cfg, tc.class, field.NewPath("default"))
+			var gotPaths []string
+			for _, err := range errs {
+				gotPaths = append(gotPaths, err.Field)
+			}
+			if len(gotPaths) != len(tc.wantPaths) {
+				t.Fatalf("ValidateConfig() = %v, want errors for %v", errs, tc.wantPaths)
+			}
+			for i := range gotPaths {
+				if gotPaths[i] != tc.wantPaths[i] {
+					t.Errorf("ValidateConfig() = %v, want errors for %v", errs, tc.wantPaths)
+				}
+			}
+		})
+	}
+}
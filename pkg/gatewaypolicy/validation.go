@@ -0,0 +1,87 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package gatewaypolicy
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/computeref"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/gatewayclass"
+)
+
+// ValidateConfig validates a GCPGatewayPolicyConfig against the GatewayClass
+// of the targeted Gateway.
+//
+// If gatewayClassName is not a GatewayClass managed by GKE, only the syntax of
+// the configuration is validated.
+func ValidateConfig(cfg *networkingv1.GCPGatewayPolicyConfig, gatewayClassName string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if cfg == nil {
+		return allErrs
+	}
+
+	var sslPolicy *SSLPolicyReference
+	if cfg.SslPolicy != "" {
+		ref, err := ParseSSLPolicy(cfg.SslPolicy)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sslPolicy"), cfg.SslPolicy, err.Error()))
+		} else {
+			sslPolicy = ref
+		}
+	}
+	if cfg.Region != "" && !computeref.IsValidRegion(cfg.Region) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("region"), cfg.Region, "must be a Google Cloud region, e.g. us-central1"))
+	}
+
+	class, ok := gatewayclass.Lookup(gatewayClassName)
+	if !ok {
+		return allErrs
+	}
+
+	if sslPolicy != nil {
+		if want := requiredSSLPolicyScope(class); !sslPolicy.IsRawName() && sslPolicy.Scope != want {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sslPolicy"), cfg.SslPolicy,
+				fmt.Sprintf("a %s SslPolicy cannot be used with GatewayClass %s, which requires a %s SslPolicy", sslPolicy.Scope, class.Name, want)))
+		}
+		if sslPolicy.Scope == computeref.ScopeRegional && cfg.Region != "" && sslPolicy.Region != cfg.Region {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sslPolicy"), cfg.SslPolicy,
+				fmt.Sprintf("SslPolicy region %s does not match the load balancer region %s", sslPolicy.Region, cfg.Region)))
+		}
+	}
+
+	if cfg.AllowGlobalAccess && !class.Supports(gatewayclass.FeatureAllowGlobalAccess) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("allowGlobalAccess"), cfg.AllowGlobalAccess,
+			fmt.Sprintf("only supported by regional internal GatewayClasses, not %s", class.Name)))
+	}
+	if cfg.Region != "" && !class.Supports(gatewayclass.FeatureRegion) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("region"), cfg.Region,
+			fmt.Sprintf("only supported by regional multi-cluster GatewayClasses, not %s", class.Name)))
+	}
+	return allErrs
+}
+
+// requiredSSLPolicyScope returns the scope of the SslPolicies that can be
+// attached to the target proxy of the given GatewayClass.
+func requiredSSLPolicyScope(class gatewayclass.Class) computeref.Scope {
+	if class.IsGlobal() {
+		return computeref.ScopeGlobal
+	}
+	return computeref.ScopeRegional
+}
@@ -0,0 +1,342 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package healthcheck
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// Defaults of HealthCheckPolicyConfig.
+const (
+	DefaultCheckIntervalSec   = 5
+	DefaultTimeoutSec         = 5
+	DefaultHealthyThreshold   = 2
+	DefaultUnhealthyThreshold = 2
+	// DefaultCheckName is the name of the health check defined by Config, or
+	// derived from a readinessProbe.
+	DefaultCheckName = "default"
+)
+
+// Bounds of the HealthCheckPolicyConfig fields, which also apply to values
+// derived from a readinessProbe.
+const (
+	minIntervalSec  = 1
+	maxIntervalSec  = 300
+	minThreshold    = 1
+	maxThreshold    = 10
+	maxNamedChecks  = 4
+	readinessSource = "readinessProbe"
+)
+
+// Effective is a HealthCheckPolicyConfig with all defaults applied.
+type Effective struct {
+	CheckIntervalSec   int64
+	TimeoutSec         int64
+	HealthyThreshold   int64
+	UnhealthyThreshold int64
+	// Checks are the health checks of the policy. Config is normalized into
+	// a single check named DefaultCheckName.
+	Checks []networkingv1.NamedHealthCheck
+	// Combination specifies how the results of Checks are combined.
+	Combination networkingv1.HealthCheckCombination
+	// FromReadinessProbe is true if Checks were derived from a readinessProbe.
+	FromReadinessProbe bool
+}
+
+// Resolve applies the defaults to the given configuration. container is the
+// container whose readinessProbe is used if FromReadinessProbe is set, see
+// ReadinessContainer. It can be nil otherwise. A nil configuration resolves to
+// the default HTTP health check on the serving port.
+func Resolve(cfg *networkingv1.HealthCheckPolicyConfig, container *corev1.Container) (*Effective, error) {
+	eff := &Effective{
+		CheckIntervalSec:   DefaultCheckIntervalSec,
+		TimeoutSec:         DefaultTimeoutSec,
+		HealthyThreshold:   DefaultHealthyThreshold,
+		UnhealthyThreshold: DefaultUnhealthyThreshold,
+		Combination:        networkingv1.HealthCheckCombinationAll,
+	}
+	if cfg == nil {
+		cfg = &networkingv1.HealthCheckPolicyConfig{}
+	}
+
+	switch {
+	case len(cfg.Checks) > 0:
+		eff.Checks = cfg.Checks
+		if cfg.Combination != nil {
+			eff.Combination = *cfg.Combination
+		}
+	case cfg.Config != nil:
+		eff.Checks = []networkingv1.NamedHealthCheck{{Name: DefaultCheckName, Config: *cfg.Config}}
+	case cfg.FromReadinessProbe != nil && *cfg.FromReadinessProbe:
+		if container == nil || container.ReadinessProbe == nil {
+			return nil, errors.New("fromReadinessProbe is enabled but no container with a readinessProbe was found")
+		}
+		hc, err := FromReadinessProbe(container)
+		if err != nil {
+			return nil, err
+		}
+		eff.Checks = []networkingv1.NamedHealthCheck{{Name: DefaultCheckName, Config: *hc}}
+		eff.FromReadinessProbe = true
+		p := container.ReadinessProbe
+		if p.PeriodSeconds > 0 {
+			eff.CheckIntervalSec = clamp(int64(p.PeriodSeconds), minIntervalSec, maxIntervalSec)
+		}
+		if p.TimeoutSeconds > 0 {
+			eff.TimeoutSec = clamp(int64(p.TimeoutSeconds), minIntervalSec, eff.CheckIntervalSec)
+		} else if eff.TimeoutSec > eff.CheckIntervalSec {
+			eff.TimeoutSec = eff.CheckIntervalSec
+		}
+		if p.SuccessThreshold > 0 {
+			eff.HealthyThreshold = clamp(int64(p.SuccessThreshold), minThreshold, maxThreshold)
+		}
+		if p.FailureThreshold > 0 {
+			eff.UnhealthyThreshold = clamp(int64(p.FailureThreshold), minThreshold, maxThreshold)
+		}
+	default:
+		eff.Checks = []networkingv1.NamedHealthCheck{{
+			Name:   DefaultCheckName,
+			Config: networkingv1.HealthCheck{Type: networkingv1.HTTP, HTTP: &networkingv1.HTTPHealthCheck{}},
+		}}
+	}
+
+	// Explicit fields take precedence over the readinessProbe.
+	if cfg.CheckIntervalSec != nil {
+		eff.CheckIntervalSec = *cfg.CheckIntervalSec
+	}
+	if cfg.TimeoutSec != nil {
+		eff.TimeoutSec = *cfg.TimeoutSec
+	}
+	if cfg.HealthyThreshold != nil {
+		eff.HealthyThreshold = *cfg.HealthyThreshold
+	}
+	if cfg.UnhealthyThreshold != nil {
+		eff.UnhealthyThreshold = *cfg.UnhealthyThreshold
+	}
+	if eff.TimeoutSec > eff.CheckIntervalSec {
+		return nil, fmt.Errorf("timeoutSec %d cannot exceed checkIntervalSec %d", eff.TimeoutSec, eff.CheckIntervalSec)
+	}
+	return eff, nil
+}
+
+// FromReadinessProbe derives a health check from the readinessProbe of the
+// given container. Named ports are resolved against the ports of the
+// container, so the health check always uses USE_FIXED_PORT.
+func FromReadinessProbe(container *corev1.Container) (*networkingv1.HealthCheck, error) {
+	p := container.ReadinessProbe
+	if p == nil {
+		return nil, fmt.Errorf("container %s has no readinessProbe", container.Name)
+	}
+	fixed := networkingv1.UseFixedPort
+	common := func(port intstr.IntOrString) (networkingv1.CommonHealthCheck, error) {
+		n, err := containerPort(container, port)
+		if err != nil {
+			return networkingv1.CommonHealthCheck{}, err
+		}
+		return networkingv1.CommonHealthCheck{PortSpecification: &fixed, Port: &n}, nil
+	}
+	switch {
+	case p.HTTPGet != nil:
+		c, err := common(p.HTTPGet.Port)
+		if err != nil {
+			return nil, err
+		}
+		var h networkingv1.CommonHTTPHealthCheck
+		if p.HTTPGet.Path != "" {
+			path := p.HTTPGet.Path
+			h.RequestPath = &path
+		}
+		for _, header := range p.HTTPGet.HTTPHeaders {
+			if !strings.EqualFold(header.Name, "Host") {
+				return nil, fmt.Errorf("%s of container %s sets header %s, health checks can only set the Host header", readinessSource, container.Name, header.Name)
+			}
+			host := header.Value
+			h.Host = &host
+		}
+		if p.HTTPGet.Scheme == corev1.URISchemeHTTPS {
+			return &networkingv1.HealthCheck{Type: networkingv1.HTTPS, HTTPS: &networkingv1.HTTPSHealthCheck{CommonHealthCheck: c, CommonHTTPHealthCheck: h}}, nil
+		}
+		return &networkingv1.HealthCheck{Type: networkingv1.HTTP, HTTP: &networkingv1.HTTPHealthCheck{CommonHealthCheck: c, CommonHTTPHealthCheck: h}}, nil
+	case p.TCPSocket != nil:
+		c, err := common(p.TCPSocket.Port)
+		if err != nil {
+			return nil, err
+		}
+		return &networkingv1.HealthCheck{Type: networkingv1.TCP, TCP: &networkingv1.TCPHealthCheck{CommonHealthCheck: c}}, nil
+	case p.GRPC != nil:
+		c, err := common(intstr.FromInt32(p.GRPC.Port))
+		if err != nil {
+			return nil, err
+		}
+		g := &networkingv1.GRPCHealthCheck{CommonHealthCheck: c}
+		if p.GRPC.Service != nil && *p.GRPC.Service != "" {
+			service := *p.GRPC.Service
+			g.GRPCServiceName = &service
+		}
+		return &networkingv1.HealthCheck{Type: networkingv1.GRPC, GRPC: g}, nil
+	case p.Exec != nil:
+		return nil, fmt.Errorf("%s of container %s is an exec probe, which cannot be used as a health check", readinessSource, container.Name)
+	default:
+		return nil, fmt.Errorf("%s of container %s has no handler", readinessSource, container.Name)
+	}
+}
+
+func containerPort(container *corev1.Container, port intstr.IntOrString) (int64, error) {
+	if port.Type == intstr.Int {
+		return int64(port.IntVal), nil
+	}
+	for _, p := range container.Ports {
+		if p.Name == port.StrVal {
+			return int64(p.ContainerPort), nil
+		}
+	}
+	return 0, fmt.Errorf("container %s has no port named %q", container.Name, port.StrVal)
+}
+
+// ReadinessContainer returns the container of the given Pod whose
+// readinessProbe describes the health of the given target port of the
+// Service. It prefers the container that exposes the target port, and falls
+// back to the only container with a readinessProbe. It returns nil if no
+// container qualifies.
+func ReadinessContainer(pod *corev1.Pod, targetPort intstr.IntOrString) *corev1.Container {
+	var withProbe []*corev1.Container
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if c.ReadinessProbe == nil {
+			continue
+		}
+		withProbe = append(withProbe, c)
+		for _, p := range c.Ports {
+			if (targetPort.Type == intstr.Int && p.ContainerPort == targetPort.IntVal) ||
+				(targetPort.Type == intstr.String && p.Name == targetPort.StrVal) {
+				return c
+			}
+		}
+	}
+	if len(withProbe) == 1 {
+		return withProbe[0]
+	}
+	return nil
+}
+
+// Validate validates the given configuration beyond what the CRD schema
+// enforces, and reports the violations of the CRD schema for configurations
+// that did not go through the API server.
+func Validate(cfg *networkingv1.HealthCheckPolicyConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if cfg == nil {
+		return allErrs
+	}
+	if cfg.Config != nil && len(cfg.Checks) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("checks"), "only one of config and checks can be specified"))
+	}
+	if cfg.Combination != nil && len(cfg.Checks) == 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("combination"), "can only be specified with checks"))
+	}
+	if cfg.FromReadinessProbe != nil && *cfg.FromReadinessProbe && (cfg.Config != nil || len(cfg.Checks) > 0) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("fromReadinessProbe"), "can only be enabled if neither config nor checks is specified"))
+	}
+	interval, timeout := int64(DefaultCheckIntervalSec), int64(DefaultTimeoutSec)
+	if cfg.CheckIntervalSec != nil {
+		interval = *cfg.CheckIntervalSec
+	}
+	if cfg.TimeoutSec != nil {
+		timeout = *cfg.TimeoutSec
+	}
+	if timeout > interval {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSec"), timeout, fmt.Sprintf("cannot exceed checkIntervalSec (%d)", interval)))
+	}
+
+	if cfg.Config != nil {
+		allErrs = append(allErrs, ValidateHealthCheck(cfg.Config, fldPath.Child("config"))...)
+	}
+	if len(cfg.Checks) > maxNamedChecks {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("checks"), len(cfg.Checks), maxNamedChecks))
+	}
+	names := map[string]bool{}
+	for i, c := range cfg.Checks {
+		idxPath := fldPath.Child("checks").Index(i)
+		if names[c.Name] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), c.Name))
+		}
+		names[c.Name] = true
+		allErrs = append(allErrs, ValidateHealthCheck(&c.Config, idxPath.Child("config"))...)
+	}
+	return allErrs
+}
+
+// ValidateHealthCheck validates that exactly the protocol configuration that
+// matches the type of the health check is set, and that its port fields match
+// its port specification.
+func ValidateHealthCheck(hc *networkingv1.HealthCheck, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	members := map[networkingv1.HealthCheckType]bool{
+		networkingv1.TCP:   hc.TCP != nil,
+		networkingv1.HTTP:  hc.HTTP != nil,
+		networkingv1.HTTPS: hc.HTTPS != nil,
+		networkingv1.HTTP2: hc.HTTP2 != nil,
+		networkingv1.GRPC:  hc.GRPC != nil,
+	}
+	set := 0
+	for _, ok := range members {
+		if ok {
+			set++
+		}
+	}
+	if _, ok := members[hc.Type]; !ok {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), hc.Type, []string{"TCP", "HTTP", "HTTPS", "HTTP2", "GRPC"}))
+	} else if !members[hc.Type] || set != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, hc.Type, "exactly the protocol configuration matching the type must be specified"))
+	}
+
+	c := Common(hc)
+	if c == nil {
+		return allErrs
+	}
+	switch PortSpecification(c) {
+	case networkingv1.UseFixedPort:
+		if c.Port == nil || c.PortName != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("portSpecification"), networkingv1.UseFixedPort, "port must be set and portName must be unset"))
+		}
+	case networkingv1.UseNamedPort:
+		if c.Port != nil || c.PortName == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("portSpecification"), networkingv1.UseNamedPort, "portName must be set and port must be unset"))
+		}
+	case networkingv1.UseServingPort:
+		if c.Port != nil || c.PortName != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("portSpecification"), networkingv1.UseServingPort, "port and portName must be unset"))
+		}
+	}
+	return allErrs
+}
+
+func clamp(v, lo, hi int64) int64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,218 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package healthcheck
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+func httpCheck(name string) networkingv1.NamedHealthCheck {
+	return networkingv1.NamedHealthCheck{
+		Name:   name,
+		Config: networkingv1.HealthCheck{Type: networkingv1.HTTP, HTTP: &networkingv1.HTTPHealthCheck{}},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	container := &corev1.Container{
+		Name:  "web",
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{
+				Path:        "/ready",
+				Port:        intstr.FromString("http"),
+				Scheme:      corev1.URISchemeHTTPS,
+				HTTPHeaders: []corev1.HTTPHeader{{Name: "Host", Value: "example.com"}},
+			}},
+			PeriodSeconds:    10,
+			TimeoutSeconds:   2,
+			SuccessThreshold: 1,
+			FailureThreshold: 30,
+		},
+	}
+
+	for _, tc := range []struct {
+		desc      string
+		cfg       *networkingv1.HealthCheckPolicyConfig
+		container *corev1.Container
+		want      Effective
+		wantErr   bool
+	}{
+		{
+			desc: "nil config",
+			want: Effective{
+				CheckIntervalSec: 5, TimeoutSec: 5, HealthyThreshold: 2, UnhealthyThreshold: 2,
+				Checks:      []networkingv1.NamedHealthCheck{httpCheck(DefaultCheckName)},
+				Combination: networkingv1.HealthCheckCombinationAll,
+			},
+		},
+		{
+			desc: "named checks",
+			cfg: &networkingv1.HealthCheckPolicyConfig{
+				CheckIntervalSec: ptr.To[int64](10),
+				Checks:           []networkingv1.NamedHealthCheck{httpCheck("a"), httpCheck("b")},
+				Combination:      ptr.To(networkingv1.HealthCheckCombinationAny),
+			},
+			want: Effective{
+				CheckIntervalSec: 10, TimeoutSec: 5, HealthyThreshold: 2, UnhealthyThreshold: 2,
+				Checks:      []networkingv1.NamedHealthCheck{httpCheck("a"), httpCheck("b")},
+				Combination: networkingv1.HealthCheckCombinationAny,
+			},
+		},
+		{
+			desc: "readiness probe",
+			cfg: &networkingv1.HealthCheckPolicyConfig{
+				FromReadinessProbe: ptr.To(true),
+				HealthyThreshold:   ptr.To[int64](3),
+			},
+			container: container,
+			want: Effective{
+				CheckIntervalSec: 10, TimeoutSec: 2, HealthyThreshold: 3, UnhealthyThreshold: 10,
+				Checks: []networkingv1.NamedHealthCheck{{
+					Name: DefaultCheckName,
+					Config: networkingv1.HealthCheck{Type: networkingv1.HTTPS, HTTPS: &networkingv1.HTTPSHealthCheck{
+						CommonHealthCheck:     networkingv1.CommonHealthCheck{PortSpecification: ptr.To(networkingv1.UseFixedPort), Port: ptr.To[int64](8080)},
+						CommonHTTPHealthCheck: networkingv1.CommonHTTPHealthCheck{Host: ptr.To("example.com"), RequestPath: ptr.To("/ready")},
+					}},
+				}},
+				Combination:        networkingv1.HealthCheckCombinationAll,
+				FromReadinessProbe: true,
+			},
+		},
+		{
+			desc:    "readiness probe without container",
+			cfg:     &networkingv1.HealthCheckPolicyConfig{FromReadinessProbe: ptr.To(true)},
+			wantErr: true,
+		},
+		{
+			desc: "exec readiness probe",
+			cfg:  &networkingv1.HealthCheckPolicyConfig{FromReadinessProbe: ptr.To(true)},
+			container: &corev1.Container{Name: "web", ReadinessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: []string{"true"}}},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "timeout exceeds interval",
+			cfg: &networkingv1.HealthCheckPolicyConfig{
+				CheckIntervalSec: ptr.To[int64](2),
+				TimeoutSec:       ptr.To[int64](3),
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := Resolve(tc.cfg, tc.container)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(*got, tc.want) {
+				t.Errorf("Resolve() = %+v, want %+v", *got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadinessContainer(t *testing.T) {
+	probe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(8080)}}}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "sidecar", ReadinessProbe: probe, Ports: []corev1.ContainerPort{{Name: "admin", ContainerPort: 9000}}},
+		{Name: "web", ReadinessProbe: probe, Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+	}}}
+	if c := ReadinessContainer(pod, intstr.FromString("http")); c == nil || c.Name != "web" {
+		t.Errorf("ReadinessContainer(http) = %v, want web", c)
+	}
+	if c := ReadinessContainer(pod, intstr.FromInt32(9000)); c == nil || c.Name != "sidecar" {
+		t.Errorf("ReadinessContainer(9000) = %v, want sidecar", c)
+	}
+	if c := ReadinessContainer(pod, intstr.FromInt32(7000)); c != nil {
+		t.Errorf("ReadinessContainer(7000) = %v, want nil", c.Name)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	config := httpCheck("").Config
+	for _, tc := range []struct {
+		desc    string
+		cfg     *networkingv1.HealthCheckPolicyConfig
+		wantErr int
+	}{
+		{
+			desc: "valid checks",
+			cfg: &networkingv1.HealthCheckPolicyConfig{
+				Checks:      []networkingv1.NamedHealthCheck{httpCheck("a"), httpCheck("b")},
+				Combination: ptr.To(networkingv1.HealthCheckCombinationAll),
+			},
+		},
+		{
+			desc: "config and checks",
+			cfg: &networkingv1.HealthCheckPolicyConfig{
+				Config: &config,
+				Checks: []networkingv1.NamedHealthCheck{httpCheck("a")},
+			},
+			wantErr: 1,
+		},
+		{
+			desc:    "combination without checks",
+			cfg:     &networkingv1.HealthCheckPolicyConfig{Combination: ptr.To(networkingv1.HealthCheckCombinationAny)},
+			wantErr: 1,
+		},
+		{
+			desc:    "readiness probe with config",
+			cfg:     &networkingv1.HealthCheckPolicyConfig{Config: &config, FromReadinessProbe: ptr.To(true)},
+			wantErr: 1,
+		},
+		{
+			desc:    "duplicate names",
+			cfg:     &networkingv1.HealthCheckPolicyConfig{Checks: []networkingv1.NamedHealthCheck{httpCheck("a"), httpCheck("a")}},
+			wantErr: 1,
+		},
+		{
+			desc: "mismatched type and port fields",
+			cfg: &networkingv1.HealthCheckPolicyConfig{Config: &networkingv1.HealthCheck{
+				Type: networkingv1.TCP,
+				HTTP: &networkingv1.HTTPHealthCheck{CommonHealthCheck: networkingv1.CommonHealthCheck{
+					PortSpecification: ptr.To(networkingv1.UseNamedPort),
+				}},
+			}},
+			wantErr: 2,
+		},
+		{
+			desc:    "timeout exceeds default interval",
+			cfg:     &networkingv1.HealthCheckPolicyConfig{TimeoutSec: ptr.To[int64](10)},
+			wantErr: 1,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			errs := Validate(tc.cfg, field.NewPath("spec", "default"))
+			if len(errs) != tc.wantErr {
+				t.Errorf("Validate() = %v, want %d errors", errs, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package healthcheck contains helpers to resolve HealthCheckPolicy
+// configuration against the backends it applies to.
+package healthcheck
+
+import (
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// Common returns the fields common to all protocols of the given health
+// check, or nil if no protocol specific configuration is set.
+func Common(hc *networkingv1.HealthCheck) *networkingv1.CommonHealthCheck {
+	switch {
+	case hc == nil:
+		return nil
+	case hc.TCP != nil:
+		return &hc.TCP.CommonHealthCheck
+	case hc.HTTP != nil:
+		return &hc.HTTP.CommonHealthCheck
+	case hc.HTTPS != nil:
+		return &hc.HTTPS.CommonHealthCheck
+	case hc.HTTP2 != nil:
+		return &hc.HTTP2.CommonHealthCheck
+	case hc.GRPC != nil:
+		return &hc.GRPC.CommonHealthCheck
+	default:
+		return nil
+	}
+}
+
+// PortSpecification returns the effective port specification of the given
+// common health check configuration. When PortSpecification is not set, a
+// Port selects USE_FIXED_PORT, a PortName selects USE_NAMED_PORT, and
+// USE_SERVING_PORT is used otherwise.
+func PortSpecification(c *networkingv1.CommonHealthCheck) networkingv1.PortSpecificationType {
+	switch {
+	case c == nil:
+		return networkingv1.UseServingPort
+	case c.PortSpecification != nil:
+		return *c.PortSpecification
+	case c.Port != nil:
+		return networkingv1.UseFixedPort
+	case c.PortName != nil:
+		return networkingv1.UseNamedPort
+	default:
+		return networkingv1.UseServingPort
+	}
+}
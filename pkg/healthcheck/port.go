@@ -0,0 +1,254 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package healthcheck
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// PortErrorReason is the reason of a PortError.
+type PortErrorReason string
+
+const (
+	// ReasonTargetMismatch means that the policy does not target the Service.
+	ReasonTargetMismatch PortErrorReason = "TargetMismatch"
+	// ReasonInvalidConfig means that the health check configuration of the
+	// policy cannot be resolved.
+	ReasonInvalidConfig PortErrorReason = "InvalidConfig"
+	// ReasonReadinessProbe means that the health check is derived from the
+	// readinessProbe of each Pod, so its port is not determined by the
+	// policy and the endpoints alone.
+	ReasonReadinessProbe PortErrorReason = "ReadinessProbe"
+	// ReasonInvalidPortSpecification means that the port fields of the
+	// policy are inconsistent with its port specification.
+	ReasonInvalidPortSpecification PortErrorReason = "InvalidPortSpecification"
+	// ReasonServicePortNotFound means that the Service has no port with the
+	// serving port number.
+	ReasonServicePortNotFound PortErrorReason = "ServicePortNotFound"
+	// ReasonNamedPortNotFound means that the endpoints do not expose a port
+	// with the name of the policy.
+	ReasonNamedPortNotFound PortErrorReason = "NamedPortNotFound"
+	// ReasonEndpointPortNotFound means that the EndpointSlice of an endpoint
+	// does not expose the serving port.
+	ReasonEndpointPortNotFound PortErrorReason = "EndpointPortNotFound"
+)
+
+// PortError is returned when the probe port of a HealthCheckPolicy cannot be
+// resolved.
+type PortError struct {
+	// Reason is the reason of the error.
+	Reason PortErrorReason
+	// Service is the targeted Service.
+	Service types.NamespacedName
+	// Check is the name of the health check whose port could not be
+	// resolved, if any.
+	Check string
+	// Port is the port that could not be resolved: a port name or number.
+	Port string
+	// EndpointSlice is the name of the EndpointSlice that lacks the port, if
+	// any.
+	EndpointSlice string
+	// Message is a human readable description of the error.
+	Message string
+}
+
+func (e *PortError) Error() string {
+	return e.Message
+}
+
+// EndpointPort is the port probed on an endpoint by one health check.
+type EndpointPort struct {
+	// Check is the name of the health check.
+	Check string
+	// Address is the address of the endpoint.
+	Address string
+	// TargetRef is the object that backs the endpoint, usually a Pod.
+	TargetRef *corev1.ObjectReference
+	// Ready is the readiness of the endpoint.
+	Ready bool
+	// Port is the port that is probed. 0 if Err is set.
+	Port int32
+	// Err is set if the port of this endpoint cannot be resolved.
+	Err *PortError
+}
+
+// ResolvePorts resolves the port probed on each endpoint of the given Service
+// by each health check of the given policy. servingPort is the Service port
+// that the load balancer sends traffic to, i.e. the port of the backendRef.
+// endpointSlices are the EndpointSlices of the Service.
+//
+// The result holds one EndpointPort per health check and endpoint, ordered by
+// health check in the order of the policy. A policy with a single Config
+// resolves to one check named DefaultCheckName.
+//
+// An error is returned, as a *PortError, if the port of any health check
+// cannot be resolved for any endpoint. Endpoints whose EndpointSlice does not
+// expose the port have their Err set. Policies that derive their health check
+// from a readinessProbe are rejected, as the port depends on the containers of
+// each Pod.
+func ResolvePorts(policy *networkingv1.HealthCheckPolicy, svc *corev1.Service, servingPort int32, endpointSlices []*discoveryv1.EndpointSlice) ([]EndpointPort, error) {
+	svcName := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+	target := policy.Spec.TargetRef
+	targetNamespace := policy.Namespace
+	if target.Namespace != nil && *target.Namespace != "" {
+		targetNamespace = string(*target.Namespace)
+	}
+	if target.Group != "" || target.Kind != "Service" || string(target.Name) != svc.Name || targetNamespace != svc.Namespace {
+		return nil, &PortError{Reason: ReasonTargetMismatch, Service: svcName,
+			Message: fmt.Sprintf("HealthCheckPolicy %s/%s does not target Service %s", policy.Namespace, policy.Name, svcName)}
+	}
+
+	cfg := policy.Spec.Default
+	if cfg != nil && len(cfg.Checks) == 0 && cfg.Config == nil && cfg.FromReadinessProbe != nil && *cfg.FromReadinessProbe {
+		return nil, &PortError{Reason: ReasonReadinessProbe, Service: svcName,
+			Message: fmt.Sprintf("HealthCheckPolicy %s/%s derives its health check from the readinessProbe of each Pod, whose ports cannot be resolved from the endpoints", policy.Namespace, policy.Name)}
+	}
+	eff, err := Resolve(cfg, nil)
+	if err != nil {
+		return nil, &PortError{Reason: ReasonInvalidConfig, Service: svcName,
+			Message: fmt.Sprintf("HealthCheckPolicy %s/%s: %v", policy.Namespace, policy.Name, err)}
+	}
+
+	sorted := sortedSlices(endpointSlices)
+	var ret []EndpointPort
+	for _, check := range eff.Checks {
+		portOf, perr := checkPortOf(Common(&check.Config), svc, servingPort, endpointSlices)
+		if perr != nil {
+			perr.Service = svcName
+			perr.Check = check.Name
+			if len(eff.Checks) > 1 {
+				perr.Message = fmt.Sprintf("health check %s: %s", check.Name, perr.Message)
+			}
+			return nil, perr
+		}
+		for _, slice := range sorted {
+			port, err := portOf(slice)
+			if err != nil {
+				err.Check = check.Name
+			}
+			for _, ep := range slice.Endpoints {
+				ready := ep.Conditions.Ready == nil || *ep.Conditions.Ready
+				for _, addr := range ep.Addresses {
+					ret = append(ret, EndpointPort{Check: check.Name, Address: addr, TargetRef: ep.TargetRef, Ready: ready, Port: port, Err: err})
+				}
+			}
+		}
+	}
+	return ret, nil
+}
+
+// checkPortOf returns a function that returns the port of the endpoints of an
+// EndpointSlice for the health check with the given common fields, or an error
+// if the EndpointSlice does not expose the port.
+func checkPortOf(common *networkingv1.CommonHealthCheck, svc *corev1.Service, servingPort int32, endpointSlices []*discoveryv1.EndpointSlice) (func(*discoveryv1.EndpointSlice) (int32, *PortError), *PortError) {
+	svcName := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+	switch spec := PortSpecification(common); spec {
+	case networkingv1.UseFixedPort:
+		if common == nil || common.Port == nil {
+			return nil, &PortError{Reason: ReasonInvalidPortSpecification,
+				Message: "port must be set for USE_FIXED_PORT"}
+		}
+		port := int32(*common.Port)
+		return func(*discoveryv1.EndpointSlice) (int32, *PortError) { return port, nil }, nil
+	case networkingv1.UseNamedPort:
+		if common == nil || common.PortName == nil || *common.PortName == "" {
+			return nil, &PortError{Reason: ReasonInvalidPortSpecification,
+				Message: "portName must be set for USE_NAMED_PORT"}
+		}
+		name := *common.PortName
+		if !exposesPort(endpointSlices, name) {
+			return nil, &PortError{Reason: ReasonNamedPortNotFound, Port: name,
+				Message: fmt.Sprintf("no endpoint of Service %s exposes a port named %q, available ports are %v", svcName, name, portNames(endpointSlices))}
+		}
+		return func(slice *discoveryv1.EndpointSlice) (int32, *PortError) {
+			return slicePort(slice, svcName, name, ReasonNamedPortNotFound)
+		}, nil
+	case networkingv1.UseServingPort:
+		var sp *corev1.ServicePort
+		for i := range svc.Spec.Ports {
+			if svc.Spec.Ports[i].Port == servingPort {
+				sp = &svc.Spec.Ports[i]
+				break
+			}
+		}
+		if sp == nil {
+			return nil, &PortError{Reason: ReasonServicePortNotFound, Port: strconv.Itoa(int(servingPort)),
+				Message: fmt.Sprintf("Service %s has no port %d", svcName, servingPort)}
+		}
+		name := sp.Name
+		return func(slice *discoveryv1.EndpointSlice) (int32, *PortError) {
+			return slicePort(slice, svcName, name, ReasonEndpointPortNotFound)
+		}, nil
+	default:
+		return nil, &PortError{Reason: ReasonInvalidPortSpecification,
+			Message: fmt.Sprintf("unsupported port specification %q", spec)}
+	}
+}
+
+// slicePort returns the number of the port with the given name in the given
+// EndpointSlice.
+func slicePort(slice *discoveryv1.EndpointSlice, svc types.NamespacedName, name string, reason PortErrorReason) (int32, *PortError) {
+	for _, p := range slice.Ports {
+		if p.Port != nil && p.Name != nil && *p.Name == name {
+			return *p.Port, nil
+		}
+	}
+	return 0, &PortError{Reason: reason, Service: svc, Port: name, EndpointSlice: slice.Name,
+		Message: fmt.Sprintf("EndpointSlice %s of Service %s does not expose port %q", slice.Name, svc, name)}
+}
+
+func exposesPort(slices []*discoveryv1.EndpointSlice, name string) bool {
+	for _, slice := range slices {
+		for _, p := range slice.Ports {
+			if p.Port != nil && p.Name != nil && *p.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func portNames(slices []*discoveryv1.EndpointSlice) []string {
+	seen := map[string]bool{}
+	var ret []string
+	for _, slice := range slices {
+		for _, p := range slice.Ports {
+			if p.Name != nil && *p.Name != "" && !seen[*p.Name] {
+				seen[*p.Name] = true
+				ret = append(ret, *p.Name)
+			}
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// sortedSlices returns the EndpointSlices sorted by name, so that the result
+// of ResolvePorts is deterministic.
+func sortedSlices(slices []*discoveryv1.EndpointSlice) []*discoveryv1.EndpointSlice {
+	ret := append([]*discoveryv1.EndpointSlice(nil), slices...)
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret
+}
@@ -0,0 +1,271 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package healthcheck
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+func slice(name string, ports map[string]int32, addrs ...string) *discoveryv1.EndpointSlice {
+	s := &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: name}}
+	for n, p := range ports {
+		s.Ports = append(s.Ports, discoveryv1.EndpointPort{Name: ptr.To(n), Port: ptr.To(p)})
+	}
+	for _, a := range addrs {
+		s.Endpoints = append(s.Endpoints, discoveryv1.Endpoint{Addresses: []string{a}})
+	}
+	return s
+}
+
+func policy(common networkingv1.CommonHealthCheck) *networkingv1.HealthCheckPolicy {
+	return &networkingv1.HealthCheckPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "hc"},
+		Spec: networkingv1.HealthCheckPolicySpec{
+			TargetRef: v1alpha2.NamespacedPolicyTargetReference{Kind: "Service", Name: "web"},
+			Default: &networkingv1.HealthCheckPolicyConfig{Config: &networkingv1.HealthCheck{
+				Type: networkingv1.HTTP,
+				HTTP: &networkingv1.HTTPHealthCheck{CommonHealthCheck: common},
+			}},
+		},
+	}
+}
+
+func TestResolvePorts(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web"},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+			{Name: "http", Port: 80},
+			{Name: "admin", Port: 9000},
+		}},
+	}
+	slices := []*discoveryv1.EndpointSlice{
+		slice("web-b", map[string]int32{"http": 8080}, "10.0.0.3"),
+		slice("web-a", map[string]int32{"http": 8080, "admin": 9090}, "10.0.0.1", "10.0.0.2"),
+	}
+
+	for _, tc := range []struct {
+		desc        string
+		policy      *networkingv1.HealthCheckPolicy
+		servingPort int32
+		wantPorts   []int32
+		wantReason  PortErrorReason
+		wantEPErr   []PortErrorReason
+	}{
+		{
+			desc:        "serving port",
+			policy:      policy(networkingv1.CommonHealthCheck{}),
+			servingPort: 80,
+			wantPorts:   []int32{8080, 8080, 8080},
+		},
+		{
+			desc:        "fixed port",
+			policy:      policy(networkingv1.CommonHealthCheck{PortSpecification: ptr.To(networkingv1.UseFixedPort), Port: ptr.To[int64](15021)}),
+			servingPort: 80,
+			wantPorts:   []int32{15021, 15021, 15021},
+		},
+		{
+			desc:        "named port missing on one slice",
+			policy:      policy(networkingv1.CommonHealthCheck{PortSpecification: ptr.To(networkingv1.UseNamedPort), PortName: ptr.To("admin")}),
+			servingPort: 80,
+			wantPorts:   []int32{9090, 9090, 0},
+			wantEPErr:   []PortErrorReason{"", "", ReasonNamedPortNotFound},
+		},
+		{
+			desc:        "unknown named port",
+			policy:      policy(networkingv1.CommonHealthCheck{PortName: ptr.To("metrics")}),
+			servingPort: 80,
+			wantReason:  ReasonNamedPortNotFound,
+		},
+		{
+			desc:        "unknown serving port",
+			policy:      policy(networkingv1.CommonHealthCheck{}),
+			servingPort: 443,
+			wantReason:  ReasonServicePortNotFound,
+		},
+		{
+			desc: "other target",
+			policy: func() *networkingv1.HealthCheckPolicy {
+				p := policy(networkingv1.CommonHealthCheck{})
+				p.Spec.TargetRef.Name = "api"
+				return p
+			}(),
+			servingPort: 80,
+			wantReason:  ReasonTargetMismatch,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ResolvePorts(tc.policy, svc, tc.servingPort, slices)
+			if tc.wantReason != "" {
+				var pe *PortError
+				if !errors.As(err, &pe) || pe.Reason != tc.wantReason {
+					t.Fatalf("ResolvePorts() = %v, want reason %s", err, tc.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolvePorts() = %v", err)
+			}
+			if len(got) != len(tc.wantPorts) {
+				t.Fatalf("ResolvePorts() returned %d endpoints, want %d", len(got), len(tc.wantPorts))
+			}
+			for i, ep := range got {
+				if ep.Port != tc.wantPorts[i] {
+					t.Errorf("endpoint %s port = %d, want %d", ep.Address, ep.Port, tc.wantPorts[i])
+				}
+				var reason PortErrorReason
+				if ep.Err != nil {
+					reason = ep.Err.Reason
+				}
+				if tc.wantEPErr != nil && reason != tc.wantEPErr[i] {
+					t.Errorf("endpoint %s error = %v, want reason %q", ep.Address, ep.Err, tc.wantEPErr[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResolvePortsChecks(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "grpc", Port: 80}}},
+	}
+	slices := []*discoveryv1.EndpointSlice{
+		slice("web-a", map[string]int32{"grpc": 8080, "admin": 9090}, "10.0.0.1"),
+		slice("web-b", map[string]int32{"grpc": 8080}, "10.0.0.2"),
+	}
+	grpcCheck := networkingv1.NamedHealthCheck{Name: "grpc", Config: networkingv1.HealthCheck{
+		Type: networkingv1.GRPC,
+		GRPC: &networkingv1.GRPCHealthCheck{},
+	}}
+	fixedCheck := networkingv1.NamedHealthCheck{Name: "mesh", Config: networkingv1.HealthCheck{
+		Type: networkingv1.TCP,
+		TCP:  &networkingv1.TCPHealthCheck{CommonHealthCheck: networkingv1.CommonHealthCheck{PortSpecification: ptr.To(networkingv1.UseFixedPort), Port: ptr.To[int64](15021)}},
+	}}
+	namedCheck := func(name string) networkingv1.NamedHealthCheck {
+		return networkingv1.NamedHealthCheck{Name: "admin", Config: networkingv1.HealthCheck{
+			Type: networkingv1.HTTP,
+			HTTP: &networkingv1.HTTPHealthCheck{CommonHealthCheck: networkingv1.CommonHealthCheck{PortSpecification: ptr.To(networkingv1.UseNamedPort), PortName: ptr.To(name)}},
+		}}
+	}
+	withConfig := func(cfg *networkingv1.HealthCheckPolicyConfig) *networkingv1.HealthCheckPolicy {
+		p := policy(networkingv1.CommonHealthCheck{})
+		p.Spec.Default = cfg
+		return p
+	}
+
+	for _, tc := range []struct {
+		desc       string
+		policy     *networkingv1.HealthCheckPolicy
+		want       []EndpointPort
+		wantReason PortErrorReason
+		wantCheck  string
+	}{
+		{
+			desc: "fixed and named ports",
+			policy: withConfig(&networkingv1.HealthCheckPolicyConfig{
+				Checks: []networkingv1.NamedHealthCheck{grpcCheck, fixedCheck, namedCheck("admin")},
+			}),
+			want: []EndpointPort{
+				{Check: "grpc", Address: "10.0.0.1", Port: 8080},
+				{Check: "grpc", Address: "10.0.0.2", Port: 8080},
+				{Check: "mesh", Address: "10.0.0.1", Port: 15021},
+				{Check: "mesh", Address: "10.0.0.2", Port: 15021},
+				{Check: "admin", Address: "10.0.0.1", Port: 9090},
+				{Check: "admin", Address: "10.0.0.2", Err: &PortError{Reason: ReasonNamedPortNotFound, Check: "admin"}},
+			},
+		},
+		{
+			desc: "unknown named port",
+			policy: withConfig(&networkingv1.HealthCheckPolicyConfig{
+				Checks: []networkingv1.NamedHealthCheck{grpcCheck, namedCheck("metrics")},
+			}),
+			wantReason: ReasonNamedPortNotFound,
+			wantCheck:  "admin",
+		},
+		{
+			desc:   "default check",
+			policy: withConfig(nil),
+			want: []EndpointPort{
+				{Check: DefaultCheckName, Address: "10.0.0.1", Port: 8080},
+				{Check: DefaultCheckName, Address: "10.0.0.2", Port: 8080},
+			},
+		},
+		{
+			desc:       "readiness probe",
+			policy:     withConfig(&networkingv1.HealthCheckPolicyConfig{FromReadinessProbe: ptr.To(true)}),
+			wantReason: ReasonReadinessProbe,
+		},
+		{
+			desc: "checks take precedence over readiness probe",
+			policy: withConfig(&networkingv1.HealthCheckPolicyConfig{
+				Checks:             []networkingv1.NamedHealthCheck{fixedCheck},
+				FromReadinessProbe: ptr.To(true),
+			}),
+			want: []EndpointPort{
+				{Check: "mesh", Address: "10.0.0.1", Port: 15021},
+				{Check: "mesh", Address: "10.0.0.2", Port: 15021},
+			},
+		},
+		{
+			desc: "invalid timeout",
+			policy: withConfig(&networkingv1.HealthCheckPolicyConfig{
+				Checks:           []networkingv1.NamedHealthCheck{fixedCheck},
+				CheckIntervalSec: ptr.To[int64](5),
+				TimeoutSec:       ptr.To[int64](10),
+			}),
+			wantReason: ReasonInvalidConfig,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ResolvePorts(tc.policy, svc, 80, slices)
+			if tc.wantReason != "" {
+				var pe *PortError
+				if !errors.As(err, &pe) || pe.Reason != tc.wantReason || pe.Check != tc.wantCheck {
+					t.Fatalf("ResolvePorts() = %#v, want reason %s for check %q", err, tc.wantReason, tc.wantCheck)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolvePorts() = %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("ResolvePorts() returned %d ports, want %d", len(got), len(tc.want))
+			}
+			for i, ep := range got {
+				want := tc.want[i]
+				if ep.Check != want.Check || ep.Address != want.Address || ep.Port != want.Port {
+					t.Errorf("port %d = %s %s:%d, want %s %s:%d", i, ep.Check, ep.Address, ep.Port, want.Check, want.Address, want.Port)
+				}
+				switch {
+				case want.Err == nil && ep.Err != nil:
+					t.Errorf("port %d error = %v, want none", i, ep.Err)
+				case want.Err != nil && (ep.Err == nil || ep.Err.Reason != want.Err.Reason || ep.Err.Check != want.Err.Check):
+					t.Errorf("port %d error = %#v, want reason %s for check %s", i, ep.Err, want.Err.Reason, want.Err.Check)
+				}
+			}
+		})
+	}
+}
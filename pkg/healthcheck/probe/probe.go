@@ -0,0 +1,347 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package probe executes the health check described by a HealthCheckPolicy
+// against an endpoint, the way the load balancer health checkers would.
+package probe
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/healthcheck"
+)
+
+// DefaultRequestPath is the request path of HTTP based health checks that do
+// not specify one.
+const DefaultRequestPath = "/"
+
+// maxResponseBytes is the number of bytes of an HTTP response body that are
+// searched for the expected response.
+const maxResponseBytes = 1024
+
+// Probe is a single health check of an endpoint.
+type Probe interface {
+	// Check probes the endpoint at the given address, in host:port form. It
+	// returns nil if the endpoint is healthy.
+	Check(ctx context.Context, addr string) error
+}
+
+// New returns the Probe described by the given health check configuration.
+// A nil configuration describes the default HTTP health check.
+func New(hc *networkingv1.HealthCheck) (Probe, error) {
+	if hc == nil {
+		return &httpProbe{scheme: "http"}, nil
+	}
+	switch hc.Type {
+	case networkingv1.TCP:
+		if hc.TCP == nil {
+			return nil, errors.New("tcpHealthCheck must be specified for type TCP")
+		}
+		return &tcpProbe{
+			request:     deref(hc.TCP.Request),
+			response:    deref(hc.TCP.Response),
+			proxyHeader: proxyV1(hc.TCP.ProxyHeader),
+		}, nil
+	case networkingv1.HTTP, "":
+		var common networkingv1.CommonHTTPHealthCheck
+		if hc.HTTP != nil {
+			common = hc.HTTP.CommonHTTPHealthCheck
+		}
+		return newHTTPProbe("http", common), nil
+	case networkingv1.HTTPS:
+		if hc.HTTPS == nil {
+			return nil, errors.New("httpsHealthCheck must be specified for type HTTPS")
+		}
+		return newHTTPProbe("https", hc.HTTPS.CommonHTTPHealthCheck), nil
+	case networkingv1.HTTP2:
+		if hc.HTTP2 == nil {
+			return nil, errors.New("http2HealthCheck must be specified for type HTTP2")
+		}
+		p := newHTTPProbe("https", hc.HTTP2.CommonHTTPHealthCheck)
+		p.http2 = true
+		return p, nil
+	case networkingv1.GRPC:
+		if hc.GRPC == nil {
+			return nil, errors.New("grpcHealthCheck must be specified for type GRPC")
+		}
+		return &grpcProbe{service: deref(hc.GRPC.GRPCServiceName)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported health check type %q", hc.Type)
+	}
+}
+
+// NewCombined returns a Probe that runs the given named health checks and
+// combines their results. With HealthCheckCombinationAll the endpoint is
+// healthy if all checks pass, with HealthCheckCombinationAny if at least one
+// does. Checks that use USE_FIXED_PORT probe that port on the host of the
+// address passed to Check.
+func NewCombined(checks []networkingv1.NamedHealthCheck, combination networkingv1.HealthCheckCombination) (Probe, error) {
+	if len(checks) == 0 {
+		return nil, errors.New("at least one health check must be specified")
+	}
+	if combination != networkingv1.HealthCheckCombinationAll && combination != networkingv1.HealthCheckCombinationAny {
+		return nil, fmt.Errorf("unsupported combination %q", combination)
+	}
+	cp := &combinedProbe{any: combination == networkingv1.HealthCheckCombinationAny}
+	for i := range checks {
+		p, err := New(&checks[i].Config)
+		if err != nil {
+			return nil, fmt.Errorf("check %s: %w", checks[i].Name, err)
+		}
+		port, _ := FixedPort(&checks[i].Config)
+		cp.checks = append(cp.checks, namedProbe{name: checks[i].Name, probe: p, port: port})
+	}
+	return cp, nil
+}
+
+type namedProbe struct {
+	name  string
+	probe Probe
+	// port overrides the port of the address if not zero.
+	port int
+}
+
+type combinedProbe struct {
+	checks []namedProbe
+	any    bool
+}
+
+func (p *combinedProbe) Check(ctx context.Context, addr string) error {
+	var errs []error
+	for _, c := range p.checks {
+		target := addr
+		if c.port != 0 {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				return err
+			}
+			target = Address(host, c.port)
+		}
+		err := c.probe.Check(ctx, target)
+		if err == nil {
+			if p.any {
+				return nil
+			}
+			continue
+		}
+		err = fmt.Errorf("check %s: %w", c.name, err)
+		if !p.any {
+			return err
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// FixedPort returns the port of the given health check if it uses
+// USE_FIXED_PORT.
+func FixedPort(hc *networkingv1.HealthCheck) (int, bool) {
+	common := healthcheck.Common(hc)
+	if healthcheck.PortSpecification(common) != networkingv1.UseFixedPort || common.Port == nil {
+		return 0, false
+	}
+	return int(*common.Port), true
+}
+
+// tcpProbe opens a TCP connection, optionally sends a request and checks that
+// the response starts with the expected bytes.
+type tcpProbe struct {
+	request     string
+	response    string
+	proxyHeader bool
+}
+
+func (p *tcpProbe) Check(ctx context.Context, addr string) error {
+	conn, err := dial(ctx, addr, p.proxyHeader)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if p.request != "" {
+		if _, err := io.WriteString(conn, p.request); err != nil {
+			return fmt.Errorf("failed to send request: %w", err)
+		}
+	}
+	if p.response == "" {
+		return nil
+	}
+	got := make([]byte, len(p.response))
+	if n, err := io.ReadFull(conn, got); err != nil {
+		return fmt.Errorf("failed to read response, got %q: %w", got[:n], err)
+	}
+	if string(got) != p.response {
+		return fmt.Errorf("response %q does not start with %q", got, p.response)
+	}
+	return nil
+}
+
+// httpProbe sends a GET request and checks that the response is 200 OK and,
+// optionally, that the beginning of the body contains the expected response.
+type httpProbe struct {
+	scheme      string
+	http2       bool
+	host        string
+	path        string
+	response    string
+	proxyHeader bool
+}
+
+func newHTTPProbe(scheme string, c networkingv1.CommonHTTPHealthCheck) *httpProbe {
+	return &httpProbe{
+		scheme:      scheme,
+		host:        deref(c.Host),
+		path:        deref(c.RequestPath),
+		response:    deref(c.Response),
+		proxyHeader: proxyV1(c.ProxyHeader),
+	}
+}
+
+func (p *httpProbe) Check(ctx context.Context, addr string) error {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, addr string) (net.Conn, error) {
+			return dial(ctx, addr, p.proxyHeader)
+		},
+		// Health checkers do not validate the certificate of the endpoints.
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		ForceAttemptHTTP2: p.http2,
+		DisableKeepAlives: true,
+	}
+	defer transport.CloseIdleConnections()
+	path := p.path
+	if path == "" {
+		path = DefaultRequestPath
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.scheme+"://"+addr+path, nil)
+	if err != nil {
+		return err
+	}
+	if p.host != "" {
+		req.Host = p.host
+	}
+	resp, err := (&http.Client{
+		Transport: transport,
+		// Redirects are not followed: only 200 OK is healthy.
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if p.http2 && resp.ProtoMajor != 2 {
+		return fmt.Errorf("response protocol is %s, want HTTP/2", resp.Proto)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("response status is %q, want 200 OK", resp.Status)
+	}
+	if p.response == "" {
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if !bytes.Contains(body, []byte(p.response)) {
+		return fmt.Errorf("the first %d bytes of the response body do not contain %q", maxResponseBytes, p.response)
+	}
+	return nil
+}
+
+// grpcProbe calls the gRPC health checking protocol and checks that the
+// service is SERVING.
+type grpcProbe struct {
+	service string
+}
+
+func (p *grpcProbe) Check(ctx context.Context, addr string) error {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: p.service})
+	if err != nil {
+		return err
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("service %q is %s", p.service, resp.Status)
+	}
+	return nil
+}
+
+// dial opens a TCP connection and, if proxyHeader is true, sends a PROXY
+// protocol version 1 header.
+func dial(ctx context.Context, addr string, proxyHeader bool) (net.Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if proxyHeader {
+		if _, err := io.WriteString(conn, ProxyV1Header(conn.LocalAddr(), conn.RemoteAddr())); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to send PROXY header: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// ProxyV1Header returns the PROXY protocol version 1 header of a connection
+// from src to dst.
+func ProxyV1Header(src, dst net.Addr) string {
+	srcHost, srcPort, err1 := net.SplitHostPort(src.String())
+	dstHost, dstPort, err2 := net.SplitHostPort(dst.String())
+	if err1 != nil || err2 != nil {
+		return "PROXY UNKNOWN\r\n"
+	}
+	proto := "TCP4"
+	if strings.Contains(srcHost, ":") {
+		proto = "TCP6"
+	}
+	return strings.Join([]string{"PROXY", proto, srcHost, dstHost, srcPort, dstPort}, " ") + "\r\n"
+}
+
+// Address returns the host:port address of the given host and port.
+func Address(host string, port int) string {
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+func proxyV1(t *networkingv1.ProxyHeaderType) bool {
+	return t != nil && *t == networkingv1.ProxyV1
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
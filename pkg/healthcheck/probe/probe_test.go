@@ -0,0 +1,337 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package probe
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+func check(t *testing.T, hc *networkingv1.HealthCheck, addr string) error {
+	t.Helper()
+	p, err := New(hc)
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return p.Check(ctx, addr)
+}
+
+func TestHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/healthz" && r.Host == "app.example.com":
+			io.WriteString(w, "status: ok")
+		case r.URL.Path == "/redirect":
+			http.Redirect(w, r, "/healthz", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	addr := srv.Listener.Addr().String()
+
+	for _, tc := range []struct {
+		desc    string
+		common  networkingv1.CommonHTTPHealthCheck
+		wantErr bool
+	}{
+		{
+			desc:   "match",
+			common: networkingv1.CommonHTTPHealthCheck{Host: ptr.To("app.example.com"), RequestPath: ptr.To("/healthz"), Response: ptr.To("ok")},
+		},
+		{
+			desc:    "wrong host",
+			common:  networkingv1.CommonHTTPHealthCheck{RequestPath: ptr.To("/healthz")},
+			wantErr: true,
+		},
+		{
+			desc:    "response mismatch",
+			common:  networkingv1.CommonHTTPHealthCheck{Host: ptr.To("app.example.com"), RequestPath: ptr.To("/healthz"), Response: ptr.To("ready")},
+			wantErr: true,
+		},
+		{
+			desc:    "redirect is not followed",
+			common:  networkingv1.CommonHTTPHealthCheck{RequestPath: ptr.To("/redirect")},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			hc := &networkingv1.HealthCheck{Type: networkingv1.HTTP, HTTP: &networkingv1.HTTPHealthCheck{CommonHTTPHealthCheck: tc.common}}
+			if err := check(t, hc, addr); (err != nil) != tc.wantErr {
+				t.Errorf("Check() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPSAndHTTP2(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+	addr := srv.Listener.Addr().String()
+
+	https := &networkingv1.HealthCheck{Type: networkingv1.HTTPS, HTTPS: &networkingv1.HTTPSHealthCheck{}}
+	if err := check(t, https, addr); err != nil {
+		t.Errorf("HTTPS Check() = %v", err)
+	}
+	http2 := &networkingv1.HealthCheck{Type: networkingv1.HTTP2, HTTP2: &networkingv1.HTTP2HealthCheck{}}
+	if err := check(t, http2, addr); err != nil {
+		t.Errorf("HTTP2 Check() = %v", err)
+	}
+
+	http1 := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer http1.Close()
+	if err := check(t, http2, http1.Listener.Addr().String()); err == nil {
+		t.Errorf("HTTP2 Check() against an HTTP/1.1 server succeeded")
+	}
+}
+
+// tcpServer serves each connection with fn until the test ends.
+func tcpServer(t *testing.T, fn func(net.Conn)) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				fn(conn)
+			}()
+		}
+	}()
+	return l.Addr().String()
+}
+
+func TestTCP(t *testing.T) {
+	addr := tcpServer(t, func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+		line, _ := r.ReadString('\n')
+		if strings.HasPrefix(line, "PROXY TCP4 127.0.0.1 127.0.0.1 ") {
+			line, _ = r.ReadString('\n')
+		}
+		if line == "PING\n" {
+			io.WriteString(conn, "PONG and more")
+		}
+	})
+
+	for _, tc := range []struct {
+		desc    string
+		tcp     *networkingv1.TCPHealthCheck
+		wantErr bool
+	}{
+		{desc: "connect only", tcp: &networkingv1.TCPHealthCheck{}},
+		{desc: "request and response", tcp: &networkingv1.TCPHealthCheck{Request: ptr.To("PING\n"), Response: ptr.To("PONG")}},
+		{desc: "proxy header", tcp: &networkingv1.TCPHealthCheck{Request: ptr.To("PING\n"), Response: ptr.To("PONG"), ProxyHeader: ptr.To(networkingv1.ProxyV1)}},
+		{desc: "response mismatch", tcp: &networkingv1.TCPHealthCheck{Request: ptr.To("PING\n"), Response: ptr.To("PING")}, wantErr: true},
+		{desc: "no response", tcp: &networkingv1.TCPHealthCheck{Request: ptr.To("HELLO\n"), Response: ptr.To("PONG")}, wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			hc := &networkingv1.HealthCheck{Type: networkingv1.TCP, TCP: tc.tcp}
+			if err := check(t, hc, addr); (err != nil) != tc.wantErr {
+				t.Errorf("Check() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := l.Addr().String()
+	l.Close()
+	if err := check(t, &networkingv1.HealthCheck{Type: networkingv1.TCP, TCP: &networkingv1.TCPHealthCheck{}}, closed); err == nil {
+		t.Errorf("Check() against a closed port succeeded")
+	}
+}
+
+func TestGRPC(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	hs := health.NewServer()
+	hs.SetServingStatus("app.Serving", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus("app.NotServing", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(srv, hs)
+	go srv.Serve(l)
+	defer srv.Stop()
+
+	for service, wantErr := range map[string]bool{
+		"":               false,
+		"app.Serving":    false,
+		"app.NotServing": true,
+		"app.Unknown":    true,
+	} {
+		hc := &networkingv1.HealthCheck{Type: networkingv1.GRPC, GRPC: &networkingv1.GRPCHealthCheck{GRPCServiceName: ptr.To(service)}}
+		if err := check(t, hc, l.Addr().String()); (err != nil) != wantErr {
+			t.Errorf("Check(%q) = %v, want error %t", service, err, wantErr)
+		}
+	}
+}
+
+func TestCombined(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/live" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	host, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+	srvPort, _ := strconv.Atoi(port)
+
+	httpCheck := func(name, path string) networkingv1.NamedHealthCheck {
+		return networkingv1.NamedHealthCheck{Name: name, Config: networkingv1.HealthCheck{
+			Type: networkingv1.HTTP,
+			HTTP: &networkingv1.HTTPHealthCheck{CommonHTTPHealthCheck: networkingv1.CommonHTTPHealthCheck{RequestPath: ptr.To(path)}},
+		}}
+	}
+	// The fixed port check reaches the server even though the address
+	// passed to Check points elsewhere.
+	fixed := networkingv1.NamedHealthCheck{Name: "fixed", Config: networkingv1.HealthCheck{
+		Type: networkingv1.TCP,
+		TCP: &networkingv1.TCPHealthCheck{CommonHealthCheck: networkingv1.CommonHealthCheck{
+			PortSpecification: ptr.To(networkingv1.UseFixedPort),
+			Port:              ptr.To(int64(srvPort)),
+		}},
+	}}
+	fixedLive := httpCheck("live", "/live")
+	fixedLive.Config.HTTP.PortSpecification = ptr.To(networkingv1.UseFixedPort)
+	fixedLive.Config.HTTP.Port = ptr.To(int64(srvPort))
+
+	for _, tc := range []struct {
+		desc        string
+		checks      []networkingv1.NamedHealthCheck
+		combination networkingv1.HealthCheckCombination
+		addr        string
+		wantErr     bool
+	}{
+		{
+			desc:        "all fails",
+			checks:      []networkingv1.NamedHealthCheck{httpCheck("live", "/live"), httpCheck("ready", "/ready")},
+			combination: networkingv1.HealthCheckCombinationAll,
+			addr:        srv.Listener.Addr().String(),
+			wantErr:     true,
+		},
+		{
+			desc:        "any passes",
+			checks:      []networkingv1.NamedHealthCheck{httpCheck("ready", "/ready"), httpCheck("live", "/live")},
+			combination: networkingv1.HealthCheckCombinationAny,
+			addr:        srv.Listener.Addr().String(),
+		},
+		{
+			desc:        "fixed ports",
+			checks:      []networkingv1.NamedHealthCheck{fixedLive, fixed},
+			combination: networkingv1.HealthCheckCombinationAll,
+			addr:        Address(host, 1),
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := NewCombined(tc.checks, tc.combination)
+			if err != nil {
+				t.Fatalf("NewCombined() = %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := p.Check(ctx, tc.addr); (err != nil) != tc.wantErr {
+				t.Errorf("Check() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTracker(t *testing.T) {
+	tr := Tracker{HealthyThreshold: 2, UnhealthyThreshold: 3}
+	var got []State
+	for _, ok := range []bool{true, true, true, false, false, true, false, false, false, true, true} {
+		if state, changed := tr.Observe(ok); changed {
+			got = append(got, state)
+		}
+	}
+	want := []State{Healthy, Unhealthy, Healthy}
+	if len(got) != len(want) {
+		t.Fatalf("transitions = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("transitions = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRunner(t *testing.T) {
+	healthy := make(chan bool, 1)
+	healthy <- false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok := <-healthy
+		healthy <- ok
+		if !ok {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	r, err := NewRunner(&networkingv1.HealthCheckPolicyConfig{UnhealthyThreshold: ptr.To[int64](1)}, srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Interval = time.Millisecond
+	var transitions []State
+	n := 0
+	state := r.Run(context.Background(), 10, func(ev Event) bool {
+		if ev.Transition {
+			transitions = append(transitions, ev.State)
+		}
+		if n++; n == 3 {
+			<-healthy
+			healthy <- true
+		}
+		return true
+	})
+	if state != Healthy {
+		t.Errorf("Run() = %s, want %s", state, Healthy)
+	}
+	if len(transitions) != 2 || transitions[0] != Unhealthy || transitions[1] != Healthy {
+		t.Errorf("transitions = %v, want [%s %s]", transitions, Unhealthy, Healthy)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"time"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/healthcheck"
+)
+
+// State is the health state of an endpoint.
+type State string
+
+const (
+	// Unknown is the state of an endpoint before either threshold is reached.
+	Unknown State = "UNKNOWN"
+	// Healthy is the state of an endpoint that passed HealthyThreshold
+	// consecutive probes.
+	Healthy State = "HEALTHY"
+	// Unhealthy is the state of an endpoint that failed UnhealthyThreshold
+	// consecutive probes.
+	Unhealthy State = "UNHEALTHY"
+)
+
+// Tracker tracks the health state of an endpoint from the results of
+// consecutive probes.
+type Tracker struct {
+	// HealthyThreshold is the number of consecutive successes after which an
+	// endpoint that is not healthy becomes healthy.
+	HealthyThreshold int
+	// UnhealthyThreshold is the number of consecutive failures after which an
+	// endpoint that is not unhealthy becomes unhealthy.
+	UnhealthyThreshold int
+
+	state     State
+	successes int
+	failures  int
+}
+
+// State returns the current state of the endpoint.
+func (t *Tracker) State() State {
+	if t.state == "" {
+		return Unknown
+	}
+	return t.state
+}
+
+// Observe records the result of a probe. It returns the new state and true if
+// the state changed.
+func (t *Tracker) Observe(healthy bool) (State, bool) {
+	prev := t.State()
+	next := prev
+	if healthy {
+		t.successes++
+		t.failures = 0
+		if prev != Healthy && t.successes >= t.HealthyThreshold {
+			next = Healthy
+		}
+	} else {
+		t.failures++
+		t.successes = 0
+		if prev != Unhealthy && t.failures >= t.UnhealthyThreshold {
+			next = Unhealthy
+		}
+	}
+	t.state = next
+	return next, next != prev
+}
+
+// Event is the result of a probe.
+type Event struct {
+	// Time is the time the probe started.
+	Time time.Time
+	// Duration is the duration of the probe.
+	Duration time.Duration
+	// Err is the error of the probe, or nil if the probe succeeded.
+	Err error
+	// State is the state of the endpoint after the probe.
+	State State
+	// Transition is true if the probe changed the state of the endpoint.
+	Transition bool
+	// Previous is the state of the endpoint before the probe.
+	Previous State
+}
+
+// Runner probes an endpoint periodically.
+type Runner struct {
+	// Probe is the probe to run.
+	Probe Probe
+	// Address is the address of the endpoint in host:port form.
+	Address string
+	// Interval is the time between the start of consecutive probes.
+	Interval time.Duration
+	// Timeout is the time after which a probe fails.
+	Timeout time.Duration
+	// Tracker tracks the state of the endpoint.
+	Tracker Tracker
+}
+
+// NewRunner returns a Runner for the health check described by the given
+// policy configuration. A nil configuration describes the default health
+// check. Health checks derived from a readinessProbe are not supported, as
+// there is no Pod to derive them from.
+func NewRunner(cfg *networkingv1.HealthCheckPolicyConfig, addr string) (*Runner, error) {
+	eff, err := healthcheck.Resolve(cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	p, err := NewCombined(eff.Checks, eff.Combination)
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{
+		Probe:    p,
+		Address:  addr,
+		Interval: time.Duration(eff.CheckIntervalSec) * time.Second,
+		Timeout:  time.Duration(eff.TimeoutSec) * time.Second,
+		Tracker: Tracker{
+			HealthyThreshold:   int(eff.HealthyThreshold),
+			UnhealthyThreshold: int(eff.UnhealthyThreshold),
+		},
+	}, nil
+}
+
+// Once runs a single probe and updates the state of the endpoint.
+func (r *Runner) Once(ctx context.Context) Event {
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+	ev := Event{Time: time.Now(), Previous: r.Tracker.State()}
+	ev.Err = r.Probe.Check(ctx, r.Address)
+	ev.Duration = time.Since(ev.Time)
+	ev.State, ev.Transition = r.Tracker.Observe(ev.Err == nil)
+	return ev
+}
+
+// Run probes the endpoint every Interval and calls fn with the result of each
+// probe, until ctx is done, count probes have run, or fn returns false. A
+// count of 0 means no limit. It returns the final state of the endpoint.
+func (r *Runner) Run(ctx context.Context, count int, fn func(Event) bool) State {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for n := 0; count == 0 || n < count; n++ {
+		if n > 0 {
+			select {
+			case <-ctx.Done():
+				return r.Tracker.State()
+			case <-ticker.C:
+			}
+		}
+		if !fn(r.Once(ctx)) {
+			break
+		}
+	}
+	return r.Tracker.State()
+}
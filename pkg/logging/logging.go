@@ -0,0 +1,108 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package logging converts LoggingConfig, which is shared by GCPBackendPolicy
+// and HealthCheckPolicy, into the logConfig of the compute API.
+package logging
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// SampleRateScale is the value of LoggingConfig.SampleRate that means that all
+// requests are logged.
+const SampleRateScale = 1000000
+
+// LogConfig is the logConfig of a BackendService or HealthCheck in the compute
+// API. HealthChecks only support Enable.
+// See https://cloud.google.com/compute/docs/reference/rest/v1/backendServices
+type LogConfig struct {
+	Enable         bool     `json:"enable"`
+	SampleRate     *float64 `json:"sampleRate,omitempty"`
+	OptionalMode   string   `json:"optionalMode,omitempty"`
+	OptionalFields []string `json:"optionalFields,omitempty"`
+}
+
+// Render converts the given LoggingConfig into a compute API LogConfig. It
+// returns nil if cfg is nil.
+func Render(cfg *networkingv1.LoggingConfig) *LogConfig {
+	if cfg == nil {
+		return nil
+	}
+	ret := &LogConfig{Enable: cfg.Enabled != nil && *cfg.Enabled}
+	if !ret.Enable {
+		return ret
+	}
+	rate := SampleRateFraction(cfg.SampleRate)
+	ret.SampleRate = &rate
+	if cfg.OptionalMode != nil {
+		ret.OptionalMode = string(*cfg.OptionalMode)
+	}
+	for _, f := range cfg.OptionalFields {
+		ret.OptionalFields = append(ret.OptionalFields, string(f))
+	}
+	return ret
+}
+
+// SampleRateFraction converts LoggingConfig.SampleRate into the proportion of
+// requests that are logged, in the range [0, 1]. A nil rate means that all
+// requests are logged.
+func SampleRateFraction(rate *int32) float64 {
+	if rate == nil {
+		return 1
+	}
+	return float64(*rate) / SampleRateScale
+}
+
+// SampleRateFromFraction converts a proportion of requests in the range
+// [0, 1] into a LoggingConfig.SampleRate, rounding to the nearest value.
+func SampleRateFromFraction(f float64) (int32, error) {
+	if math.IsNaN(f) || f < 0 || f > 1 {
+		return 0, fmt.Errorf("sample rate %v must be in range [0, 1]", f)
+	}
+	return int32(math.Round(f * SampleRateScale)), nil
+}
+
+// FormatSampleRatePercent renders LoggingConfig.SampleRate as a percentage,
+// e.g. 125000 is rendered as "12.5%". A nil rate is rendered as "100%".
+func FormatSampleRatePercent(rate *int32) string {
+	if rate == nil {
+		return "100%"
+	}
+	// Scale the rate directly to avoid the rounding error of multiplying the
+	// fraction by 100.
+	return strconv.FormatFloat(float64(*rate)*100/SampleRateScale, 'f', -1, 64) + "%"
+}
+
+// ParseSampleRatePercent parses a percentage such as "12.5%" or "12.5" into a
+// LoggingConfig.SampleRate. It is the inverse of FormatSampleRatePercent.
+func ParseSampleRatePercent(s string) (int32, error) {
+	percent, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%")), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample rate percentage %q: %w", s, err)
+	}
+	if math.IsNaN(percent) || percent < 0 || percent > 100 {
+		return 0, fmt.Errorf("sample rate percentage %q must be in range [0, 100]", s)
+	}
+	// Scale the percentage directly to avoid the rounding error of dividing
+	// by 100 first.
+	return int32(math.Round(percent * SampleRateScale / 100)), nil
+}
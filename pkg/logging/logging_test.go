@@ -0,0 +1,49 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package logging
+
+import "testing"
+
+func TestSampleRatePercent(t *testing.T) {
+	for _, tc := range []struct {
+		rate    int32
+		percent string
+	}{
+		{rate: 0, percent: "0%"},
+		{rate: 1, percent: "0.0001%"},
+		{rate: 125000, percent: "12.5%"},
+		{rate: 333333, percent: "33.3333%"},
+		{rate: 1000000, percent: "100%"},
+	} {
+		if got := FormatSampleRatePercent(&tc.rate); got != tc.percent {
+			t.Errorf("FormatSampleRatePercent(%d) = %q, want %q", tc.rate, got, tc.percent)
+		}
+		got, err := ParseSampleRatePercent(tc.percent)
+		if err != nil || got != tc.rate {
+			t.Errorf("ParseSampleRatePercent(%q) = %d, %v, want %d", tc.percent, got, err, tc.rate)
+		}
+	}
+
+	if got := FormatSampleRatePercent(nil); got != "100%" {
+		t.Errorf("FormatSampleRatePercent(nil) = %q, want %q", got, "100%")
+	}
+	for _, s := range []string{"", "abc", "-1%", "100.1%", "NaN", "nan%", "Inf%"} {
+		if _, err := ParseSampleRatePercent(s); err == nil {
+			t.Errorf("ParseSampleRatePercent(%q) = nil error, want error", s)
+		}
+	}
+}
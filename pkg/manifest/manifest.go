@@ -0,0 +1,113 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package manifest decodes Kubernetes objects from multi-document YAML or JSON
+// streams, such as the output of `kubectl get -o yaml`.
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Kinds maps the kinds of the objects to decode to the functions that decode
+// them. The functions are called with the JSON encoding of an object.
+type Kinds map[string]func(raw []byte) error
+
+// Decode reads the objects of a multi-document YAML or JSON stream and passes
+// each of them to the function of its kind. Lists, i.e. objects whose kind
+// ends with "List" such as List or GCPAuthzPolicyList, are expanded. Items of
+// typed lists may omit their kind, in which case the kind of the list without
+// the "List" suffix is used. Objects of other kinds are skipped.
+func Decode(r io.Reader, kinds Kinds) error {
+	d := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var raw json.RawMessage
+		if err := d.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		if err := decodeObject(raw, "", kinds); err != nil {
+			return err
+		}
+	}
+}
+
+// decodeObject decodes the given object, whose kind defaults to kind.
+func decodeObject(raw json.RawMessage, kind string, kinds Kinds) error {
+	var doc struct {
+		Kind  string            `json:"kind"`
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+	if doc.Kind == "" {
+		doc.Kind = kind
+	}
+	if decode, ok := kinds[doc.Kind]; ok {
+		if err := decode(raw); err != nil {
+			return fmt.Errorf("invalid %s: %w", doc.Kind, err)
+		}
+		return nil
+	}
+	if strings.HasSuffix(doc.Kind, "List") {
+		for _, item := range doc.Items {
+			if err := decodeObject(item, strings.TrimSuffix(doc.Kind, "List"), kinds); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Strict returns a function that decodes an object into a new T, rejecting
+// unknown and duplicate fields, and appends it to out. It is meant for the
+// types of this module, which are known in full.
+func Strict[T any](out *[]*T) func(raw []byte) error {
+	return func(raw []byte) error {
+		obj := new(T)
+		if err := utilyaml.UnmarshalStrict(raw, obj); err != nil {
+			return err
+		}
+		*out = append(*out, obj)
+		return nil
+	}
+}
+
+// Lenient returns a function that decodes an object into a new T, ignoring
+// unknown fields, and appends it to out. It is meant for Kubernetes types,
+// which can have fields that are newer than the vendored API.
+func Lenient[T any](out *[]*T) func(raw []byte) error {
+	return func(raw []byte) error {
+		obj := new(T)
+		if err := json.Unmarshal(raw, obj); err != nil {
+			return err
+		}
+		*out = append(*out, obj)
+		return nil
+	}
+}
@@ -0,0 +1,143 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package manifest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+func TestDecode(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		input        string
+		wantPolicies []string
+		wantPods     []string
+		wantErr      string
+	}{
+		{
+			desc: "documents",
+			input: `
+apiVersion: networking.gke.io/v1
+kind: GCPAuthzPolicy
+metadata:
+  name: a
+---
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+status:
+  futureField: true
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: skipped
+`,
+			wantPolicies: []string{"a"},
+			wantPods:     []string{"web"},
+		},
+		{
+			desc: "list",
+			input: `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: networking.gke.io/v1
+  kind: GCPAuthzPolicy
+  metadata:
+    name: a
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: web
+- metadata:
+    name: unknown
+`,
+			wantPolicies: []string{"a"},
+			wantPods:     []string{"web"},
+		},
+		{
+			desc: "typed list",
+			input: `
+apiVersion: networking.gke.io/v1
+kind: GCPAuthzPolicyList
+items:
+- metadata:
+    name: a
+- metadata:
+    name: b
+`,
+			wantPolicies: []string{"a", "b"},
+		},
+		{
+			desc:     "json",
+			input:    `{"kind": "PodList", "items": [{"metadata": {"name": "web"}}]}`,
+			wantPods: []string{"web"},
+		},
+		{
+			desc: "unknown field",
+			input: `
+kind: GCPAuthzPolicyList
+items:
+- metadata:
+    name: a
+  spec:
+    unknown: true
+`,
+			wantErr: `invalid GCPAuthzPolicy: error unmarshaling JSON: while decoding JSON: json: unknown field "unknown"`,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var policies []*networkingv1.GCPAuthzPolicy
+			var pods []*corev1.Pod
+			err := Decode(strings.NewReader(tc.input), Kinds{
+				"GCPAuthzPolicy": Strict(&policies),
+				"Pod":            Lenient(&pods),
+			})
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("Decode() = %v, want %s", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode() = %v", err)
+			}
+			var gotPolicies, gotPods []string
+			for _, p := range policies {
+				gotPolicies = append(gotPolicies, p.Name)
+			}
+			for _, p := range pods {
+				gotPods = append(gotPods, p.Name)
+			}
+			if !reflect.DeepEqual(gotPolicies, tc.wantPolicies) {
+				t.Errorf("policies = %v, want %v", gotPolicies, tc.wantPolicies)
+			}
+			if !reflect.DeepEqual(gotPods, tc.wantPods) {
+				t.Errorf("pods = %v, want %v", gotPods, tc.wantPods)
+			}
+		})
+	}
+}
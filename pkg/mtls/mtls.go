@@ -0,0 +1,253 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package mtls resolves the mTLS settings of GCPServerTLSPolicies and
+// GCPClientTLSPolicies, and reports the Service ports whose clients and
+// servers disagree on them.
+//
+// The server side of a connection is configured by the GCPServerTLSPolicy
+// that applies to the port of the Pod, and the client side by the
+// GCPClientTLSPolicy that applies to the port of the Service. Both are
+// configured independently, so a Strict server behind a client policy that
+// disables TLS silently rejects all the traffic of the Service.
+package mtls
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// Level is the precedence level at which a policy applies. Higher levels
+// take precedence.
+type Level int
+
+const (
+	// LevelNone means that no policy applies.
+	LevelNone Level = iota
+	// LevelNamespace is a policy that applies to a whole namespace.
+	LevelNamespace
+	// LevelWorkload is a GCPServerTLSPolicy that selects the Pod, or a
+	// GCPClientTLSPolicy that targets the Service.
+	LevelWorkload
+	// LevelPort is a GCPServerTLSPolicy that selects the Pod and overrides
+	// the port, or a GCPClientTLSPolicy that targets the Service port.
+	LevelPort
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelNamespace:
+		return "namespace"
+	case LevelWorkload:
+		return "workload"
+	case LevelPort:
+		return "port"
+	}
+	return "none"
+}
+
+// ServerSetting is the server-side mTLS setting of a port of a Pod.
+type ServerSetting struct {
+	// Mode is the mTLS mode of the port. It is Disabled if no policy
+	// applies.
+	Mode networkingv1.MTLSMode
+	// Policy is the policy that sets the mode, if any.
+	Policy *networkingv1.GCPServerTLSPolicy
+	// Level is the precedence level of Policy.
+	Level Level
+	// Conflicts are the other policies that apply to the port at the same
+	// level as Policy, in order of precedence. They are ignored.
+	Conflicts []*networkingv1.GCPServerTLSPolicy
+}
+
+// ResolveServer returns the mTLS setting of a port of a Pod. Of the
+// policies that select the Pod, a policy that overrides the port takes
+// precedence over a policy that selects the Pod by labels, which takes
+// precedence over a policy for the whole namespace. A policy selects the
+// Pod if any of its TargetRefs does. If several policies apply at the same
+// level, the oldest one wins, and then the first by name; the others are
+// returned as Conflicts.
+func ResolveServer(policies []*networkingv1.GCPServerTLSPolicy, pod *corev1.Pod, port int32) ServerSetting {
+	type candidate struct {
+		policy *networkingv1.GCPServerTLSPolicy
+		mode   networkingv1.MTLSMode
+	}
+	var (
+		best      []candidate
+		bestLevel = LevelNone
+	)
+	for _, p := range policies {
+		level, mode := serverLevel(p, pod, port)
+		switch {
+		case level == LevelNone || level < bestLevel:
+		case level > bestLevel:
+			best, bestLevel = []candidate{{p, mode}}, level
+		default:
+			best = append(best, candidate{p, mode})
+		}
+	}
+	if len(best) == 0 {
+		return ServerSetting{Mode: networkingv1.Disabled}
+	}
+	slices.SortFunc(best, func(a, b candidate) int {
+		if older(&a.policy.ObjectMeta, &b.policy.ObjectMeta) {
+			return -1
+		}
+		return 1
+	})
+	setting := ServerSetting{Mode: best[0].mode, Policy: best[0].policy, Level: bestLevel}
+	for _, c := range best[1:] {
+		setting.Conflicts = append(setting.Conflicts, c.policy)
+	}
+	return setting
+}
+
+// serverLevel returns the level at which the policy applies to the port of
+// the Pod, and the mode it sets for the port.
+func serverLevel(p *networkingv1.GCPServerTLSPolicy, pod *corev1.Pod, port int32) (Level, networkingv1.MTLSMode) {
+	if p.Namespace != pod.Namespace {
+		return LevelNone, ""
+	}
+	mode := networkingv1.Strict
+	if p.Spec.MTLSMode != nil {
+		mode = *p.Spec.MTLSMode
+	}
+	level := LevelNone
+	for _, t := range p.Spec.TargetRefs {
+		if len(t.Selector.MatchLabels) == 0 && len(t.Selector.MatchExpressions) == 0 {
+			level = max(level, LevelNamespace)
+			continue
+		}
+		if !controlledBy(pod, t.Group, t.Kind) {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&t.Selector)
+		if err == nil && selector.Matches(labels.Set(pod.Labels)) {
+			level = LevelWorkload
+		}
+	}
+	if level != LevelWorkload {
+		return level, mode
+	}
+	for _, o := range p.Spec.PortOverrides {
+		if o.Port == port {
+			return LevelPort, o.MtlsMode
+		}
+	}
+	return level, mode
+}
+
+// controlledBy reports whether a Pod is a target of the given kind: it is
+// selected as a Pod, or its controller is a workload of that kind. Pods of
+// Deployments are owned by a ReplicaSet and have a pod-template-hash label.
+func controlledBy(pod *corev1.Pod, group gatewayv1.Group, kind gatewayv1.Kind) bool {
+	if group == "" && kind == "Pod" {
+		return true
+	}
+	if group != "apps" {
+		return false
+	}
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || !strings.HasPrefix(owner.APIVersion, "apps/") {
+		return false
+	}
+	switch kind {
+	case "Deployment":
+		return owner.Kind == "ReplicaSet" && pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey] != ""
+	case "StatefulSet", "DaemonSet":
+		return owner.Kind == string(kind)
+	}
+	return false
+}
+
+// ClientSetting is the client-side TLS setting of a port of a Service.
+type ClientSetting struct {
+	// Mode is the TLS mode of the port. It is Disable if no policy applies.
+	Mode networkingv1.TLSMode
+	// Policy is the policy that sets the mode, if any.
+	Policy *networkingv1.GCPClientTLSPolicy
+	// Level is the precedence level of Policy.
+	Level Level
+}
+
+// ResolveClient returns the TLS setting of clients of a port of a Service.
+// Of the policies that target the Service, a policy that targets the port
+// by its name takes precedence over a policy that targets the Service,
+// which takes precedence over a policy that targets the namespace. If
+// several policies apply at the same level, the oldest one wins, and then
+// the first by name.
+func ResolveClient(policies []*networkingv1.GCPClientTLSPolicy, svc *corev1.Service, port *corev1.ServicePort) ClientSetting {
+	setting := ClientSetting{Mode: networkingv1.Disable}
+	for _, p := range policies {
+		level := clientLevel(p, svc, port)
+		if level == LevelNone {
+			continue
+		}
+		if level > setting.Level || level == setting.Level && older(&p.ObjectMeta, &setting.Policy.ObjectMeta) {
+			mode := cmp.Or(p.Spec.TLSMode, networkingv1.MutualTLS)
+			setting = ClientSetting{Mode: mode, Policy: p, Level: level}
+		}
+	}
+	return setting
+}
+
+// clientLevel returns the level at which the policy applies to the port of
+// the Service.
+func clientLevel(p *networkingv1.GCPClientTLSPolicy, svc *corev1.Service, port *corev1.ServicePort) Level {
+	if p.Namespace != svc.Namespace {
+		return LevelNone
+	}
+	level := LevelNone
+	for _, t := range p.Spec.TargetRefs {
+		if t.Group != "" {
+			continue
+		}
+		switch {
+		case t.Kind == "Namespace" && string(t.Name) == svc.Namespace:
+			level = max(level, LevelNamespace)
+		case t.Kind == "Service" && string(t.Name) == svc.Name:
+			if t.SectionName == nil || *t.SectionName == "" {
+				level = max(level, LevelWorkload)
+			} else if string(*t.SectionName) == port.Name {
+				level = LevelPort
+			}
+		}
+	}
+	return level
+}
+
+// older reports whether the object a takes precedence over b: it was
+// created first, or at the same time and its name sorts first.
+func older(a, b *metav1.ObjectMeta) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+func policyName(meta *metav1.ObjectMeta) string {
+	return fmt.Sprintf("%s/%s", meta.Namespace, meta.Name)
+}
@@ -0,0 +1,477 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package mtls
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+func serverPolicy(name string, created int, mode networkingv1.MTLSMode, matchLabels map[string]string, overrides ...networkingv1.PortOverride) *networkingv1.GCPServerTLSPolicy {
+	return &networkingv1.GCPServerTLSPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, CreationTimestamp: metav1.NewTime(time.Unix(int64(created), 0))},
+		Spec: networkingv1.GCPServerTLSPolicySpec{
+			MTLSMode:      &mode,
+			PortOverrides: overrides,
+			TargetRefs: []networkingv1.PolicyTargetReferenceWithLabelSelectors{{
+				Kind:     "Pod",
+				Selector: metav1.LabelSelector{MatchLabels: matchLabels},
+			}},
+		},
+	}
+}
+
+func TestResolveServer(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web-1", Labels: map[string]string{"app": "web"}}}
+	web := map[string]string{"app": "web"}
+
+	tests := []struct {
+		name       string
+		policies   []*networkingv1.GCPServerTLSPolicy
+		port       int32
+		wantMode   networkingv1.MTLSMode
+		wantPolicy string
+		wantLevel  Level
+	}{
+		{
+			name:     "no policy",
+			port:     8080,
+			wantMode: networkingv1.Disabled,
+		},
+		{
+			name:       "namespace",
+			policies:   []*networkingv1.GCPServerTLSPolicy{serverPolicy("ns-wide", 0, networkingv1.Permissive, nil)},
+			port:       8080,
+			wantMode:   networkingv1.Permissive,
+			wantPolicy: "ns-wide",
+			wantLevel:  LevelNamespace,
+		},
+		{
+			name: "workload over namespace",
+			policies: []*networkingv1.GCPServerTLSPolicy{
+				serverPolicy("ns-wide", 0, networkingv1.Permissive, nil),
+				serverPolicy("web", 1, networkingv1.Strict, web),
+			},
+			port:       8080,
+			wantMode:   networkingv1.Strict,
+			wantPolicy: "web",
+			wantLevel:  LevelWorkload,
+		},
+		{
+			name: "port override",
+			policies: []*networkingv1.GCPServerTLSPolicy{
+				serverPolicy("web", 0, networkingv1.Strict, web, networkingv1.PortOverride{Port: 9090, MtlsMode: networkingv1.Disabled}),
+			},
+			port:       9090,
+			wantMode:   networkingv1.Disabled,
+			wantPolicy: "web",
+			wantLevel:  LevelPort,
+		},
+		{
+			name: "other port not overridden",
+			policies: []*networkingv1.GCPServerTLSPolicy{
+				serverPolicy("web", 0, networkingv1.Strict, web, networkingv1.PortOverride{Port: 9090, MtlsMode: networkingv1.Disabled}),
+			},
+			port:       8080,
+			wantMode:   networkingv1.Strict,
+			wantPolicy: "web",
+			wantLevel:  LevelWorkload,
+		},
+		{
+			name: "oldest wins",
+			policies: []*networkingv1.GCPServerTLSPolicy{
+				serverPolicy("newer", 2, networkingv1.Permissive, web),
+				serverPolicy("older", 1, networkingv1.Strict, web),
+			},
+			port:       8080,
+			wantMode:   networkingv1.Strict,
+			wantPolicy: "older",
+			wantLevel:  LevelWorkload,
+		},
+		{
+			name:     "selector does not match",
+			policies: []*networkingv1.GCPServerTLSPolicy{serverPolicy("db", 0, networkingv1.Strict, map[string]string{"app": "db"})},
+			port:     8080,
+			wantMode: networkingv1.Disabled,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ResolveServer(tc.policies, pod, tc.port)
+			gotPolicy := ""
+			if got.Policy != nil {
+				gotPolicy = got.Policy.Name
+			}
+			if got.Mode != tc.wantMode || gotPolicy != tc.wantPolicy || got.Level != tc.wantLevel {
+				t.Errorf("ResolveServer() = %s, %q, %s, want %s, %q, %s", got.Mode, gotPolicy, got.Level, tc.wantMode, tc.wantPolicy, tc.wantLevel)
+			}
+		})
+	}
+}
+
+func TestResolveServerSelectors(t *testing.T) {
+	rs := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "ns",
+		Name:      "web-7d9f8b6c5-x2x4z",
+		Labels:    map[string]string{"app": "web", "tier": "frontend", "pod-template-hash": "7d9f8b6c5"},
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-7d9f8b6c5", Controller: ptr.To(true),
+		}},
+	}}
+	bare := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web", Labels: map[string]string{"app": "web", "tier": "frontend"}}}
+	target := func(group, kind string, sel metav1.LabelSelector) networkingv1.PolicyTargetReferenceWithLabelSelectors {
+		return networkingv1.PolicyTargetReferenceWithLabelSelectors{Group: gatewayv1.Group(group), Kind: gatewayv1.Kind(kind), Selector: sel}
+	}
+	policy := func(name string, created int, refs ...networkingv1.PolicyTargetReferenceWithLabelSelectors) *networkingv1.GCPServerTLSPolicy {
+		p := serverPolicy(name, created, networkingv1.Permissive, nil)
+		p.Spec.TargetRefs = refs
+		return p
+	}
+	in := func(key string, values ...string) metav1.LabelSelector {
+		return metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: key, Operator: metav1.LabelSelectorOpIn, Values: values}}}
+	}
+	notIn := func(key string, values ...string) metav1.LabelSelector {
+		return metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: key, Operator: metav1.LabelSelectorOpNotIn, Values: values}}}
+	}
+
+	tests := []struct {
+		name          string
+		policies      []*networkingv1.GCPServerTLSPolicy
+		pod           *corev1.Pod
+		wantPolicy    string
+		wantConflicts []string
+	}{
+		{
+			name:       "In expression",
+			policies:   []*networkingv1.GCPServerTLSPolicy{policy("p", 0, target("", "Pod", in("tier", "frontend", "backend")))},
+			pod:        bare,
+			wantPolicy: "p",
+		},
+		{
+			name:     "NotIn expression",
+			policies: []*networkingv1.GCPServerTLSPolicy{policy("p", 0, target("", "Pod", notIn("tier", "frontend")))},
+			pod:      bare,
+		},
+		{
+			name: "labels and expressions are ANDed",
+			policies: []*networkingv1.GCPServerTLSPolicy{policy("p", 0, target("", "Pod", metav1.LabelSelector{
+				MatchLabels:      map[string]string{"app": "web"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "canary", Operator: metav1.LabelSelectorOpDoesNotExist}},
+			}))},
+			pod:        bare,
+			wantPolicy: "p",
+		},
+		{
+			name:       "any of several targetRefs",
+			policies:   []*networkingv1.GCPServerTLSPolicy{policy("p", 0, target("", "Pod", in("app", "db")), target("", "Pod", in("app", "web")))},
+			pod:        bare,
+			wantPolicy: "p",
+		},
+		{
+			name:       "Deployment",
+			policies:   []*networkingv1.GCPServerTLSPolicy{policy("p", 0, target("apps", "Deployment", in("app", "web")))},
+			pod:        rs,
+			wantPolicy: "p",
+		},
+		{
+			name:     "Deployment does not select bare Pods",
+			policies: []*networkingv1.GCPServerTLSPolicy{policy("p", 0, target("apps", "Deployment", in("app", "web")))},
+			pod:      bare,
+		},
+		{
+			name:     "StatefulSet does not select Pods of Deployments",
+			policies: []*networkingv1.GCPServerTLSPolicy{policy("p", 0, target("apps", "StatefulSet", in("app", "web")))},
+			pod:      rs,
+		},
+		{
+			name: "conflicts at the same level",
+			policies: []*networkingv1.GCPServerTLSPolicy{
+				policy("c", 2, target("", "Pod", in("app", "web"))),
+				policy("b", 1, target("apps", "Deployment", in("tier", "frontend"))),
+				policy("a", 2, target("", "Pod", in("tier", "frontend"))),
+				policy("namespace", 0, target("", "Pod", metav1.LabelSelector{})),
+			},
+			pod:           rs,
+			wantPolicy:    "b",
+			wantConflicts: []string{"a", "c"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ResolveServer(tc.policies, tc.pod, 8080)
+			gotPolicy := ""
+			if got.Policy != nil {
+				gotPolicy = got.Policy.Name
+			}
+			var gotConflicts []string
+			for _, p := range got.Conflicts {
+				gotConflicts = append(gotConflicts, p.Name)
+			}
+			if gotPolicy != tc.wantPolicy || strings.Join(gotConflicts, ",") != strings.Join(tc.wantConflicts, ",") {
+				t.Errorf("ResolveServer() = %q, conflicts %v, want %q, conflicts %v", gotPolicy, gotConflicts, tc.wantPolicy, tc.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestResolveClient(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"}}
+	policy := func(name string, mode networkingv1.TLSMode, refs ...gatewayv1.LocalPolicyTargetReferenceWithSectionName) *networkingv1.GCPClientTLSPolicy {
+		return &networkingv1.GCPClientTLSPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Spec:       networkingv1.GCPClientTLSPolicySpec{TLSMode: mode, TargetRefs: refs},
+		}
+	}
+	ref := func(kind, name, section string) gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+		r := gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Kind: gatewayv1.Kind(kind), Name: gatewayv1.ObjectName(name)},
+		}
+		if section != "" {
+			r.SectionName = ptr.To(gatewayv1.SectionName(section))
+		}
+		return r
+	}
+	policies := []*networkingv1.GCPClientTLSPolicy{
+		policy("namespace", "", ref("Namespace", "ns", "")),
+		policy("service", networkingv1.MutualTLS, ref("Service", "web", "")),
+		policy("metrics", networkingv1.Disable, ref("Service", "web", "metrics")),
+		policy("other", networkingv1.Disable, ref("Service", "db", "")),
+	}
+
+	tests := []struct {
+		name       string
+		policies   []*networkingv1.GCPClientTLSPolicy
+		port       string
+		wantMode   networkingv1.TLSMode
+		wantPolicy string
+	}{
+		{name: "no policy", port: "http", wantMode: networkingv1.Disable},
+		{name: "namespace defaults to MutualTLS", policies: policies[:1], port: "http", wantMode: networkingv1.MutualTLS, wantPolicy: "namespace"},
+		{name: "service", policies: policies, port: "http", wantMode: networkingv1.MutualTLS, wantPolicy: "service"},
+		{name: "service port", policies: policies, port: "metrics", wantMode: networkingv1.Disable, wantPolicy: "metrics"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ResolveClient(tc.policies, svc, &corev1.ServicePort{Name: tc.port})
+			gotPolicy := ""
+			if got.Policy != nil {
+				gotPolicy = got.Policy.Name
+			}
+			if got.Mode != tc.wantMode || gotPolicy != tc.wantPolicy {
+				t.Errorf("ResolveClient() = %s, %q, want %s, %q", got.Mode, gotPolicy, tc.wantMode, tc.wantPolicy)
+			}
+		})
+	}
+}
+
+const snapshot = `
+apiVersion: networking.gke.io/v1
+kind: GCPServerTLSPolicy
+metadata:
+  name: strict
+  namespace: shop
+spec:
+  mtlsMode: Strict
+  portOverrides:
+  - port: 9090
+    mtlsMode: Permissive
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchLabels:
+        app: cart
+---
+apiVersion: networking.gke.io/v1
+kind: GCPServerTLSPolicy
+metadata:
+  name: catalog
+  namespace: shop
+  creationTimestamp: "2026-01-02T00:00:00Z"
+spec:
+  mtlsMode: Permissive
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchExpressions:
+      - key: app
+        operator: In
+        values: [catalog]
+  - kind: Pod
+    selector:
+      matchLabels:
+        app: catalog-v2
+---
+apiVersion: networking.gke.io/v1
+kind: GCPServerTLSPolicy
+metadata:
+  name: catalog-plaintext
+  namespace: shop
+  creationTimestamp: "2026-01-01T00:00:00Z"
+spec:
+  mtlsMode: Disabled
+  targetRefs:
+  - kind: Pod
+    selector:
+      matchLabels:
+        app: catalog
+---
+apiVersion: networking.gke.io/v1
+kind: GCPClientTLSPolicy
+metadata:
+  name: cart-plaintext
+  namespace: shop
+spec:
+  tlsMode: Disable
+  targetRefs:
+  - group: ""
+    kind: Service
+    name: cart
+    sectionName: http
+---
+apiVersion: networking.gke.io/v1
+kind: GCPClientTLSPolicy
+metadata:
+  name: shop
+  namespace: shop
+spec:
+  targetRefs:
+  - group: ""
+    kind: Namespace
+    name: shop
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: cart
+    namespace: shop
+  spec:
+    selector:
+      app: cart
+    ports:
+    - name: http
+      port: 80
+      targetPort: http
+    - name: metrics
+      port: 9090
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: catalog
+    namespace: shop
+  spec:
+    selector:
+      app: catalog
+    ports:
+    - name: http
+      port: 80
+      targetPort: 8080
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: cart-1
+    namespace: shop
+    labels:
+      app: cart
+  spec:
+    containers:
+    - name: cart
+      image: cart
+      ports:
+      - name: http
+        containerPort: 8080
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: cart-2
+    namespace: shop
+    labels:
+      app: cart
+  spec:
+    containers:
+    - name: cart
+      image: cart
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: catalog-1
+    namespace: shop
+    labels:
+      app: catalog
+  spec:
+    containers:
+    - name: catalog
+      image: catalog
+---
+apiVersion: discovery.k8s.io/v1
+kind: EndpointSlice
+metadata:
+  name: cart-abcde
+  namespace: shop
+  labels:
+    kubernetes.io/service-name: cart
+addressType: IPv4
+ports:
+- name: http
+  port: 8080
+- name: metrics
+  port: 9090
+endpoints:
+- addresses: [10.0.0.1]
+  targetRef:
+    kind: Pod
+    name: cart-1
+- addresses: [10.0.0.2]
+  targetRef:
+    kind: Pod
+    name: cart-2
+`
+
+func TestAnalyze(t *testing.T) {
+	var s Snapshot
+	if err := s.Decode(strings.NewReader(snapshot)); err != nil {
+		t.Fatalf("Decode() = %v", err)
+	}
+	if len(s.ServerPolicies) != 3 || len(s.ClientPolicies) != 2 || len(s.Services) != 2 || len(s.Pods) != 3 || len(s.EndpointSlices) != 1 {
+		t.Fatalf("Decode() got %d/%d policies, %d services, %d pods and %d endpoint slices",
+			len(s.ServerPolicies), len(s.ClientPolicies), len(s.Services), len(s.Pods), len(s.EndpointSlices))
+	}
+
+	var buf bytes.Buffer
+	if err := Format(&buf, Analyze(&s)); err != nil {
+		t.Fatalf("Format() = %v", err)
+	}
+	want := `ERROR PlaintextToStrictServer: shop/cart:http -> port 8080 of cart-1,cart-2: clients use plaintext but the server only accepts mTLS; client Disable (shop/cart-plaintext), server Strict (shop/strict)
+ERROR MTLSToPlaintextServer: shop/catalog:http -> port 8080 of catalog-1: clients use mTLS but the server only accepts plaintext; client MutualTLS (shop/shop), server Disabled (shop/catalog-plaintext)
+WARNING PermissiveServer: shop/cart:metrics -> port 9090 of cart-1,cart-2: clients use mTLS but the Permissive server also accepts plaintext; client MutualTLS (shop/shop), server Permissive (shop/strict)
+WARNING ConflictingServerPolicies: shop/catalog:http -> port 8080 of catalog-1: GCPServerTLSPolicies that also apply to the server at the workload level are ignored: shop/catalog; client MutualTLS (shop/shop), server Disabled (shop/catalog-plaintext)
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,314 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package mtls
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// Severity is the severity of a Finding.
+type Severity string
+
+const (
+	// SeverityError is a finding that breaks traffic.
+	SeverityError Severity = "Error"
+	// SeverityWarning is a finding that leaves plaintext traffic possible.
+	SeverityWarning Severity = "Warning"
+)
+
+// Reason is the reason of a Finding.
+type Reason string
+
+const (
+	// ReasonMTLSToPlaintextServer means that clients use mTLS but the server
+	// only accepts plaintext.
+	ReasonMTLSToPlaintextServer Reason = "MTLSToPlaintextServer"
+	// ReasonPlaintextToStrictServer means that clients use plaintext but the
+	// server only accepts mTLS.
+	ReasonPlaintextToStrictServer Reason = "PlaintextToStrictServer"
+	// ReasonPlaintextToPermissiveServer means that clients use plaintext,
+	// which the server accepts because it is Permissive.
+	ReasonPlaintextToPermissiveServer Reason = "PlaintextToPermissiveServer"
+	// ReasonPermissiveServer means that clients of the Service use mTLS, but
+	// the server is Permissive and accepts plaintext from other clients.
+	ReasonPermissiveServer Reason = "PermissiveServer"
+	// ReasonConflictingServerPolicies means that several
+	// GCPServerTLSPolicies apply to the port of the server at the same
+	// level, and all but the oldest are ignored.
+	ReasonConflictingServerPolicies Reason = "ConflictingServerPolicies"
+)
+
+// Finding is a Service port whose clients and servers settings are
+// incompatible or allow plaintext traffic.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Reason   Reason   `json:"reason"`
+	// Service is the namespace/name of the Service.
+	Service string `json:"service"`
+	// ServicePort is the name of the Service port, or its number if it has
+	// no name.
+	ServicePort string `json:"servicePort"`
+	// ClientMode is the TLS mode of the clients of the Service port.
+	ClientMode networkingv1.TLSMode `json:"clientMode"`
+	// ClientPolicy is the namespace/name of the GCPClientTLSPolicy that sets
+	// ClientMode, if any.
+	ClientPolicy string `json:"clientPolicy,omitempty"`
+	// Pods are the names of the backend Pods of the Service port whose
+	// server settings are the same.
+	Pods []string `json:"pods"`
+	// Port is the port of the Pods that the Service port sends traffic to.
+	Port int32 `json:"port"`
+	// ServerMode is the mTLS mode of Port.
+	ServerMode networkingv1.MTLSMode `json:"serverMode"`
+	// ServerPolicy is the namespace/name of the GCPServerTLSPolicy that sets
+	// ServerMode, if any.
+	ServerPolicy string `json:"serverPolicy,omitempty"`
+	// ConflictingPolicies are the namespace/names of the
+	// GCPServerTLSPolicies that are ignored in favor of ServerPolicy.
+	ConflictingPolicies []string `json:"conflictingPolicies,omitempty"`
+	// Message is a human readable description of the finding.
+	Message string `json:"message"`
+}
+
+// check returns the findings for a client and a server setting.
+func check(client ClientSetting, server ServerSetting) []Finding {
+	f := Finding{ClientMode: client.Mode, ServerMode: server.Mode}
+	if client.Policy != nil {
+		f.ClientPolicy = policyName(&client.Policy.ObjectMeta)
+	}
+	if server.Policy != nil {
+		f.ServerPolicy = policyName(&server.Policy.ObjectMeta)
+	}
+
+	var findings []Finding
+	add := func(severity Severity, reason Reason, message string) {
+		f := f
+		f.Severity, f.Reason, f.Message = severity, reason, message
+		findings = append(findings, f)
+	}
+	switch {
+	case client.Mode == networkingv1.MutualTLS && server.Mode == networkingv1.Disabled:
+		add(SeverityError, ReasonMTLSToPlaintextServer, "clients use mTLS but the server only accepts plaintext")
+	case client.Mode == networkingv1.Disable && server.Mode == networkingv1.Strict:
+		add(SeverityError, ReasonPlaintextToStrictServer, "clients use plaintext but the server only accepts mTLS")
+	case client.Mode == networkingv1.Disable && server.Mode == networkingv1.Permissive:
+		add(SeverityWarning, ReasonPlaintextToPermissiveServer, "clients use plaintext, which the Permissive server accepts")
+	case client.Mode == networkingv1.MutualTLS && server.Mode == networkingv1.Permissive:
+		add(SeverityWarning, ReasonPermissiveServer, "clients use mTLS but the Permissive server also accepts plaintext")
+	}
+	if len(server.Conflicts) > 0 {
+		var names []string
+		for _, p := range server.Conflicts {
+			names = append(names, policyName(&p.ObjectMeta))
+		}
+		f.ConflictingPolicies = names
+		add(SeverityWarning, ReasonConflictingServerPolicies,
+			fmt.Sprintf("GCPServerTLSPolicies that also apply to the server at the %s level are ignored: %s", server.Level, strings.Join(names, ", ")))
+	}
+	return findings
+}
+
+// backend is a port of a backend Pod of a Service port.
+type backend struct {
+	pod  *corev1.Pod
+	port int32
+}
+
+// Analyze returns the findings of the snapshot, sorted by Service, Service
+// port and port, with errors first. The backends of each Service port are
+// read from the EndpointSlices of the Service, or from the Pods that its
+// selector matches if it has no EndpointSlices. Ports of Pods that are not
+// in the snapshot are skipped.
+func Analyze(s *Snapshot) []Finding {
+	pods := map[string]*corev1.Pod{}
+	for _, pod := range s.Pods {
+		pods[pod.Namespace+"/"+pod.Name] = pod
+	}
+	slicesByService := map[string][]*discoveryv1.EndpointSlice{}
+	for _, slice := range s.EndpointSlices {
+		if name := slice.Labels[discoveryv1.LabelServiceName]; name != "" {
+			key := slice.Namespace + "/" + name
+			slicesByService[key] = append(slicesByService[key], slice)
+		}
+	}
+
+	var findings []Finding
+	for _, svc := range s.Services {
+		key := svc.Namespace + "/" + svc.Name
+		for i := range svc.Spec.Ports {
+			sp := &svc.Spec.Ports[i]
+			if sp.Protocol != "" && sp.Protocol != corev1.ProtocolTCP {
+				continue
+			}
+			client := ResolveClient(s.ClientPolicies, svc, sp)
+			var backends []backend
+			if endpointSlices := slicesByService[key]; len(endpointSlices) > 0 {
+				backends = sliceBackends(endpointSlices, sp, pods)
+			} else {
+				backends = selectorBackends(svc, sp, s.Pods)
+			}
+
+			// Group the backends with the same server setting.
+			byServer := map[string]*Finding{}
+			for _, b := range backends {
+				server := ResolveServer(s.ServerPolicies, b.pod, b.port)
+				for _, f := range check(client, server) {
+					f.Service = key
+					f.ServicePort = servicePortName(sp)
+					f.Port = b.port
+					group := fmt.Sprintf("%s/%d/%s/%s", f.Reason, f.Port, f.ServerPolicy, strings.Join(f.ConflictingPolicies, ","))
+					if byServer[group] == nil {
+						byServer[group] = &f
+					}
+					if !slices.Contains(byServer[group].Pods, b.pod.Name) {
+						byServer[group].Pods = append(byServer[group].Pods, b.pod.Name)
+					}
+				}
+			}
+			for _, f := range byServer {
+				sort.Strings(f.Pods)
+				findings = append(findings, *f)
+			}
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity == SeverityError
+		}
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		if a.ServicePort != b.ServicePort {
+			return a.ServicePort < b.ServicePort
+		}
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		if a.Reason != b.Reason {
+			return a.Reason < b.Reason
+		}
+		return a.ServerPolicy < b.ServerPolicy
+	})
+	return findings
+}
+
+// sliceBackends returns the backends of a Service port from the endpoints
+// of its EndpointSlices that are backed by Pods.
+func sliceBackends(endpointSlices []*discoveryv1.EndpointSlice, sp *corev1.ServicePort, pods map[string]*corev1.Pod) []backend {
+	var ret []backend
+	for _, slice := range endpointSlices {
+		var port int32
+		for _, p := range slice.Ports {
+			if p.Port != nil && (p.Name == nil && sp.Name == "" || p.Name != nil && *p.Name == sp.Name) {
+				port = *p.Port
+				break
+			}
+		}
+		if port == 0 {
+			continue
+		}
+		for _, ep := range slice.Endpoints {
+			if ep.TargetRef == nil || ep.TargetRef.Kind != "Pod" {
+				continue
+			}
+			namespace := ep.TargetRef.Namespace
+			if namespace == "" {
+				namespace = slice.Namespace
+			}
+			if pod := pods[namespace+"/"+ep.TargetRef.Name]; pod != nil {
+				ret = append(ret, backend{pod: pod, port: port})
+			}
+		}
+	}
+	return ret
+}
+
+// selectorBackends returns the backends of a Service port from the Pods
+// that the selector of the Service matches.
+func selectorBackends(svc *corev1.Service, sp *corev1.ServicePort, pods []*corev1.Pod) []backend {
+	if len(svc.Spec.Selector) == 0 {
+		return nil
+	}
+	selector := labels.SelectorFromSet(svc.Spec.Selector)
+	var ret []backend
+	for _, pod := range pods {
+		if pod.Namespace != svc.Namespace || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		if port, ok := targetPort(pod, sp); ok {
+			ret = append(ret, backend{pod: pod, port: port})
+		}
+	}
+	return ret
+}
+
+// targetPort resolves the target port of a Service port on a Pod.
+func targetPort(pod *corev1.Pod, sp *corev1.ServicePort) (int32, bool) {
+	switch {
+	case sp.TargetPort.Type == intstr.String && sp.TargetPort.StrVal != "":
+		for _, c := range pod.Spec.Containers {
+			for _, p := range c.Ports {
+				if p.Name == sp.TargetPort.StrVal {
+					return p.ContainerPort, true
+				}
+			}
+		}
+		return 0, false
+	case sp.TargetPort.Type == intstr.Int && sp.TargetPort.IntVal != 0:
+		return sp.TargetPort.IntVal, true
+	}
+	return sp.Port, true
+}
+
+func servicePortName(sp *corev1.ServicePort) string {
+	if sp.Name != "" {
+		return sp.Name
+	}
+	return strconv.Itoa(int(sp.Port))
+}
+
+// Format writes the findings as text, one per line.
+func Format(w io.Writer, findings []Finding) error {
+	for _, f := range findings {
+		client := string(f.ClientMode)
+		if f.ClientPolicy != "" {
+			client += " (" + f.ClientPolicy + ")"
+		}
+		server := string(f.ServerMode)
+		if f.ServerPolicy != "" {
+			server += " (" + f.ServerPolicy + ")"
+		}
+		if _, err := fmt.Fprintf(w, "%s %s: %s:%s -> port %d of %s: %s; client %s, server %s\n",
+			strings.ToUpper(string(f.Severity)), f.Reason, f.Service, f.ServicePort, f.Port, strings.Join(f.Pods, ","), f.Message, client, server); err != nil {
+			return err
+		}
+	}
+	return nil
+}
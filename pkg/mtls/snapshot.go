@@ -0,0 +1,98 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package mtls
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/client/clientset/versioned"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/manifest"
+)
+
+// Snapshot is the state of a cluster that the posture is analyzed from.
+type Snapshot struct {
+	ServerPolicies []*networkingv1.GCPServerTLSPolicy
+	ClientPolicies []*networkingv1.GCPClientTLSPolicy
+	Services       []*corev1.Service
+	Pods           []*corev1.Pod
+	EndpointSlices []*discoveryv1.EndpointSlice
+}
+
+// Decode adds the TLS policies, Services, Pods and EndpointSlices of a
+// multi-document YAML or JSON stream, such as the output of `kubectl get
+// gcpservertlspolicies,gcpclienttlspolicies,services,pods,endpointslices -A
+// -o yaml`, to the snapshot. Lists are expanded, and documents of other
+// kinds are skipped.
+func (s *Snapshot) Decode(r io.Reader) error {
+	return manifest.Decode(r, manifest.Kinds{
+		"GCPServerTLSPolicy": manifest.Strict(&s.ServerPolicies),
+		"GCPClientTLSPolicy": manifest.Strict(&s.ClientPolicies),
+		"Service":            manifest.Lenient(&s.Services),
+		"Pod":                manifest.Lenient(&s.Pods),
+		"EndpointSlice":      manifest.Lenient(&s.EndpointSlices),
+	})
+}
+
+// FromCluster reads a snapshot from a live cluster, in the given namespace
+// or in all namespaces if it is empty.
+func FromCluster(ctx context.Context, kube kubernetes.Interface, networking versioned.Interface, namespace string) (*Snapshot, error) {
+	s := &Snapshot{}
+	serverPolicies, err := networking.NetworkingV1().GCPServerTLSPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list GCPServerTLSPolicies: %w", err)
+	}
+	for i := range serverPolicies.Items {
+		s.ServerPolicies = append(s.ServerPolicies, &serverPolicies.Items[i])
+	}
+	clientPolicies, err := networking.NetworkingV1().GCPClientTLSPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list GCPClientTLSPolicies: %w", err)
+	}
+	for i := range clientPolicies.Items {
+		s.ClientPolicies = append(s.ClientPolicies, &clientPolicies.Items[i])
+	}
+	services, err := kube.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Services: %w", err)
+	}
+	for i := range services.Items {
+		s.Services = append(s.Services, &services.Items[i])
+	}
+	pods, err := kube.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Pods: %w", err)
+	}
+	for i := range pods.Items {
+		s.Pods = append(s.Pods, &pods.Items[i])
+	}
+	endpointSlices, err := kube.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list EndpointSlices: %w", err)
+	}
+	for i := range endpointSlices.Items {
+		s.EndpointSlices = append(s.EndpointSlices, &endpointSlices.Items[i])
+	}
+	return s, nil
+}
@@ -0,0 +1,53 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package policyref holds the error returned when the references of a policy
+// cannot be resolved.
+package policyref
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// RefError is returned when a policy, or an object it references, cannot be
+// resolved. Reason can be used as the reason of the policy condition that
+// reflects the error, such as "Attached" for a GCPBackendPolicy or
+// "ResolvedRefs" for a GCPAuthzPolicy.
+type RefError struct {
+	// Reason is the reason of the condition that reflects this error.
+	Reason networkingv1.PolicyConditionReason
+	// Ref is the referenced object, if any.
+	Ref types.NamespacedName
+	// Message is a human readable description of the error.
+	Message string
+	// Err is the underlying error, if any.
+	Err error
+}
+
+func (e *RefError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *RefError) Unwrap() error {
+	return e.Err
+}
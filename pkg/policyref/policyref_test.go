@@ -0,0 +1,54 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package policyref
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+func TestRefError(t *testing.T) {
+	cause := errors.New("connection refused")
+	for _, tc := range []struct {
+		err  *RefError
+		want string
+	}{
+		{
+			err:  &RefError{Reason: networkingv1.PolicyReasonInvalidRef, Message: "ConfigMap app/ca not found"},
+			want: "ConfigMap app/ca not found",
+		},
+		{
+			err:  &RefError{Reason: networkingv1.PolicyReasonInvalidRef, Message: "failed to get ConfigMap app/ca", Err: cause},
+			want: "failed to get ConfigMap app/ca: connection refused",
+		},
+	} {
+		if got := tc.err.Error(); got != tc.want {
+			t.Errorf("Error() = %q, want %q", got, tc.want)
+		}
+		wrapped := fmt.Errorf("resolving policy: %w", tc.err)
+		var refErr *RefError
+		if !errors.As(wrapped, &refErr) || refErr.Reason != tc.err.Reason {
+			t.Errorf("errors.As(%v) = %v, want reason %s", wrapped, refErr, tc.err.Reason)
+		}
+		if got := errors.Is(wrapped, cause); got != (tc.err.Err != nil) {
+			t.Errorf("errors.Is(%v, cause) = %t", wrapped, got)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package refgrant checks cross namespace references against Gateway API
+// ReferenceGrants.
+package refgrant
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	gatewayv1beta1listers "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+)
+
+// From identifies the object that holds a reference.
+type From struct {
+	// Group is the API group of the referencing object.
+	Group string
+	// Kind is the kind of the referencing object.
+	Kind string
+	// Namespace is the namespace of the referencing object.
+	Namespace string
+}
+
+// To identifies the referenced object.
+type To struct {
+	// Group is the API group of the referenced object. Empty for the core API
+	// group.
+	Group string
+	// Kind is the kind of the referenced object.
+	Kind string
+	// Namespace is the namespace of the referenced object.
+	Namespace string
+	// Name is the name of the referenced object.
+	Name string
+}
+
+// Permitted returns true if the reference from an object described by from to
+// the object described by to is allowed. References within a namespace are
+// always allowed. References across namespaces are allowed if a ReferenceGrant
+// in the namespace of the referenced object allows them.
+func Permitted(lister gatewayv1beta1listers.ReferenceGrantLister, from From, to To) (bool, error) {
+	if from.Namespace == to.Namespace {
+		return true, nil
+	}
+	grants, err := lister.ReferenceGrants(to.Namespace).List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	for _, grant := range grants {
+		fromMatches := false
+		for _, f := range grant.Spec.From {
+			if string(f.Group) == from.Group && string(f.Kind) == from.Kind && string(f.Namespace) == from.Namespace {
+				fromMatches = true
+				break
+			}
+		}
+		if !fromMatches {
+			continue
+		}
+		for _, t := range grant.Spec.To {
+			if string(t.Group) != to.Group || string(t.Kind) != to.Kind {
+				continue
+			}
+			if t.Name == nil || *t.Name == "" || string(*t.Name) == to.Name {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
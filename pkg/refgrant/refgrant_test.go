@@ -0,0 +1,102 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package refgrant
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gatewayv1beta1listers "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+)
+
+func TestPermitted(t *testing.T) {
+	grants := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, g := range []*gatewayv1beta1.ReferenceGrant{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "any-secret"},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{
+					{Group: "networking.gke.io", Kind: "GCPBackendPolicy", Namespace: "app"},
+					{Group: "networking.gke.io", Kind: "GCPBackendPolicy", Namespace: "web"},
+				},
+				To: []gatewayv1beta1.ReferenceGrantTo{{Kind: "Secret"}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "empty-name"},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{{Group: "networking.gke.io", Kind: "GCPBackendPolicy", Namespace: "empty"}},
+				To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: "Secret", Name: ptr.To[gatewayv1beta1.ObjectName]("")}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "restricted", Name: "one-secret"},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{{Group: "networking.gke.io", Kind: "GCPBackendPolicy", Namespace: "app"}},
+				To: []gatewayv1beta1.ReferenceGrantTo{
+					{Kind: "Secret", Name: ptr.To[gatewayv1beta1.ObjectName]("iap")},
+					{Group: "networking.gke.io", Kind: "GCPAuthzExtension"},
+				},
+			},
+		},
+	} {
+		grants.Add(g)
+	}
+	lister := gatewayv1beta1listers.NewReferenceGrantLister(grants)
+
+	backendPolicy := func(namespace string) From {
+		return From{Group: "networking.gke.io", Kind: "GCPBackendPolicy", Namespace: namespace}
+	}
+	secret := func(namespace, name string) To {
+		return To{Kind: "Secret", Namespace: namespace, Name: name}
+	}
+
+	for _, tc := range []struct {
+		desc string
+		from From
+		to   To
+		want bool
+	}{
+		{desc: "same namespace", from: backendPolicy("app"), to: secret("app", "iap"), want: true},
+		{desc: "same namespace without grants", from: backendPolicy("other"), to: secret("other", "iap"), want: true},
+		{desc: "any name", from: backendPolicy("app"), to: secret("shared", "iap"), want: true},
+		{desc: "second from entry", from: backendPolicy("web"), to: secret("shared", "tls"), want: true},
+		{desc: "empty name is a wildcard", from: backendPolicy("empty"), to: secret("shared", "tls"), want: true},
+		{desc: "namespace not granted", from: backendPolicy("other"), to: secret("shared", "iap"), want: false},
+		{desc: "kind of referencing object not granted", from: From{Group: "networking.gke.io", Kind: "GCPGatewayPolicy", Namespace: "app"}, to: secret("shared", "iap"), want: false},
+		{desc: "group of referencing object not granted", from: From{Group: "example.com", Kind: "GCPBackendPolicy", Namespace: "app"}, to: secret("shared", "iap"), want: false},
+		{desc: "named object", from: backendPolicy("app"), to: secret("restricted", "iap"), want: true},
+		{desc: "other name", from: backendPolicy("app"), to: secret("restricted", "tls"), want: false},
+		{desc: "kind of referenced object not granted", from: backendPolicy("app"), to: To{Kind: "ConfigMap", Namespace: "restricted", Name: "iap"}, want: false},
+		{desc: "group of referenced object", from: backendPolicy("app"), to: To{Group: "networking.gke.io", Kind: "GCPAuthzExtension", Namespace: "restricted", Name: "ext"}, want: true},
+		{desc: "group of referenced object not granted", from: backendPolicy("app"), to: To{Group: "example.com", Kind: "Secret", Namespace: "restricted", Name: "iap"}, want: false},
+		{desc: "grant in the referencing namespace does not apply", from: From{Group: "networking.gke.io", Kind: "GCPBackendPolicy", Namespace: "shared"}, to: secret("app", "iap"), want: false},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := Permitted(lister, tc.from, tc.to)
+			if err != nil {
+				t.Fatalf("Permitted() = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Permitted() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
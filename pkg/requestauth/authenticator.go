@@ -0,0 +1,269 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package requestauth
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/authz"
+)
+
+const (
+	// DefaultJWKSKey is the ConfigMap key of the key set if a JWKS reference
+	// does not specify one.
+	DefaultJWKSKey = "jwks.json"
+	// DefaultClockSkew is the clock skew allowed when validating the exp and
+	// nbf claims.
+	DefaultClockSkew = 60 * time.Second
+)
+
+// DefaultTokenLocation is the location tokens are read from if a rule does
+// not specify any.
+var DefaultTokenLocation = networkingv1.JWTTokenLocation{
+	Header: &networkingv1.JWTHeaderLocation{Name: "Authorization", Prefix: ptr.To("Bearer ")},
+}
+
+// Authenticator validates the tokens of requests against the rules of a
+// GCPRequestAuthenticationPolicy.
+type Authenticator struct {
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+	// ClockSkew is the clock skew allowed when validating the exp and nbf
+	// claims.
+	ClockSkew time.Duration
+	rules     []*rule
+}
+
+type rule struct {
+	networkingv1.JWTRule
+	keys *KeySet
+}
+
+// New returns an Authenticator for the given policy. The key sets of rules
+// with a JWKS reference are read from the given ConfigMaps. Rules with a
+// JWKS URI have no key set until one is set with SetKeySet, and their
+// tokens fail validation until then.
+func New(policy *networkingv1.GCPRequestAuthenticationPolicy, configMaps []*corev1.ConfigMap) (*Authenticator, error) {
+	a := &Authenticator{Now: time.Now, ClockSkew: DefaultClockSkew}
+	for _, r := range policy.Spec.JWTRules {
+		cr := &rule{JWTRule: r}
+		if r.JWKS != nil {
+			data, err := readJWKS(policy.Namespace, r.JWKS, configMaps)
+			if err != nil {
+				return nil, fmt.Errorf("issuer %s: %w", r.Issuer, err)
+			}
+			if cr.keys, err = ParseJWKS(data); err != nil {
+				return nil, fmt.Errorf("issuer %s: %w", r.Issuer, err)
+			}
+		}
+		a.rules = append(a.rules, cr)
+	}
+	return a, nil
+}
+
+func readJWKS(namespace string, ref *networkingv1.JWKSConfigMapReference, configMaps []*corev1.ConfigMap) ([]byte, error) {
+	key := DefaultJWKSKey
+	if ref.Key != nil {
+		key = *ref.Key
+	}
+	for _, cm := range configMaps {
+		if cm.Namespace != namespace || cm.Name != string(ref.Name) {
+			continue
+		}
+		if data, ok := cm.Data[key]; ok {
+			return []byte(data), nil
+		}
+		if data, ok := cm.BinaryData[key]; ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf("ConfigMap %s/%s has no key %q", namespace, ref.Name, key)
+	}
+	return nil, fmt.Errorf("ConfigMap %s/%s not found", namespace, ref.Name)
+}
+
+// SetKeySet sets the key set of the rule of the given issuer, typically one
+// that was fetched from its JWKS URI.
+func (a *Authenticator) SetKeySet(issuer string, ks *KeySet) error {
+	for _, r := range a.rules {
+		if r.Issuer == issuer {
+			r.keys = ks
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q", ErrUnknownIssuer, issuer)
+}
+
+// Result is the outcome of authenticating a request with a token.
+type Result struct {
+	// Issuer is the issuer of the token.
+	Issuer string
+	// Claims are the claims of the validated token. They can be used as
+	// authz.Request Claims.
+	Claims map[string]any
+	// Header is the request header that is sent to the backend, with the
+	// token removed unless forwardOriginalToken is set and the claims copied
+	// by claimToHeaders.
+	Header http.Header
+}
+
+// Authenticate validates the token of a request with the given header.
+// It returns nil and no error if the request has no token, as such requests
+// are left to GCPAuthzPolicies. The token is read from the locations of all
+// rules, and validated by the rule of its issuer.
+func (a *Authenticator) Authenticate(header http.Header) (*Result, error) {
+	for _, r := range a.rules {
+		for _, loc := range r.locations() {
+			raw, ok := extractToken(header, loc)
+			if !ok {
+				continue
+			}
+			t, err := Parse(raw)
+			if err != nil {
+				return nil, err
+			}
+			tr := a.rule(t.Issuer())
+			if tr == nil {
+				return nil, fmt.Errorf("%w %q", ErrUnknownIssuer, t.Issuer())
+			}
+			if !slices.ContainsFunc(tr.locations(), func(l networkingv1.JWTTokenLocation) bool { return sameLocation(l, loc) }) {
+				return nil, fmt.Errorf("tokens of issuer %s are not accepted from %s", tr.Issuer, describeLocation(loc))
+			}
+			if err := a.validate(tr, t); err != nil {
+				return nil, err
+			}
+			return &Result{Issuer: tr.Issuer, Claims: t.Claims, Header: tr.rewriteHeader(header, loc, t.Claims)}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (a *Authenticator) rule(issuer string) *rule {
+	for _, r := range a.rules {
+		if r.Issuer == issuer {
+			return r
+		}
+	}
+	return nil
+}
+
+func (a *Authenticator) validate(r *rule, t *Token) error {
+	if r.keys == nil {
+		return fmt.Errorf("issuer %s: no key set, it must be set with SetKeySet", r.Issuer)
+	}
+	if err := t.Verify(r.keys); err != nil {
+		return err
+	}
+	now := time.Now
+	if a.Now != nil {
+		now = a.Now
+	}
+	if err := t.ValidateTime(now(), a.ClockSkew); err != nil {
+		return err
+	}
+	if len(r.Audiences) > 0 && !slices.ContainsFunc(t.Audiences(), func(aud string) bool { return slices.Contains(r.Audiences, aud) }) {
+		return fmt.Errorf("%w: %v", ErrInvalidAudience, t.Audiences())
+	}
+	return nil
+}
+
+func (r *rule) locations() []networkingv1.JWTTokenLocation {
+	if len(r.TokenLocations) == 0 {
+		return []networkingv1.JWTTokenLocation{DefaultTokenLocation}
+	}
+	return r.TokenLocations
+}
+
+func extractToken(header http.Header, loc networkingv1.JWTTokenLocation) (string, bool) {
+	if loc.Cookie != nil {
+		c, err := (&http.Request{Header: header}).Cookie(*loc.Cookie)
+		if err != nil || c.Value == "" {
+			return "", false
+		}
+		return c.Value, true
+	}
+	prefix := ""
+	if loc.Header.Prefix != nil {
+		prefix = *loc.Header.Prefix
+	}
+	for _, v := range header.Values(string(loc.Header.Name)) {
+		if token, ok := strings.CutPrefix(v, prefix); ok && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+func sameLocation(a, b networkingv1.JWTTokenLocation) bool {
+	if a.Cookie != nil || b.Cookie != nil {
+		return a.Cookie != nil && b.Cookie != nil && *a.Cookie == *b.Cookie
+	}
+	return strings.EqualFold(string(a.Header.Name), string(b.Header.Name)) && deref(a.Header.Prefix) == deref(b.Header.Prefix)
+}
+
+func describeLocation(loc networkingv1.JWTTokenLocation) string {
+	if loc.Cookie != nil {
+		return "cookie " + *loc.Cookie
+	}
+	return "header " + string(loc.Header.Name)
+}
+
+// rewriteHeader returns the header that is sent to the backend.
+func (r *rule) rewriteHeader(header http.Header, loc networkingv1.JWTTokenLocation, claims map[string]any) http.Header {
+	out := header.Clone()
+	if r.ForwardOriginalToken == nil || !*r.ForwardOriginalToken {
+		if loc.Cookie != nil {
+			removeCookie(out, *loc.Cookie)
+		} else {
+			out.Del(string(loc.Header.Name))
+		}
+	}
+	for _, c := range r.ClaimToHeaders {
+		out.Del(string(c.Header))
+		if values := authz.ClaimValues(claims, c.Claim); len(values) > 0 {
+			out.Set(string(c.Header), strings.Join(values, ","))
+		}
+	}
+	return out
+}
+
+func removeCookie(header http.Header, name string) {
+	var kept []string
+	for _, c := range (&http.Request{Header: header}).Cookies() {
+		if c.Name != name {
+			kept = append(kept, c.Name+"="+c.Value)
+		}
+	}
+	header.Del("Cookie")
+	if len(kept) > 0 {
+		header.Set("Cookie", strings.Join(kept, "; "))
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
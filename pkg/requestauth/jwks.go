@@ -0,0 +1,191 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package requestauth validates JSON Web Tokens the way a
+// GCPRequestAuthenticationPolicy does. It works offline, against key sets
+// from ConfigMaps or supplied by the caller, which makes it suitable for
+// testing policies and tokens before they are deployed.
+package requestauth
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// minRSAKeyBits is the minimum size of RSA keys.
+const minRSAKeyBits = 2048
+
+// Key is a public key of a JSON Web Key Set.
+type Key struct {
+	// ID is the key ID, which tokens reference in their kid header.
+	ID string
+	// Algorithm is the algorithm the key must be used with, or empty if any
+	// algorithm compatible with the key type can be used.
+	Algorithm string
+	// Public is an *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey.
+	Public crypto.PublicKey
+}
+
+// KeySet is a parsed JSON Web Key Set.
+type KeySet struct {
+	Keys []Key
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC and OKP
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// Private key members, which must not be published.
+	D string `json:"d"`
+}
+
+// ParseJWKS parses a JSON Web Key Set, as defined by RFC 7517. Only public
+// signing keys of type RSA, EC (P-256, P-384 and P-521) and OKP (Ed25519) are
+// supported. Keys with another use than `sig` are skipped.
+func ParseJWKS(data []byte) (*KeySet, error) {
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+	ks := &KeySet{}
+	for i, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := parseKey(k)
+		if err != nil {
+			if k.Kid != "" {
+				return nil, fmt.Errorf("JWKS key %q: %w", k.Kid, err)
+			}
+			return nil, fmt.Errorf("JWKS key %d: %w", i, err)
+		}
+		if k.Alg != "" {
+			if err := checkKey(k.Alg, pub); err != nil {
+				return nil, fmt.Errorf("JWKS key %q: %w", k.Kid, err)
+			}
+		}
+		ks.Keys = append(ks.Keys, Key{ID: k.Kid, Algorithm: k.Alg, Public: pub})
+	}
+	if len(ks.Keys) == 0 {
+		return nil, errors.New("JWKS contains no signing keys")
+	}
+	return ks, nil
+}
+
+func parseKey(k jsonWebKey) (crypto.PublicKey, error) {
+	if k.D != "" {
+		return nil, errors.New("contains a private key")
+	}
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid n: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid e: %w", err)
+		}
+		if n.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key has %d bits, at least %d are required", n.BitLen(), minRSAKeyBits)
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 || e.Bit(0) == 0 {
+			return nil, errors.New("invalid RSA public exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var (
+			curve elliptic.Curve
+			ec    ecdh.Curve
+		)
+		switch k.Crv {
+		case "P-256":
+			curve, ec = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, ec = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			curve, ec = elliptic.P521(), ecdh.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		x, err := decodeFixed(k.X, size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x: %w", err)
+		}
+		y, err := decodeFixed(k.Y, size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y: %w", err)
+		}
+		// Check that the point is on the curve.
+		if _, err := ec.NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, fmt.Errorf("invalid EC point: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeFixed(k.X, ed25519.PublicKeySize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x: %w", err)
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func decodeFixed(s string, size int) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("got %d bytes, want %d", len(b), size)
+	}
+	return b, nil
+}
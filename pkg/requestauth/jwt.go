@@ -0,0 +1,257 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package requestauth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	_ "crypto/sha256" // SHA-256 for RS256, PS256 and ES256.
+	_ "crypto/sha512" // SHA-384 and SHA-512.
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Errors of token validation. The errors returned by Verify and
+// Authenticator.Authenticate wrap one of them.
+var (
+	ErrMalformed        = errors.New("malformed token")
+	ErrUnsupportedAlg   = errors.New("unsupported signing algorithm")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrUnknownIssuer    = errors.New("unknown issuer")
+	ErrInvalidAudience  = errors.New("audience not allowed")
+	ErrExpired          = errors.New("token is expired")
+	ErrNotYetValid      = errors.New("token is not valid yet")
+)
+
+type algorithm struct {
+	hash crypto.Hash
+	// kind is the key type: RSA, RSA-PSS, EC or OKP.
+	kind string
+	// curveBits is the size of the curve of EC algorithms.
+	curveBits int
+}
+
+var algorithms = map[string]algorithm{
+	"RS256": {crypto.SHA256, "RSA", 0},
+	"RS384": {crypto.SHA384, "RSA", 0},
+	"RS512": {crypto.SHA512, "RSA", 0},
+	"PS256": {crypto.SHA256, "RSA-PSS", 0},
+	"PS384": {crypto.SHA384, "RSA-PSS", 0},
+	"PS512": {crypto.SHA512, "RSA-PSS", 0},
+	"ES256": {crypto.SHA256, "EC", 256},
+	"ES384": {crypto.SHA384, "EC", 384},
+	"ES512": {crypto.SHA512, "EC", 521},
+	"EdDSA": {0, "OKP", 0},
+}
+
+// checkKey returns an error if the given key cannot be used with the given
+// algorithm.
+func checkKey(alg string, pub crypto.PublicKey) error {
+	a, ok := algorithms[alg]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnsupportedAlg, alg)
+	}
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		if a.kind == "RSA" || a.kind == "RSA-PSS" {
+			return nil
+		}
+	case *ecdsa.PublicKey:
+		if a.kind == "EC" && pub.Curve.Params().BitSize == a.curveBits {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if a.kind == "OKP" {
+			return nil
+		}
+	}
+	return fmt.Errorf("key cannot be used with %s", alg)
+}
+
+// Token is a parsed JSON Web Token whose signature has not been verified.
+type Token struct {
+	// Algorithm is the alg header of the token.
+	Algorithm string
+	// KeyID is the kid header of the token.
+	KeyID string
+	// Claims are the claims of the token. Numbers are json.Number values.
+	Claims map[string]any
+
+	signingInput string
+	signature    []byte
+}
+
+// Parse parses the given compact serialized JWS token without verifying
+// its signature.
+func Parse(raw string) (*Token, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: want 3 parts, got %d", ErrMalformed, len(parts))
+	}
+	var header struct {
+		Alg  string `json:"alg"`
+		Kid  string `json:"kid"`
+		Crit []any  `json:"crit"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrMalformed, err)
+	}
+	if len(header.Crit) > 0 {
+		return nil, fmt.Errorf("%w: critical header extensions are not supported", ErrMalformed)
+	}
+	if _, ok := algorithms[header.Alg]; !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedAlg, header.Alg)
+	}
+	t := &Token{Algorithm: header.Alg, KeyID: header.Kid, signingInput: parts[0] + "." + parts[1]}
+	if err := decodeSegment(parts[1], &t.Claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrMalformed, err)
+	}
+	if t.Claims == nil {
+		return nil, fmt.Errorf("%w: claims must be a JSON object", ErrMalformed)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrMalformed, err)
+	}
+	t.signature = sig
+	return t, nil
+}
+
+func decodeSegment(s string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+// Issuer returns the iss claim of the token.
+func (t *Token) Issuer() string {
+	iss, _ := t.Claims["iss"].(string)
+	return iss
+}
+
+// Verify verifies the signature of the token with the keys of the given key
+// set. If the token has a key ID, only the key with that ID is used.
+func (t *Token) Verify(ks *KeySet) error {
+	a := algorithms[t.Algorithm]
+	tried := false
+	for _, k := range ks.Keys {
+		if (t.KeyID != "" && k.ID != t.KeyID) || (k.Algorithm != "" && k.Algorithm != t.Algorithm) {
+			continue
+		}
+		if checkKey(t.Algorithm, k.Public) != nil {
+			continue
+		}
+		tried = true
+		if verifySignature(a, k.Public, t.signingInput, t.signature) {
+			return nil
+		}
+	}
+	if !tried {
+		if t.KeyID != "" {
+			return fmt.Errorf("%w: no %s key with ID %q", ErrInvalidSignature, t.Algorithm, t.KeyID)
+		}
+		return fmt.Errorf("%w: no %s key", ErrInvalidSignature, t.Algorithm)
+	}
+	return ErrInvalidSignature
+}
+
+func verifySignature(a algorithm, pub crypto.PublicKey, input string, sig []byte) bool {
+	if a.kind == "OKP" {
+		return ed25519.Verify(pub.(ed25519.PublicKey), []byte(input), sig)
+	}
+	h := a.hash.New()
+	h.Write([]byte(input))
+	digest := h.Sum(nil)
+	switch a.kind {
+	case "RSA":
+		return rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), a.hash, digest, sig) == nil
+	case "RSA-PSS":
+		return rsa.VerifyPSS(pub.(*rsa.PublicKey), a.hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+	case "EC":
+		size := (a.curveBits + 7) / 8
+		if len(sig) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(pub.(*ecdsa.PublicKey), digest, r, s)
+	}
+	return false
+}
+
+// ValidateTime validates the exp and nbf claims of the token at the given
+// time, allowing for the given clock skew. Tokens without exp do not expire.
+func (t *Token) ValidateTime(now time.Time, skew time.Duration) error {
+	if exp, ok, err := t.numericDate("exp"); err != nil {
+		return err
+	} else if ok && !now.Before(exp.Add(skew)) {
+		return fmt.Errorf("%w: expired at %s", ErrExpired, exp.UTC().Format(time.RFC3339))
+	}
+	if nbf, ok, err := t.numericDate("nbf"); err != nil {
+		return err
+	} else if ok && now.Add(skew).Before(nbf) {
+		return fmt.Errorf("%w: valid from %s", ErrNotYetValid, nbf.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (t *Token) numericDate(claim string) (time.Time, bool, error) {
+	v, ok := t.Claims[claim]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("%w: %s must be a number", ErrMalformed, claim)
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: %s: %v", ErrMalformed, claim, err)
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*1e9)), true, nil
+}
+
+// Audiences returns the aud claim of the token, which can be a string or a
+// list of strings.
+func (t *Token) Audiences() []string {
+	switch aud := t.Claims["aud"].(type) {
+	case string:
+		return []string{aud}
+	case []any:
+		var out []string
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package requestauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+var b64 = base64.RawURLEncoding
+
+type testKey struct {
+	kid string
+	alg string
+	key crypto.Signer
+}
+
+func (k testKey) jwk() map[string]string {
+	m := map[string]string{"kid": k.kid, "use": "sig"}
+	switch pub := k.key.Public().(type) {
+	case *rsa.PublicKey:
+		m["kty"], m["n"], m["e"] = "RSA", b64.EncodeToString(pub.N.Bytes()), b64.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		m["kty"], m["crv"] = "EC", pub.Curve.Params().Name
+		m["x"], m["y"] = b64.EncodeToString(pub.X.FillBytes(make([]byte, size))), b64.EncodeToString(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		m["kty"], m["crv"], m["x"] = "OKP", "Ed25519", b64.EncodeToString(pub)
+	}
+	return m
+}
+
+func (k testKey) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": k.alg, "kid": k.kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	input := b64.EncodeToString(header) + "." + b64.EncodeToString(payload)
+	a := algorithms[k.alg]
+	var (
+		sig []byte
+		err error
+	)
+	switch a.kind {
+	case "OKP":
+		sig, err = k.key.Sign(rand.Reader, []byte(input), crypto.Hash(0))
+	case "RSA-PSS":
+		h := a.hash.New()
+		h.Write([]byte(input))
+		sig, err = rsa.SignPSS(rand.Reader, k.key.(*rsa.PrivateKey), a.hash, h.Sum(nil), &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case "EC":
+		h := a.hash.New()
+		h.Write([]byte(input))
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k.key.(*ecdsa.PrivateKey), h.Sum(nil))
+		size := (a.curveBits + 7) / 8
+		sig = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+	default:
+		h := a.hash.New()
+		h.Write([]byte(input))
+		sig, err = k.key.Sign(rand.Reader, h.Sum(nil), a.hash)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + b64.EncodeToString(sig)
+}
+
+func jwks(keys ...testKey) string {
+	var doc struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	for _, k := range keys {
+		doc.Keys = append(doc.Keys, k.jwk())
+	}
+	b, _ := json.Marshal(doc)
+	return string(b)
+}
+
+func TestAuthenticate(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	p256, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	keys := []testKey{
+		{"rs", "RS256", rsaKey},
+		{"ps", "PS384", rsaKey},
+		{"es256", "ES256", p256},
+		{"es384", "ES384", p384},
+		{"ed", "EdDSA", edKey},
+	}
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	policy := &networkingv1.GCPRequestAuthenticationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "jwt"},
+		Spec: networkingv1.GCPRequestAuthenticationPolicySpec{JWTRules: []networkingv1.JWTRule{
+			{
+				Issuer:         "https://issuer.example.com",
+				Audiences:      []string{"orders"},
+				JWKS:           &networkingv1.JWKSConfigMapReference{Name: "jwks"},
+				ClaimToHeaders: []networkingv1.JWTClaimToHeader{{Header: "X-Groups", Claim: "groups"}},
+			},
+			{
+				Issuer:         "https://partner.example.com",
+				JWKS:           &networkingv1.JWKSConfigMapReference{Name: "jwks", Key: ptr.To("partner")},
+				TokenLocations: []networkingv1.JWTTokenLocation{{Cookie: ptr.To("session")}},
+			},
+		}},
+	}
+	configMaps := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "jwks"},
+		Data:       map[string]string{DefaultJWKSKey: jwks(keys...), "partner": jwks(keys[0])},
+	}}
+	a, err := New(policy, configMaps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	a.Now = func() time.Time { return now }
+	claims := func(extra map[string]any) map[string]any {
+		c := map[string]any{"iss": "https://issuer.example.com", "sub": "alice", "aud": []string{"orders"}, "exp": now.Unix() + 60, "groups": []string{"a", "b"}}
+		for k, v := range extra {
+			c[k] = v
+		}
+		return c
+	}
+	bearer := func(token string) http.Header { return http.Header{"Authorization": {"Bearer " + token}} }
+
+	for _, k := range keys {
+		t.Run(k.alg, func(t *testing.T) {
+			res, err := a.Authenticate(bearer(k.sign(t, claims(nil))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Issuer != "https://issuer.example.com" || res.Header.Get("Authorization") != "" || res.Header.Get("X-Groups") != "a,b" {
+				t.Errorf("Authenticate() = %+v", res)
+			}
+		})
+	}
+
+	tampered := keys[0].sign(t, claims(nil))
+	parts := strings.Split(tampered, ".")
+	parts[1] = b64.EncodeToString([]byte(`{"iss":"https://issuer.example.com","aud":"orders","sub":"mallory"}`))
+	tampered = strings.Join(parts, ".")
+
+	for _, tc := range []struct {
+		desc    string
+		header  http.Header
+		wantErr error
+	}{
+		{desc: "tampered", header: bearer(tampered), wantErr: ErrInvalidSignature},
+		{desc: "unknown key", header: bearer(testKey{"es256", "ES256", otherKey}.sign(t, claims(nil))), wantErr: ErrInvalidSignature},
+		{desc: "expired", header: bearer(keys[0].sign(t, claims(map[string]any{"exp": now.Unix() - 120}))), wantErr: ErrExpired},
+		{desc: "within clock skew", header: bearer(keys[0].sign(t, claims(map[string]any{"exp": now.Unix() - 30})))},
+		{desc: "not yet valid", header: bearer(keys[0].sign(t, claims(map[string]any{"nbf": now.Unix() + 120}))), wantErr: ErrNotYetValid},
+		{desc: "audience", header: bearer(keys[0].sign(t, claims(map[string]any{"aud": "billing"}))), wantErr: ErrInvalidAudience},
+		{desc: "unknown issuer", header: bearer(keys[0].sign(t, claims(map[string]any{"iss": "https://evil.example.com"}))), wantErr: ErrUnknownIssuer},
+		{desc: "alg none", header: bearer(b64.EncodeToString([]byte(`{"alg":"none"}`)) + "." + b64.EncodeToString([]byte(`{}`)) + "."), wantErr: ErrUnsupportedAlg},
+		{desc: "malformed", header: bearer("not-a-token"), wantErr: ErrMalformed},
+		{desc: "cookie", header: http.Header{"Cookie": {"a=1; session=" + keys[0].sign(t, map[string]any{"iss": "https://partner.example.com"})}}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := a.Authenticate(tc.header)
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil && err != nil) {
+				t.Errorf("Authenticate() = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+
+	if res, err := a.Authenticate(http.Header{}); res != nil || err != nil {
+		t.Errorf("Authenticate() without token = %v, %v, want nil, nil", res, err)
+	}
+	res, err := a.Authenticate(http.Header{"Cookie": {"a=1; session=" + keys[0].sign(t, map[string]any{"iss": "https://partner.example.com"})}})
+	if err != nil || res.Header.Get("Cookie") != "a=1" {
+		t.Errorf("Authenticate() cookie header = %v, %v, want a=1", res, err)
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	small, _ := rsa.GenerateKey(rand.Reader, 1024)
+	p256, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	withD := testKey{"ec", "ES256", p256}.jwk()
+	withD["d"] = b64.EncodeToString(p256.D.Bytes())
+	wrongAlg := testKey{"ec", "ES256", p256}.jwk()
+	wrongAlg["alg"] = "RS256"
+	offCurve := testKey{"ec", "ES256", p256}.jwk()
+	offCurve["y"] = offCurve["x"]
+	encryption := testKey{"ec", "ES256", p256}.jwk()
+	encryption["use"] = "enc"
+
+	for _, tc := range []struct {
+		desc    string
+		key     map[string]string
+		wantErr bool
+	}{
+		{desc: "valid", key: testKey{"ec", "ES256", p256}.jwk()},
+		{desc: "small RSA key", key: testKey{"rs", "RS256", small}.jwk(), wantErr: true},
+		{desc: "private key", key: withD, wantErr: true},
+		{desc: "wrong algorithm", key: wrongAlg, wantErr: true},
+		{desc: "point not on curve", key: offCurve, wantErr: true},
+		{desc: "only encryption keys", key: encryption, wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			b, _ := json.Marshal(map[string]any{"keys": []map[string]string{tc.key}})
+			if _, err := ParseJWKS(b); (err != nil) != tc.wantErr {
+				t.Errorf("ParseJWKS() = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSpec(t *testing.T) {
+	spec := &networkingv1.GCPRequestAuthenticationPolicySpec{JWTRules: []networkingv1.JWTRule{
+		{
+			Issuer:         "https://issuer.example.com",
+			JWKSURI:        ptr.To("http://issuer.example.com/jwks"),
+			TokenLocations: []networkingv1.JWTTokenLocation{{Cookie: ptr.To("a")}, {Cookie: ptr.To("a")}},
+			ClaimToHeaders: []networkingv1.JWTClaimToHeader{{Header: "Host", Claim: "sub"}},
+		},
+		{Issuer: "https://issuer.example.com", JWKS: &networkingv1.JWKSConfigMapReference{Name: "jwks"}},
+	}}
+	want := []string{
+		"spec.jwtRules[0].jwksUri",
+		"spec.jwtRules[0].tokenLocations[1]",
+		"spec.jwtRules[0].claimToHeaders[0].header",
+		"spec.jwtRules[1].issuer",
+	}
+	var got []string
+	for _, err := range ValidateSpec(spec, field.NewPath("spec")) {
+		got = append(got, err.Field)
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("ValidateSpec() fields = %v, want %v", got, want)
+	}
+}
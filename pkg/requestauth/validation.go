@@ -0,0 +1,75 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package requestauth
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+// reservedHeaders are request headers that claimToHeaders cannot set.
+var reservedHeaders = []string{"host", "content-length", "transfer-encoding", "connection", "te", "upgrade"}
+
+// ValidateSpec validates the given policy beyond what the CRD schema
+// enforces.
+func ValidateSpec(spec *networkingv1.GCPRequestAuthenticationPolicySpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	issuers := map[string]bool{}
+	for i, r := range spec.JWTRules {
+		rPath := fldPath.Child("jwtRules").Index(i)
+		if issuers[r.Issuer] {
+			allErrs = append(allErrs, field.Duplicate(rPath.Child("issuer"), r.Issuer))
+		}
+		issuers[r.Issuer] = true
+
+		switch {
+		case (r.JWKSURI == nil) == (r.JWKS == nil):
+			allErrs = append(allErrs, field.Invalid(rPath, r.Issuer, "exactly one of jwksUri and jwks must be specified"))
+		case r.JWKSURI != nil:
+			if u, err := url.Parse(*r.JWKSURI); err != nil || u.Scheme != "https" || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(rPath.Child("jwksUri"), *r.JWKSURI, "must be an https URL"))
+			}
+		}
+
+		for j, loc := range r.TokenLocations {
+			lPath := rPath.Child("tokenLocations").Index(j)
+			if (loc.Header == nil) == (loc.Cookie == nil) {
+				allErrs = append(allErrs, field.Invalid(lPath, loc, "exactly one of header and cookie must be specified"))
+				continue
+			}
+			if slices.ContainsFunc(r.TokenLocations[:j], func(l networkingv1.JWTTokenLocation) bool { return sameLocation(l, loc) }) {
+				allErrs = append(allErrs, field.Duplicate(lPath, describeLocation(loc)))
+			}
+		}
+
+		for j, c := range r.ClaimToHeaders {
+			cPath := rPath.Child("claimToHeaders").Index(j)
+			if slices.Contains(reservedHeaders, strings.ToLower(string(c.Header))) {
+				allErrs = append(allErrs, field.Invalid(cPath.Child("header"), c.Header, "cannot be set from a claim"))
+			}
+			if slices.Contains(strings.Split(c.Claim, "."), "") {
+				allErrs = append(allErrs, field.Invalid(cPath.Child("claim"), c.Claim, "must be a claim name, with nested claims separated by single dots"))
+			}
+		}
+	}
+	return allErrs
+}
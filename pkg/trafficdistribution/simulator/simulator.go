@@ -0,0 +1,331 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package simulator computes the expected distribution of the traffic of a
+// client across the backends of a Service, given the
+// GCPTrafficDistributionPolicy of the Service.
+//
+// The simulator is deterministic and models:
+//
+//   - the preference of the client for its own region, and for its own zone
+//     with WATERFALL_BY_ZONE;
+//   - the capacity of each backend, i.e. its healthy endpoints (or static
+//     weight) multiplied by its capacity scaler and MaxRatePerEndpoint;
+//   - auto capacity drain, which drains the backends of a locality with fewer
+//     healthy endpoints than the drain threshold (25% by default);
+//   - failover, which moves traffic away from a region whose healthy
+//     percentage is below the failover health threshold (70 for Envoy and 50
+//     for proxyless gRPC by default). As with Envoy priority levels, a region
+//     receives min(1, healthy%/threshold) of the traffic that reaches it.
+//
+// For a single client, SPRAY_TO_REGION and WATERFALL_BY_REGION both spread
+// the traffic of a region across its zones in proportion to capacity. They
+// only differ in how the traffic of proxies in different zones is combined,
+// which is not modeled. LocalityLbAlgorithm selects endpoints within a
+// backend and does not affect the result.
+package simulator
+
+import (
+	"sort"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/trafficdistribution"
+)
+
+// DefaultMaxRatePerEndpoint is the MaxRatePerEndpoint of backends that do
+// not set it, which matches the GCPBackendPolicy default.
+const DefaultMaxRatePerEndpoint = 1e8
+
+const (
+	// SprayToRegion spreads traffic across all zones of a region.
+	SprayToRegion = "SPRAY_TO_REGION"
+	// WaterfallByZone prefers the zone of the client, then its region.
+	WaterfallByZone = "WATERFALL_BY_ZONE"
+	// WaterfallByRegion prefers the region of the client. This is the default.
+	WaterfallByRegion = "WATERFALL_BY_REGION"
+)
+
+// epsilon is the precision below which fractions of traffic are ignored.
+const epsilon = 1e-12
+
+// Backend is the set of endpoints of the Service in a locality.
+type Backend struct {
+	trafficdistribution.Backend
+	// MaxRatePerEndpoint is the target capacity of each endpoint in requests
+	// per second. DefaultMaxRatePerEndpoint is used if it is 0.
+	MaxRatePerEndpoint float64
+}
+
+// Client is the origin of the simulated traffic.
+type Client struct {
+	// Region is the region of the client, i.e. of the proxy that serves it.
+	Region string
+	// Zone is the zone of the client.
+	Zone string
+	// Proxyless is true for proxyless gRPC clients, which use a different
+	// default failover health threshold than Envoy.
+	Proxyless bool
+	// RequestRate is the request rate of the client in requests per second.
+	// If it is 0, the capacity of the backends is only used to weight them
+	// and traffic never overflows to other zones or regions.
+	RequestRate float64
+}
+
+// Input is the input of a simulation.
+type Input struct {
+	// Policy is the configuration of the GCPTrafficDistributionPolicy of the
+	// Service, or nil if there is none.
+	Policy *networkingv1.GCPTrafficDistributionPolicyConfig
+	// Backends are the backends of the Service.
+	Backends []Backend
+	// Client is the origin of the traffic.
+	Client Client
+}
+
+// Allocation is the traffic sent to a backend.
+type Allocation struct {
+	Backend
+	// Drained is true if the backend is drained by auto capacity drain.
+	Drained bool
+	// Capacity is the capacity of the backend in requests per second.
+	Capacity float64
+	// Fraction is the fraction of the traffic of the client sent to the
+	// backend, in the range [0, 1].
+	Fraction float64
+	// Rate is the request rate sent to the backend. 0 if Client.RequestRate
+	// is 0.
+	Rate float64
+}
+
+// Utilization returns the ratio of the rate to the capacity of the backend.
+func (a Allocation) Utilization() float64 {
+	if a.Capacity == 0 {
+		return 0
+	}
+	return a.Rate / a.Capacity
+}
+
+// Region summarizes the state of a region.
+type Region struct {
+	// Name is the name of the region.
+	Name string
+	// HealthyPercent is the percentage of healthy endpoints in the region.
+	// Endpoints of drained backends are considered unhealthy.
+	HealthyPercent float64
+	// Fraction is the fraction of the traffic of the client sent to the
+	// region.
+	Fraction float64
+}
+
+// Result is the outcome of a simulation.
+type Result struct {
+	// Allocations are the allocations of the backends, in the order of
+	// Input.Backends.
+	Allocations []Allocation
+	// Regions are the regions in order of preference of the client.
+	Regions []Region
+	// FailoverHealthThresholdPercent is the effective failover threshold.
+	FailoverHealthThresholdPercent int32
+	// Overloaded is true if the traffic of the client exceeds the capacity
+	// that the failover rules make available. The excess traffic is spread in
+	// proportion to the allocations.
+	Overloaded bool
+	// Unserved is 1 if no backend can receive traffic, and 0 otherwise.
+	Unserved float64
+}
+
+// Simulate computes the distribution of the traffic of the client.
+func Simulate(in Input) *Result {
+	cfg := in.Policy
+	threshold := trafficdistribution.FailoverHealthThresholdPercent(cfg, in.Client.Proxyless)
+	res := &Result{FailoverHealthThresholdPercent: threshold}
+
+	bases := make([]trafficdistribution.Backend, len(in.Backends))
+	for i, b := range in.Backends {
+		bases[i] = b.Backend
+	}
+	for i, s := range trafficdistribution.Distribute(cfg, bases) {
+		rate := in.Backends[i].MaxRatePerEndpoint
+		if rate == 0 {
+			rate = DefaultMaxRatePerEndpoint
+		}
+		res.Allocations = append(res.Allocations, Allocation{
+			Backend:  in.Backends[i],
+			Drained:  s.Drained,
+			Capacity: s.Capacity * rate,
+		})
+	}
+
+	algorithm := WaterfallByRegion
+	if cfg != nil && cfg.ServiceLbAlgorithm != nil {
+		algorithm = *cfg.ServiceLbAlgorithm
+	}
+	remaining := 1.0
+	for _, name := range regionOrder(cfg, in.Client.Region, in.Backends) {
+		idx := indices(res.Allocations, func(a Allocation) bool { return a.Region == name })
+		region := Region{Name: name, HealthyPercent: healthyPercent(res.Allocations, idx)}
+		if remaining > epsilon {
+			portion := remaining * regionLoad(region.HealthyPercent, threshold)
+			if capacity := totalCapacity(res.Allocations, idx); capacity == 0 {
+				portion = 0
+			} else if in.Client.RequestRate > 0 && portion > capacity/in.Client.RequestRate {
+				portion = capacity / in.Client.RequestRate
+			}
+			if algorithm == WaterfallByZone && name == in.Client.Region {
+				fillZones(res.Allocations, idx, in.Client.Zone, portion, in.Client.RequestRate)
+			} else {
+				spread(res.Allocations, idx, portion)
+			}
+			region.Fraction = portion
+			remaining -= portion
+		}
+		res.Regions = append(res.Regions, region)
+	}
+
+	if remaining > epsilon {
+		assigned := 1 - remaining
+		if assigned <= epsilon {
+			res.Unserved = 1
+			return res
+		}
+		// Spread the excess in proportion to the allocations, as Envoy
+		// normalizes the load of priority levels whose health does not add up
+		// to 100%.
+		res.Overloaded = in.Client.RequestRate > 0 && totalCapacity(res.Allocations, allIndices(res.Allocations)) < in.Client.RequestRate
+		for i := range res.Allocations {
+			res.Allocations[i].Fraction /= assigned
+		}
+		for i := range res.Regions {
+			res.Regions[i].Fraction /= assigned
+		}
+	}
+	for i := range res.Allocations {
+		res.Allocations[i].Rate = res.Allocations[i].Fraction * in.Client.RequestRate
+	}
+	return res
+}
+
+// regionOrder returns the regions of the backends in order of preference of a
+// client in the given region: the region itself, then the configured failover
+// regions, then the other regions in lexical order.
+func regionOrder(cfg *networkingv1.GCPTrafficDistributionPolicyConfig, client string, backends []Backend) []string {
+	present := map[string]bool{}
+	for _, b := range backends {
+		present[b.Region] = true
+	}
+	var ret []string
+	add := func(r string) {
+		if present[r] {
+			ret = append(ret, r)
+			delete(present, r)
+		}
+	}
+	add(client)
+	for _, r := range trafficdistribution.FailoverRegions(cfg, client) {
+		add(r)
+	}
+	var rest []string
+	for r := range present {
+		rest = append(rest, r)
+	}
+	sort.Strings(rest)
+	return append(ret, rest...)
+}
+
+// regionLoad returns the fraction of the traffic that reaches a region that
+// the region receives, given its healthy percentage.
+func regionLoad(healthy float64, threshold int32) float64 {
+	if threshold <= 0 {
+		if healthy > 0 {
+			return 1
+		}
+		return 0
+	}
+	if load := healthy / float64(threshold); load < 1 {
+		return load
+	}
+	return 1
+}
+
+// fillZones sends the given portion of traffic to the backends in the zone
+// of the client first, up to their capacity, and spreads the rest across the
+// other zones of the region in proportion to capacity.
+func fillZones(allocs []Allocation, idx []int, zone string, portion, rate float64) {
+	var local, other []int
+	for _, i := range idx {
+		if allocs[i].Zone == zone {
+			local = append(local, i)
+		} else {
+			other = append(other, i)
+		}
+	}
+	localPortion := portion
+	if capacity := totalCapacity(allocs, local); capacity == 0 {
+		localPortion = 0
+	} else if rate > 0 && localPortion > capacity/rate {
+		localPortion = capacity / rate
+	}
+	spread(allocs, local, localPortion)
+	spread(allocs, other, portion-localPortion)
+}
+
+// spread adds the given portion of traffic to the backends in proportion to
+// their capacity.
+func spread(allocs []Allocation, idx []int, portion float64) {
+	capacity := totalCapacity(allocs, idx)
+	if capacity == 0 || portion <= 0 {
+		return
+	}
+	for _, i := range idx {
+		allocs[i].Fraction += portion * allocs[i].Capacity / capacity
+	}
+}
+
+func healthyPercent(allocs []Allocation, idx []int) float64 {
+	var endpoints, healthy int32
+	for _, i := range idx {
+		endpoints += allocs[i].Endpoints
+		if !allocs[i].Drained {
+			healthy += allocs[i].HealthyEndpoints
+		}
+	}
+	if endpoints == 0 {
+		return 0
+	}
+	return float64(healthy) * 100 / float64(endpoints)
+}
+
+func totalCapacity(allocs []Allocation, idx []int) float64 {
+	var total float64
+	for _, i := range idx {
+		total += allocs[i].Capacity
+	}
+	return total
+}
+
+func indices(allocs []Allocation, pred func(Allocation) bool) []int {
+	var ret []int
+	for i, a := range allocs {
+		if pred(a) {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+func allIndices(allocs []Allocation) []int {
+	return indices(allocs, func(Allocation) bool { return true })
+}
@@ -0,0 +1,154 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package simulator
+
+import (
+	"math"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+	"github.com/GoogleCloudPlatform/gke-gateway-api/pkg/trafficdistribution"
+)
+
+func backend(cluster, zone, region string, endpoints, healthy int32, maxRate float64) Backend {
+	return Backend{
+		Backend: trafficdistribution.Backend{
+			Locality:         trafficdistribution.Locality{Cluster: cluster, Zone: zone},
+			Region:           region,
+			Endpoints:        endpoints,
+			HealthyEndpoints: healthy,
+		},
+		MaxRatePerEndpoint: maxRate,
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	for _, tc := range []struct {
+		desc           string
+		policy         *networkingv1.GCPTrafficDistributionPolicyConfig
+		backends       []Backend
+		client         Client
+		want           []float64
+		wantOverloaded bool
+		wantUnserved   float64
+	}{
+		{
+			desc: "local region by capacity",
+			backends: []Backend{
+				backend("c1", "us-central1-a", "us-central1", 3, 3, 10),
+				backend("c1", "us-central1-b", "us-central1", 1, 1, 10),
+				backend("c2", "us-east1-b", "us-east1", 4, 4, 10),
+			},
+			client: Client{Region: "us-central1", Zone: "us-central1-a"},
+			want:   []float64{0.75, 0.25, 0},
+		},
+		{
+			desc: "envoy failover threshold",
+			backends: []Backend{
+				backend("c1", "us-central1-a", "us-central1", 10, 6, 10),
+				backend("c2", "us-east1-b", "us-east1", 10, 10, 10),
+			},
+			client: Client{Region: "us-central1"},
+			want:   []float64{60.0 / 70, 10.0 / 70},
+		},
+		{
+			desc: "proxyless failover threshold",
+			backends: []Backend{
+				backend("c1", "us-central1-a", "us-central1", 10, 6, 10),
+				backend("c2", "us-east1-b", "us-east1", 10, 10, 10),
+			},
+			client: Client{Region: "us-central1", Proxyless: true},
+			want:   []float64{1, 0},
+		},
+		{
+			desc: "auto capacity drain",
+			policy: &networkingv1.GCPTrafficDistributionPolicyConfig{
+				AutoCapacityDrain: &networkingv1.AutoCapacityDrain{EnableAutoCapacityDrain: ptr.To(true)},
+				FailoverConfig:    &networkingv1.FailoverConfig{FailoverHealthThreshold: ptr.To[int32](50)},
+			},
+			backends: []Backend{
+				backend("c1", "us-central1-a", "us-central1", 10, 10, 10),
+				backend("c1", "us-central1-b", "us-central1", 10, 2, 10),
+				backend("c2", "us-east1-b", "us-east1", 10, 10, 10),
+			},
+			client: Client{Region: "us-central1"},
+			// The drained zone leaves the region 50% healthy.
+			want: []float64{1, 0, 0},
+		},
+		{
+			desc: "waterfall by zone overflows to the region",
+			policy: &networkingv1.GCPTrafficDistributionPolicyConfig{
+				ServiceLbAlgorithm: ptr.To(WaterfallByZone),
+			},
+			backends: []Backend{
+				backend("c1", "us-central1-a", "us-central1", 10, 10, 10),
+				backend("c1", "us-central1-b", "us-central1", 10, 10, 10),
+			},
+			client: Client{Region: "us-central1", Zone: "us-central1-a", RequestRate: 150},
+			want:   []float64{100.0 / 150, 50.0 / 150},
+		},
+		{
+			desc: "failover regions",
+			policy: &networkingv1.GCPTrafficDistributionPolicyConfig{
+				FailoverConfig: &networkingv1.FailoverConfig{FailoverRegions: []string{"us-west1"}},
+			},
+			backends: []Backend{
+				backend("c1", "us-central1-a", "us-central1", 10, 10, 1),
+				backend("c2", "us-east1-b", "us-east1", 10, 10, 1),
+				backend("c3", "us-west1-a", "us-west1", 10, 10, 1),
+			},
+			client: Client{Region: "us-central1", RequestRate: 15},
+			want:   []float64{10.0 / 15, 0, 5.0 / 15},
+		},
+		{
+			desc: "overloaded",
+			backends: []Backend{
+				backend("c1", "us-central1-a", "us-central1", 1, 1, 10),
+				backend("c2", "us-east1-b", "us-east1", 3, 3, 10),
+			},
+			client:         Client{Region: "us-central1", RequestRate: 80},
+			want:           []float64{0.25, 0.75},
+			wantOverloaded: true,
+		},
+		{
+			desc: "unserved",
+			backends: []Backend{
+				backend("c1", "us-central1-a", "us-central1", 1, 0, 10),
+			},
+			client:       Client{Region: "us-central1"},
+			want:         []float64{0},
+			wantUnserved: 1,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			res := Simulate(Input{Policy: tc.policy, Backends: tc.backends, Client: tc.client})
+			for i, a := range res.Allocations {
+				if math.Abs(a.Fraction-tc.want[i]) > 1e-9 {
+					t.Errorf("Allocations[%d].Fraction = %v, want %v", i, a.Fraction, tc.want[i])
+				}
+			}
+			if res.Overloaded != tc.wantOverloaded {
+				t.Errorf("Overloaded = %t, want %t", res.Overloaded, tc.wantOverloaded)
+			}
+			if res.Unserved != tc.wantUnserved {
+				t.Errorf("Unserved = %v, want %v", res.Unserved, tc.wantUnserved)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+// Package trafficdistribution resolves GCPTrafficDistributionPolicy
+// configuration into the effective distribution of traffic across backends.
+package trafficdistribution
+
+import (
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+const (
+	// DefaultDrainThresholdPercent is the percentage of healthy endpoints
+	// below which a locality is drained when auto capacity drain is enabled.
+	DefaultDrainThresholdPercent = 25
+	// DefaultFailoverHealthThresholdPercent is the failover health threshold
+	// of Envoy based load balancers.
+	DefaultFailoverHealthThresholdPercent = 70
+	// DefaultProxylessFailoverHealthThresholdPercent is the failover health
+	// threshold of proxyless gRPC clients.
+	DefaultProxylessFailoverHealthThresholdPercent = 50
+)
+
+// Locality identifies the zone of a cluster that hosts backends.
+type Locality struct {
+	// Cluster is the name of the cluster.
+	Cluster string
+	// Zone is the zone of the backends.
+	Zone string
+}
+
+// Backend is the set of endpoints of a Service in a locality.
+type Backend struct {
+	Locality
+	// Region is the region of Zone.
+	Region string
+	// Endpoints is the number of endpoints.
+	Endpoints int32
+	// HealthyEndpoints is the number of healthy endpoints.
+	HealthyEndpoints int32
+}
+
+// HealthyPercent returns the percentage of healthy endpoints of the backend.
+// A backend without endpoints is 0% healthy.
+func (b Backend) HealthyPercent() float64 {
+	if b.Endpoints == 0 {
+		return 0
+	}
+	return float64(b.HealthyEndpoints) * 100 / float64(b.Endpoints)
+}
+
+// LocalitySettings are the effective weight settings of a locality.
+type LocalitySettings struct {
+	// Weight is the static weight of the locality, or nil if the locality is
+	// weighted by its number of healthy endpoints.
+	Weight *int32
+	// CapacityScaler scales the capacity of the locality, in the range [0, 1].
+	CapacityScaler float64
+}
+
+// Settings returns the effective weight settings of the given locality. The
+// LocalityWeights entry that matches both the zone and the cluster takes
+// precedence over the entries that match only one of them. Fields that are not
+// set by the most specific entry are inherited from less specific ones.
+func Settings(cfg *networkingv1.GCPTrafficDistributionPolicyConfig, loc Locality) LocalitySettings {
+	ret := LocalitySettings{CapacityScaler: 1}
+	if cfg == nil {
+		return ret
+	}
+	// Apply the matching entries from the least to the most specific.
+	for _, specificity := range []int{1, 2} {
+		for _, w := range cfg.LocalityWeights {
+			if matches(w, loc) != specificity {
+				continue
+			}
+			if w.Weight != nil {
+				weight := *w.Weight
+				ret.Weight = &weight
+			}
+			if w.CapacityScalerPercent != nil {
+				ret.CapacityScaler = float64(*w.CapacityScalerPercent) / 100
+			}
+		}
+	}
+	return ret
+}
+
+// matches returns the number of fields of w that match loc, or 0 if any of
+// the fields that are set does not match.
+func matches(w networkingv1.LocalityWeight, loc Locality) int {
+	n := 0
+	if w.Zone != nil {
+		if *w.Zone != loc.Zone {
+			return 0
+		}
+		n++
+	}
+	if w.Cluster != nil {
+		if *w.Cluster != loc.Cluster {
+			return 0
+		}
+		n++
+	}
+	return n
+}
+
+// DrainThresholdPercent returns the percentage of healthy endpoints below which
+// a locality is drained, or 0 if auto capacity drain is disabled.
+func DrainThresholdPercent(cfg *networkingv1.GCPTrafficDistributionPolicyConfig) int32 {
+	if cfg == nil || cfg.AutoCapacityDrain == nil {
+		return 0
+	}
+	acd := cfg.AutoCapacityDrain
+	if acd.EnableAutoCapacityDrain == nil || !*acd.EnableAutoCapacityDrain {
+		return 0
+	}
+	if acd.DrainThresholdPercent != nil {
+		return *acd.DrainThresholdPercent
+	}
+	return DefaultDrainThresholdPercent
+}
+
+// FailoverHealthThresholdPercent returns the failover health threshold of the
+// given configuration. proxyless selects the default of proxyless gRPC clients
+// instead of the default of Envoy.
+func FailoverHealthThresholdPercent(cfg *networkingv1.GCPTrafficDistributionPolicyConfig, proxyless bool) int32 {
+	if cfg != nil && cfg.FailoverConfig != nil && cfg.FailoverConfig.FailoverHealthThreshold != nil {
+		return *cfg.FailoverConfig.FailoverHealthThreshold
+	}
+	if proxyless {
+		return DefaultProxylessFailoverHealthThresholdPercent
+	}
+	return DefaultFailoverHealthThresholdPercent
+}
+
+// FailoverRegions returns the regions that receive traffic when the backends
+// of the given region fail over, in order. The region itself is excluded. It
+// returns nil if the configuration does not specify failover regions.
+func FailoverRegions(cfg *networkingv1.GCPTrafficDistributionPolicyConfig, region string) []string {
+	if cfg == nil || cfg.FailoverConfig == nil {
+		return nil
+	}
+	var ret []string
+	for _, r := range cfg.FailoverConfig.FailoverRegions {
+		if r != region {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+// Share is the share of traffic of a backend.
+type Share struct {
+	Backend
+	// Settings are the effective weight settings of the backend.
+	Settings LocalitySettings
+	// Drained is true if the backend is drained by auto capacity drain.
+	Drained bool
+	// Capacity is the effective capacity of the backend: its static weight,
+	// or its number of healthy endpoints, scaled by its capacity scaler.
+	Capacity float64
+	// Fraction is the fraction of traffic sent to the backend, in the range
+	// [0, 1].
+	Fraction float64
+}
+
+// Distribute computes the effective distribution of traffic across the given
+// backends. Drained backends and backends without capacity receive no
+// traffic. If no backend has capacity, every Fraction is 0.
+func Distribute(cfg *networkingv1.GCPTrafficDistributionPolicyConfig, backends []Backend) []Share {
+	threshold := float64(DrainThresholdPercent(cfg))
+	shares := make([]Share, 0, len(backends))
+	var total float64
+	for _, b := range backends {
+		s := Share{Backend: b, Settings: Settings(cfg, b.Locality)}
+		s.Drained = threshold > 0 && b.HealthyPercent() < threshold
+		if !s.Drained {
+			capacity := float64(b.HealthyEndpoints)
+			if s.Settings.Weight != nil {
+				capacity = float64(*s.Settings.Weight)
+			}
+			s.Capacity = capacity * s.Settings.CapacityScaler
+		}
+		total += s.Capacity
+		shares = append(shares, s)
+	}
+	if total > 0 {
+		for i := range shares {
+			shares[i].Fraction = shares[i].Capacity / total
+		}
+	}
+	return shares
+}
@@ -0,0 +1,91 @@
+/*
+* Copyright 2026 Google LLC
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*     https://www.apache.org/licenses/LICENSE-2.0
+*
+*     Unless required by applicable law or agreed to in writing, software
+*     distributed under the License is distributed on an "AS IS" BASIS,
+*     WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*     See the License for the specific language governing permissions and
+*     limitations under the License.
+ */
+
+package trafficdistribution
+
+import (
+	"math"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	networkingv1 "github.com/GoogleCloudPlatform/gke-gateway-api/apis/networking/v1"
+)
+
+func TestDistribute(t *testing.T) {
+	backends := []Backend{
+		{Locality: Locality{Cluster: "c1", Zone: "us-central1-a"}, Region: "us-central1", Endpoints: 4, HealthyEndpoints: 4},
+		{Locality: Locality{Cluster: "c1", Zone: "us-central1-b"}, Region: "us-central1", Endpoints: 4, HealthyEndpoints: 2},
+		{Locality: Locality{Cluster: "c2", Zone: "us-east1-b"}, Region: "us-east1", Endpoints: 8, HealthyEndpoints: 1},
+	}
+	for _, tc := range []struct {
+		desc string
+		cfg  *networkingv1.GCPTrafficDistributionPolicyConfig
+		want []float64
+	}{
+		{
+			desc: "healthy endpoints",
+			want: []float64{4.0 / 7, 2.0 / 7, 1.0 / 7},
+		},
+		{
+			desc: "auto capacity drain",
+			cfg: &networkingv1.GCPTrafficDistributionPolicyConfig{
+				AutoCapacityDrain: &networkingv1.AutoCapacityDrain{EnableAutoCapacityDrain: ptr.To(true)},
+			},
+			want: []float64{4.0 / 6, 2.0 / 6, 0},
+		},
+		{
+			desc: "custom drain threshold",
+			cfg: &networkingv1.GCPTrafficDistributionPolicyConfig{
+				AutoCapacityDrain: &networkingv1.AutoCapacityDrain{EnableAutoCapacityDrain: ptr.To(true), DrainThresholdPercent: ptr.To[int32](60)},
+			},
+			want: []float64{1, 0, 0},
+		},
+		{
+			desc: "locality weights",
+			cfg: &networkingv1.GCPTrafficDistributionPolicyConfig{
+				LocalityWeights: []networkingv1.LocalityWeight{
+					{Cluster: ptr.To("c1"), Weight: ptr.To[int32](10)},
+					{Cluster: ptr.To("c1"), Zone: ptr.To("us-central1-b"), CapacityScalerPercent: ptr.To[int32](50)},
+					{Zone: ptr.To("us-east1-b"), CapacityScalerPercent: ptr.To[int32](0)},
+				},
+			},
+			want: []float64{10.0 / 15, 5.0 / 15, 0},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			shares := Distribute(tc.cfg, backends)
+			for i, s := range shares {
+				if math.Abs(s.Fraction-tc.want[i]) > 1e-9 {
+					t.Errorf("Distribute()[%d].Fraction = %v, want %v", i, s.Fraction, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFailoverRegions(t *testing.T) {
+	cfg := &networkingv1.GCPTrafficDistributionPolicyConfig{
+		FailoverConfig: &networkingv1.FailoverConfig{FailoverRegions: []string{"us-east1", "us-central1", "europe-west1"}},
+	}
+	got := FailoverRegions(cfg, "us-central1")
+	if len(got) != 2 || got[0] != "us-east1" || got[1] != "europe-west1" {
+		t.Errorf("FailoverRegions() = %v, want [us-east1 europe-west1]", got)
+	}
+	if got := FailoverHealthThresholdPercent(nil, true); got != DefaultProxylessFailoverHealthThresholdPercent {
+		t.Errorf("FailoverHealthThresholdPercent(nil, true) = %d, want %d", got, DefaultProxylessFailoverHealthThresholdPercent)
+	}
+}